@@ -0,0 +1,62 @@
+package datacop
+
+// Annotation kinds recorded by the library. Applications may use their own kinds as well.
+const (
+	AnnotationWarning    = "warning"    // a non-fatal problem worth showing to the user
+	AnnotationAudit      = "audit"      // a fact worth recording for compliance, such as consent being given
+	AnnotationModeration = "moderation" // a category a content moderation service flagged
+	AnnotationSuggestion = "suggestion" // a light weighted rule that failed in scoring mode; see WithScoring
+)
+
+// Annotation is metadata recorded during validation that is not an error, such as a warning or
+// an audit note. Annotations never affect HasErrors.
+type Annotation struct {
+	Field   string         `json:"field,omitempty"`
+	Kind    string         `json:"kind"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// Annotate records an annotation of the given kind for a field. Use an empty field for
+// annotations that apply to the whole input.
+//
+// Example usage:
+// v.Annotate("bio", datacop.AnnotationWarning, "consider adding more detail", nil)
+func (v *Validator) Annotate(field, kind, message string, data map[string]any) {
+	v.annotations = append(v.annotations, Annotation{
+		Field:   field,
+		Kind:    kind,
+		Message: message,
+		Data:    data,
+	})
+}
+
+// Annotations returns all annotations in the order they were recorded
+func (v *Validator) Annotations() []Annotation {
+	v.Wait()
+	return v.annotations
+}
+
+// AnnotationsFor returns the annotations recorded for a field
+func (v *Validator) AnnotationsFor(field string) []Annotation {
+	v.Wait()
+	var annotations []Annotation
+	for _, a := range v.annotations {
+		if a.Field == field {
+			annotations = append(annotations, a)
+		}
+	}
+	return annotations
+}
+
+// AnnotationsOfKind returns the annotations of the given kind
+func (v *Validator) AnnotationsOfKind(kind string) []Annotation {
+	v.Wait()
+	var annotations []Annotation
+	for _, a := range v.annotations {
+		if a.Kind == kind {
+			annotations = append(annotations, a)
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,60 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestValidator_Annotations(t *testing.T) {
+	v := datacop.New()
+	v.Annotate("bio", datacop.AnnotationWarning, "consider adding more detail", nil)
+	v.Annotate("terms", datacop.AnnotationAudit, "consent given", map[string]any{"value": "on"})
+
+	assert.False(t, v.HasErrors())
+	assert.Len(t, v.Annotations(), 2)
+
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "terms", Kind: datacop.AnnotationAudit, Message: "consent given", Data: map[string]any{"value": "on"}},
+	}, v.AnnotationsFor("terms"))
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "bio", Kind: datacop.AnnotationWarning, Message: "consider adding more detail"},
+	}, v.AnnotationsOfKind(datacop.AnnotationWarning))
+	assert.Empty(t, v.AnnotationsFor("missing"))
+
+	other := datacop.New()
+	other.Annotate("", datacop.AnnotationWarning, "import used defaults", nil)
+	v.Merge(other)
+	assert.Len(t, v.Annotations(), 3)
+
+	v.Clear()
+	assert.Empty(t, v.Annotations())
+}
+
+func TestAnnotated(t *testing.T) {
+	longText := datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		s, ok := value.(string)
+		if ok && len(s) > 5 {
+			annotate(datacop.AnnotationWarning, "may be truncated", map[string]any{"length": len(s)})
+		}
+		return ok
+	})
+
+	// Run with Check, annotations are discarded
+	assert.True(t, longText("a long text"))
+	assert.False(t, longText(1))
+
+	v := datacop.New()
+	v.Field("title", "a long text").Validate(longText, "must be text")
+	v.Field("count", 3).When(true).Validate(longText.Named("text", nil), "must be text")
+	datacop.RuleSet{{Func: longText, Message: "must be text"}}.Apply(v, "summary", "another long text")
+
+	assert.Equal(t, map[string]string{"count": "must be text"}, v.Errors())
+	assert.Equal(t, "text", v.OrderedErrors()[0].Code)
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "title", Kind: datacop.AnnotationWarning, Message: "may be truncated", Data: map[string]any{"length": 11}},
+		{Field: "summary", Kind: datacop.AnnotationWarning, Message: "may be truncated", Data: map[string]any{"length": 17}},
+	}, v.Annotations())
+}
@@ -0,0 +1,64 @@
+package datacop
+
+import "fmt"
+
+// asyncCheck is a check started by CheckAsync
+type asyncCheck struct {
+	field   string
+	message string
+	done    chan struct{}
+	valid   bool
+	panic   any
+}
+
+// CheckAsync starts fn in a new goroutine, for expensive checks such as DNS lookups or database
+// queries, and returns immediately. Checks started together run concurrently, so the total
+// latency is that of the slowest check rather than their sum. Their results are recorded by
+// Wait, which every method that reads the validator's errors or annotations calls first, so a
+// failure is never missed. A check that panics fails with message, and the panic is recorded as
+// a warning annotation for field.
+//
+// fn must not use the validator. The validator itself is still not safe for concurrent use:
+// CheckAsync and the methods that wait must be called from the goroutine that owns it.
+//
+// Example usage:
+//
+//	v.CheckAsync(func() bool { return is.EmailWithContext(ctx, opts)(email) }, "email", "domain does not accept mail")
+//	v.CheckAsync(func() bool { return !breaches.Contains(ctx, password) }, "password", "appears in a data breach")
+//	v.CheckAsync(func() bool { return users.UsernameFree(ctx, username) }, "username", "is taken")
+//	if v.HasErrors() {
+//		...
+//	}
+func (v *Validator) CheckAsync(fn func() bool, field, message string) {
+	c := &asyncCheck{field: field, message: message, done: make(chan struct{})}
+	v.pending = append(v.pending, c)
+
+	go func() {
+		defer close(c.done)
+		defer func() {
+			c.panic = recover()
+		}()
+		c.valid = fn()
+	}()
+}
+
+// Wait waits for every check started by CheckAsync and records the failures, in the order the
+// checks were started, so the errors do not depend on which check finished first. It is safe to
+// call on a nil validator.
+func (v *Validator) Wait() {
+	if v == nil {
+		return
+	}
+	pending := v.pending
+	v.pending = nil
+
+	for _, c := range pending {
+		<-c.done
+		if c.panic != nil {
+			v.Annotate(c.field, AnnotationWarning, "check panicked", map[string]any{"panic": fmt.Sprint(c.panic)})
+		}
+		if c.panic != nil || !c.valid {
+			v.AddError(c.field, c.message)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package datacop_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestValidator_CheckAsync(t *testing.T) {
+	v := datacop.New()
+
+	var running, maxRunning atomic.Int32
+	slow := func(valid bool, delay time.Duration) func() bool {
+		return func() bool {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(delay)
+			running.Add(-1)
+			return valid
+		}
+	}
+
+	v.AddError("name", "is required")
+	v.CheckAsync(slow(false, 30*time.Millisecond), "email", "domain does not accept mail")
+	v.CheckAsync(slow(true, 20*time.Millisecond), "password", "appears in a data breach")
+	v.CheckAsync(slow(false, 10*time.Millisecond), "username", "is taken")
+	v.Wait()
+
+	assert.Equal(t, int32(3), maxRunning.Load(), "checks run concurrently")
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "name", Message: "is required"},
+		{Field: "email", Message: "domain does not accept mail"},
+		{Field: "username", Message: "is taken"},
+	}, v.OrderedErrors(), "errors are recorded in the order the checks started")
+
+	// Wait without pending checks does nothing
+	v.Wait()
+	assert.Len(t, v.OrderedErrors(), 3)
+}
+
+func TestValidator_CheckAsync_Panic(t *testing.T) {
+	v := datacop.New()
+	v.CheckAsync(func() bool { panic("lookup failed") }, "email", "could not be verified")
+	v.CheckAsync(func() bool { return false }, "username", "is taken")
+
+	assert.NotPanics(t, v.Wait)
+	assert.Equal(t, map[string]string{"email": "could not be verified", "username": "is taken"}, v.Errors())
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "email", Kind: datacop.AnnotationWarning, Message: "check panicked", Data: map[string]any{"panic": "lookup failed"}},
+	}, v.Annotations())
+}
+
+func TestValidator_CheckAsync_ReadersWait(t *testing.T) {
+	readers := map[string]func(v *datacop.Validator) bool{
+		"HasErrors":        func(v *datacop.Validator) bool { return v.HasErrors() },
+		"ErrOrNil":         func(v *datacop.Validator) bool { return v.ErrOrNil() != nil },
+		"HasErrorFor":      func(v *datacop.Validator) bool { return v.HasErrorFor("username") },
+		"ErrorFor":         func(v *datacop.Validator) bool { return v.ErrorFor("username") != "" },
+		"Errors":           func(v *datacop.Validator) bool { return len(v.Errors()) > 0 },
+		"OrderedErrors":    func(v *datacop.Validator) bool { return len(v.OrderedErrors()) > 0 },
+		"ErrorCount":       func(v *datacop.Validator) bool { return v.ErrorCount() > 0 },
+		"Result":           func(v *datacop.Validator) bool { return !v.Result().Passed },
+		"AnnotationsFor":   func(v *datacop.Validator) bool { return len(v.AnnotationsFor("username")) > 0 },
+		"ValidationErrors": func(v *datacop.Validator) bool { return len(v.ValidationErrors()) > 0 },
+	}
+
+	for name, read := range readers {
+		t.Run(name, func(t *testing.T) {
+			v := datacop.New()
+			v.CheckAsync(func() bool {
+				time.Sleep(5 * time.Millisecond)
+				panic("lookup failed")
+			}, "username", "could not be checked")
+			assert.True(t, read(v), "the reader waits for pending checks")
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Package batch validates collections of records, such as the rows of an imported file,
+// using datacop validators for each record and dataset rules that look across all records, such
+// as RequireNonNullRate and DistinctRate, which check aggregate quality thresholds. Results can
+// be encoded as JSON, as SARIF for code review tools with ToSARIF, or as a JUnit XML report for
+// CI with ToJUnitXML. Long validations can report their progress with WithProgress and be
+// cancelled through the context of ValidateContext and its variants, and Sample estimates the
+// error rate of a large batch from a sample of its records.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// Record is a single row of input keyed by field name
+type Record map[string]any
+
+// RowFunc validates a single record, recording failures on v
+type RowFunc func(v *datacop.Validator, r Record)
+
+// DatasetRule inspects every record in the batch at once and reports its findings on res
+type DatasetRule func(records []Record, res *Result)
+
+// Warning is a non-fatal finding for a field of a record
+type Warning struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RowResult holds the findings for a single record
+type RowResult struct {
+	Index    int                `json:"index"`
+	Errors   *datacop.Validator `json:"errors,omitempty"`
+	Warnings []Warning          `json:"warnings,omitempty"`
+}
+
+// Result holds the findings for a batch. Only records with errors or warnings appear in Rows.
+// Dataset holds the standalone errors of dataset rules about the batch as a whole, such as a
+// field that is missing from too many records, and is nil if there are none.
+type Result struct {
+	Total   int                `json:"total"`
+	Rows    []RowResult        `json:"rows,omitempty"`
+	Dataset *datacop.Validator `json:"dataset,omitempty"`
+	Dropped int                `json:"dropped,omitempty"` // records with errors not kept in Rows; see ValidateStream
+
+	byIndex map[int]int // record index to position in Rows
+	dropped []uint64    // bit i is set if record i is counted in Dropped
+}
+
+// Batch validates collections of records
+type Batch struct {
+	row   RowFunc
+	rules []DatasetRule
+
+	progress      Progress
+	progressEvery int
+}
+
+// ProgressReport describes how far a batch validation has got
+type ProgressReport struct {
+	Processed int           // records validated so far
+	Total     int           // records in the batch, or 0 if unknown, as with ValidateSeq
+	Errors    int           // records with errors so far
+	Elapsed   time.Duration // time since the validation started
+	ETA       time.Duration // estimated time remaining, or 0 if Total is unknown
+}
+
+// Progress is called as a batch validation advances; see WithProgress
+type Progress func(r ProgressReport)
+
+// New creates a batch validator that runs row against each record, followed by the dataset rules.
+// Either may be omitted.
+//
+// Example usage:
+//
+//	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+//		v.Check(is.Required(r["sku"]), "sku", "sku is required")
+//	}, batch.FlagOutliers("price", 3))
+//
+//	res := b.Validate(records)
+func New(row RowFunc, rules ...DatasetRule) *Batch {
+	return &Batch{row: row, rules: rules}
+}
+
+// WithProgress returns a copy of the batch validator that calls fn after every "every" records,
+// and once more when all records are validated, before the dataset rules run, so a UI can show
+// a progress bar. fn is called synchronously, so it should be fast.
+//
+// Example usage:
+//
+//	b = b.WithProgress(func(p batch.ProgressReport) {
+//		bar.Set(p.Processed, p.Total, p.ETA)
+//	}, 1000)
+func (b *Batch) WithProgress(fn Progress, every int) *Batch {
+	c := *b
+	c.progress, c.progressEvery = fn, max(every, 1)
+	return &c
+}
+
+// Validate runs the row validator over every record and then applies the dataset rules
+func (b *Batch) Validate(records []Record) *Result {
+	res, _ := b.ValidateContext(context.Background(), records)
+	return res
+}
+
+// ValidateContext is like Validate, but stops when ctx is done, checking it before each record,
+// and returns ctx.Err() with the findings for the records validated so far. Dataset rules are not
+// applied to such an incomplete batch.
+//
+// Example usage:
+//
+//	res, err := b.ValidateContext(r.Context(), records)
+//	if errors.Is(err, context.Canceled) {
+//		return // the user cancelled the import
+//	}
+func (b *Batch) ValidateContext(ctx context.Context, records []Record) (*Result, error) {
+	seq := func(yield func(Record, error) bool) {
+		for _, r := range records {
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+	return b.run(ctx, seq, len(records), nil, 0)
+}
+
+// ValidateSeq is like Validate, but validates records as seq produces them, such as the rows of
+// a database query, so only records with findings are kept in memory. Dataset rules need every
+// record, so a batch with dataset rules keeps all of them.
+//
+// Reading stops at the first error seq yields, which is returned with the findings for the
+// records read so far. Dataset rules are not applied to such an incomplete batch.
+//
+// Example usage:
+//
+//	res, err := b.ValidateSeq(func(yield func(batch.Record, error) bool) {
+//		for rec, err := range readCSV(f) {
+//			if !yield(rec, err) {
+//				return
+//			}
+//		}
+//	})
+func (b *Batch) ValidateSeq(seq iter.Seq2[Record, error]) (*Result, error) {
+	return b.ValidateSeqContext(context.Background(), seq)
+}
+
+// ValidateSeqContext is like ValidateSeq, but also stops when ctx is done, as ValidateContext
+// does
+func (b *Batch) ValidateSeqContext(ctx context.Context, seq iter.Seq2[Record, error]) (*Result, error) {
+	return b.run(ctx, seq, 0, nil, 0)
+}
+
+// ValidateStream is like ValidateSeq, but writes every record error to sink, for batches with
+// too many errors to hold in memory. Each error carries the index of its record as the context
+// value "record". Only the first sample records with errors are kept in Rows; the others are
+// counted in Result.Dropped, so encoders such as ToSARIF report the sample. Dataset errors are
+// kept in Result.Dataset and not written to sink.
+//
+// Reading stops at the first error sink returns, which is returned with the findings so far.
+//
+// Example usage:
+//
+//	enc := json.NewEncoder(f)
+//	res, err := b.ValidateStream(rows, datacop.ErrorSinkFunc(func(e datacop.ValidationError) error {
+//		return enc.Encode(e)
+//	}), 100)
+func (b *Batch) ValidateStream(seq iter.Seq2[Record, error], sink datacop.ErrorSink, sample int) (*Result, error) {
+	return b.ValidateStreamContext(context.Background(), seq, sink, sample)
+}
+
+// ValidateStreamContext is like ValidateStream, but also stops when ctx is done, as
+// ValidateContext does
+func (b *Batch) ValidateStreamContext(ctx context.Context, seq iter.Seq2[Record, error], sink datacop.ErrorSink, sample int) (*Result, error) {
+	return b.run(ctx, seq, 0, sink, sample)
+}
+
+// run validates the records seq produces, of which there are total if known, writing their
+// errors to sink if it is not nil
+func (b *Batch) run(ctx context.Context, seq iter.Seq2[Record, error], total int, sink datacop.ErrorSink, sample int) (*Result, error) {
+	res := &Result{}
+	tracker := b.newTracker(total)
+	var records []Record
+	for r, err := range seq {
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			res.sort()
+			return res, err
+		}
+		if len(b.rules) > 0 {
+			records = append(records, r)
+		}
+		var v *datacop.Validator
+		if sink != nil {
+			v = b.streamRow(res, res.Total, r, sink, sample)
+		} else {
+			v = b.validateRow(res, res.Total, r)
+		}
+		res.Total++
+		tracker.record(v.HasErrors())
+		if err := v.SinkErr(); err != nil {
+			res.sort()
+			return res, fmt.Errorf("batch: writing errors: %w", err)
+		}
+	}
+	tracker.done()
+
+	for _, rule := range b.rules {
+		rule(records, res)
+	}
+	res.sort()
+	return res, nil
+}
+
+// validateRow runs the row validator over the record at index. It returns the validator, or nil
+// if the batch has no row validator.
+func (b *Batch) validateRow(res *Result, index int, r Record) *datacop.Validator {
+	if b.row == nil {
+		return nil
+	}
+	v := datacop.New()
+	b.row(v, r)
+	if v.HasErrors() {
+		res.row(index).Errors = v
+	}
+	return v
+}
+
+// streamRow runs the row validator over the record at index, writing its errors to sink, and
+// keeps its findings if fewer than sample records with errors are kept. It returns the validator,
+// or nil if the batch has no row validator.
+func (b *Batch) streamRow(res *Result, index int, r Record, sink datacop.ErrorSink, sample int) *datacop.Validator {
+	if b.row == nil {
+		return nil
+	}
+	keep := len(res.Rows) < sample
+	inMemory := 0
+	if keep {
+		inMemory = -1
+	}
+	v := datacop.New(datacop.WithErrorSink(sink, inMemory))
+	v.SetContextValue("record", index)
+	b.row(v, r)
+	switch {
+	case !v.HasErrors():
+	case keep:
+		res.row(index).Errors = v
+	default:
+		res.drop(index)
+	}
+	return v
+}
+
+// sort orders the findings by record index
+func (r *Result) sort() {
+	sort.Slice(r.Rows, func(i, j int) bool { return r.Rows[i].Index < r.Rows[j].Index })
+	r.reindex()
+}
+
+// Warn records a warning for the field of the record at index
+func (r *Result) Warn(index int, field, message string) {
+	row := r.row(index)
+	row.Warnings = append(row.Warnings, Warning{Field: field, Message: message})
+}
+
+// AddDatasetError records a standalone error about the batch as a whole, with a machine-readable
+// code such as "non_null_rate"
+func (r *Result) AddDatasetError(code, message string) {
+	if r.Dataset == nil {
+		r.Dataset = datacop.New()
+	}
+	r.Dataset.AddCodedError(datacop.StandaloneErrorKey, code, message)
+}
+
+// HasErrors returns true if any record failed validation or a dataset rule reported an error
+func (r *Result) HasErrors() bool {
+	if r.Dropped > 0 || (r.Dataset != nil && r.Dataset.HasErrors()) {
+		return true
+	}
+	for _, row := range r.Rows {
+		if row.Errors != nil && row.Errors.HasErrors() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWarnings returns true if any record has warnings
+func (r *Result) HasWarnings() bool {
+	for _, row := range r.Rows {
+		if len(row.Warnings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Row returns the findings for the record at index, if it has any
+func (r *Result) Row(index int) (RowResult, bool) {
+	if pos, ok := r.byIndex[index]; ok {
+		return r.Rows[pos], true
+	}
+	return RowResult{}, false
+}
+
+// row returns the findings for the record at index, creating an entry if needed
+func (r *Result) row(index int) *RowResult {
+	if r.byIndex == nil {
+		r.byIndex = make(map[int]int)
+	}
+	pos, ok := r.byIndex[index]
+	if !ok {
+		pos = len(r.Rows)
+		r.Rows = append(r.Rows, RowResult{Index: index})
+		r.byIndex[index] = pos
+	}
+	return &r.Rows[pos]
+}
+
+// drop counts the record at index in Dropped
+func (r *Result) drop(index int) {
+	r.Dropped++
+	for len(r.dropped) <= index/64 {
+		r.dropped = append(r.dropped, 0)
+	}
+	r.dropped[index/64] |= 1 << (index % 64)
+}
+
+// wasDropped reports whether the record at index had errors that were not kept in Rows
+func (r *Result) wasDropped(index int) bool {
+	return index/64 < len(r.dropped) && r.dropped[index/64]&(1<<(index%64)) != 0
+}
+
+func (r *Result) reindex() {
+	for pos, row := range r.Rows {
+		r.byIndex[row.Index] = pos
+	}
+}
@@ -0,0 +1,144 @@
+package batch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/batch"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestBatch_Validate(t *testing.T) {
+	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+		v.Check(is.Required(r["sku"]), "sku", "sku is required")
+	})
+
+	res := b.Validate([]batch.Record{
+		{"sku": "A-1"},
+		{"sku": ""},
+		{"sku": "A-3"},
+		{},
+	})
+
+	assert.Equal(t, 4, res.Total)
+	assert.True(t, res.HasErrors())
+	assert.False(t, res.HasWarnings())
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, 1, res.Rows[0].Index)
+	assert.Equal(t, 3, res.Rows[1].Index)
+
+	row, ok := res.Row(1)
+	require.True(t, ok)
+	assert.Equal(t, "sku is required", row.Errors.ErrorFor("sku"))
+
+	_, ok = res.Row(0)
+	assert.False(t, ok)
+}
+
+func TestResult_Warn(t *testing.T) {
+	b := batch.New(nil, func(records []batch.Record, res *batch.Result) {
+		res.Warn(2, "name", "looks like a placeholder")
+		res.Warn(0, "name", "looks like a placeholder")
+	})
+
+	res := b.Validate(make([]batch.Record, 3))
+
+	assert.False(t, res.HasErrors())
+	assert.True(t, res.HasWarnings())
+	require.Len(t, res.Rows, 2)
+	assert.Equal(t, 0, res.Rows[0].Index)
+	assert.Equal(t, 2, res.Rows[1].Index)
+
+	row, ok := res.Row(2)
+	require.True(t, ok)
+	assert.Equal(t, []batch.Warning{{Field: "name", Message: "looks like a placeholder"}}, row.Warnings)
+}
+
+func TestBatch_ValidateSeq(t *testing.T) {
+	records := []batch.Record{{"sku": "A-1", "price": 10}, {"sku": ""}, {"sku": "A-3", "price": 11}}
+	seq := func(fail error) func(yield func(batch.Record, error) bool) {
+		return func(yield func(batch.Record, error) bool) {
+			for i, r := range records {
+				if fail != nil && i == 2 {
+					yield(nil, fail)
+					return
+				}
+				if !yield(r, nil) {
+					return
+				}
+			}
+		}
+	}
+	warnAll := func(records []batch.Record, res *batch.Result) {
+		for i := range records {
+			res.Warn(i, "", "seen")
+		}
+	}
+	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+		v.Check(is.Required(r["sku"]), "sku", "sku is required")
+	}, warnAll)
+
+	res, err := b.ValidateSeq(seq(nil))
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Total)
+	require.Len(t, res.Rows, 3)
+	assert.Equal(t, "sku is required", res.Rows[1].Errors.ErrorFor("sku"))
+	assert.Len(t, res.Rows[2].Warnings, 1)
+
+	failure := errors.New("connection reset")
+	res, err = b.ValidateSeq(seq(failure))
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 2, res.Total)
+	require.Len(t, res.Rows, 1, "dataset rules do not run on an incomplete batch")
+	assert.Equal(t, 1, res.Rows[0].Index)
+}
+
+func TestBatch_ValidateStream(t *testing.T) {
+	records := []batch.Record{{"sku": ""}, {"sku": "A-2"}, {"sku": "", "name": ""}, {}}
+	seq := func(yield func(batch.Record, error) bool) {
+		for _, r := range records {
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+		v.Check(is.Required(r["sku"]), "sku", "sku is required")
+		if name, ok := r["name"]; ok {
+			v.Check(is.Required(name), "name", "name is required")
+		}
+	})
+
+	var written []datacop.ValidationError
+	sink := datacop.ErrorSinkFunc(func(e datacop.ValidationError) error {
+		written = append(written, e)
+		return nil
+	})
+
+	res, err := b.ValidateStream(seq, sink, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Total)
+	assert.True(t, res.HasErrors())
+	assert.Equal(t, 2, res.Dropped)
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, 0, res.Rows[0].Index)
+	assert.Equal(t, "sku is required", res.Rows[0].Errors.ErrorFor("sku"))
+
+	require.Len(t, written, 4)
+	var indexes []any
+	for _, e := range written {
+		indexes = append(indexes, e.Context["record"])
+	}
+	assert.Equal(t, []any{0, 2, 2, 3}, indexes)
+
+	failure := errors.New("disk full")
+	res, err = b.ValidateStream(seq, datacop.ErrorSinkFunc(func(datacop.ValidationError) error {
+		return failure
+	}), 10)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, res.Total, "reading stops at the first sink error")
+}
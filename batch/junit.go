@@ -0,0 +1,141 @@
+package batch
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// JUnitOption configures ToJUnitXML
+type JUnitOption func(*junitConfig)
+
+type junitConfig struct {
+	suite string
+}
+
+// WithSuiteName sets the name of the test suite, such as the name of the file the records were
+// read from. The default is "datacop".
+//
+// Example usage:
+// batch.ToJUnitXML(res, batch.WithSuiteName("fixtures/users.csv"))
+func WithSuiteName(name string) JUnitOption {
+	return func(c *junitConfig) {
+		c.suite = name
+	}
+}
+
+// ToJUnitXML encodes the findings of a batch as a JUnit XML report, so CI jobs that validate
+// fixture or seed data show failures in the test views of standard CI tools. Every record is a
+// test case named after its index, such as "records[3]". A record with errors fails, with the
+// first error as the failure's message and its code as the type, and every error listed in the
+// failure's body. Warnings do not fail a record and are written to its system-out. Records whose
+// errors ValidateStream wrote to its sink without keeping them, counted in Result.Dropped, are
+// skipped, since their errors are not known. Dataset errors fail an additional test case named
+// "dataset".
+//
+// Example usage:
+//
+//	data, err := batch.ToJUnitXML(res, batch.WithSuiteName("fixtures/users.csv"))
+//	if err != nil {
+//		return err
+//	}
+//	os.WriteFile("datacop-junit.xml", data, 0o644)
+func ToJUnitXML(res *Result, opts ...JUnitOption) ([]byte, error) {
+	cfg := junitConfig{suite: "datacop"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	suite := junitSuite{Name: cfg.suite}
+	if res != nil {
+		suite.Tests = res.Total
+		suite.Cases = make([]junitCase, res.Total)
+		for i := range suite.Cases {
+			suite.Cases[i] = junitCase{Name: fmt.Sprintf("records[%d]", i), ClassName: cfg.suite}
+			if res.wasDropped(i) {
+				suite.Cases[i].Skipped = &junitSkipped{Message: "errors were written to the error sink and not kept"}
+				suite.Skipped++
+			}
+		}
+		for _, row := range res.Rows {
+			if row.Index < 0 || row.Index >= len(suite.Cases) {
+				continue
+			}
+			c := &suite.Cases[row.Index]
+			if row.Errors != nil && row.Errors.HasErrors() {
+				c.Failure = junitFailure(row.Errors.OrderedErrors())
+				suite.Failures++
+			}
+			if len(row.Warnings) > 0 {
+				lines := make([]string, len(row.Warnings))
+				for j, w := range row.Warnings {
+					lines[j] = "warning: " + describe(w.Field, w.Message)
+				}
+				c.SystemOut = strings.Join(lines, "\n")
+			}
+		}
+		if res.Dataset != nil && res.Dataset.HasErrors() {
+			suite.Cases = append(suite.Cases, junitCase{
+				Name:      "dataset",
+				ClassName: cfg.suite,
+				Failure:   junitFailure(res.Dataset.OrderedErrors()),
+			})
+			suite.Tests++
+			suite.Failures++
+		}
+	}
+
+	data, err := xml.MarshalIndent(junitSuites{Suites: []junitSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// junitFailure describes the errors of a record
+func junitFailure(errs []datacop.ValidationError) *junitFailureElement {
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = describe(e.Field, e.Message)
+	}
+	code := errs[0].Code
+	if code == "" {
+		code = datacop.DefaultErrorCode
+	}
+	return &junitFailureElement{Message: lines[0], Type: code, Body: strings.Join(lines, "\n")}
+}
+
+// The subset of the JUnit XML format written by ToJUnitXML
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr,omitempty"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string               `xml:"name,attr"`
+	ClassName string               `xml:"classname,attr"`
+	Failure   *junitFailureElement `xml:"failure,omitempty"`
+	Skipped   *junitSkipped        `xml:"skipped,omitempty"`
+	SystemOut string               `xml:"system-out,omitempty"`
+}
+
+type junitFailureElement struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
@@ -0,0 +1,86 @@
+package batch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/batch"
+)
+
+func TestToJUnitXML(t *testing.T) {
+	data, err := batch.ToJUnitXML(sarifBatch(), batch.WithSuiteName("fixtures/products.csv"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="fixtures/products.csv" tests="3" failures="2">
+    <testcase name="records[0]" classname="fixtures/products.csv">
+      <system-out>warning: price: looks unusually high</system-out>
+    </testcase>
+    <testcase name="records[1]" classname="fixtures/products.csv">
+      <failure message="sku: is required" type="invalid">sku: is required&#xA;name: must be at least 3 characters</failure>
+    </testcase>
+    <testcase name="records[2]" classname="fixtures/products.csv">
+      <failure message="discontinued products cannot be imported" type="invalid">discontinued products cannot be imported</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`, string(data))
+}
+
+func TestToJUnitXML_NoRecords(t *testing.T) {
+	data, err := batch.ToJUnitXML(batch.New(nil).Validate(nil))
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="datacop" tests="0" failures="0"></testsuite>
+</testsuites>`, string(data))
+}
+
+func TestToJUnitXML_DatasetErrors(t *testing.T) {
+	res := batch.New(nil, batch.DistinctRate("sku", 1)).Validate([]batch.Record{{"sku": "A-1"}, {"sku": "A-1"}})
+
+	data, err := batch.ToJUnitXML(res)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="datacop" tests="3" failures="1">
+    <testcase name="records[0]" classname="datacop"></testcase>
+    <testcase name="records[1]" classname="datacop"></testcase>
+    <testcase name="dataset" classname="datacop">
+      <failure message="sku values are 50% distinct (1 of 2), below the required 100%" type="distinct_rate">sku values are 50% distinct (1 of 2), below the required 100%</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`, string(data))
+}
+
+func TestToJUnitXML_Dropped(t *testing.T) {
+	records := []batch.Record{{}, {"sku": "A-2"}, {}}
+	sink := datacop.ErrorSinkFunc(func(datacop.ValidationError) error { return nil })
+	res, err := skuBatch().ValidateStream(func(yield func(batch.Record, error) bool) {
+		for _, r := range records {
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}, sink, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Dropped)
+
+	data, err := batch.ToJUnitXML(res)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="datacop" tests="3" failures="1" skipped="1">
+    <testcase name="records[0]" classname="datacop">
+      <failure message="sku: sku is required" type="invalid">sku: sku is required</failure>
+    </testcase>
+    <testcase name="records[1]" classname="datacop"></testcase>
+    <testcase name="records[2]" classname="datacop">
+      <skipped message="errors were written to the error sink and not kept"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`, string(data))
+}
@@ -0,0 +1,52 @@
+package batch
+
+import "time"
+
+// tracker reports the progress of a batch validation to the batch's Progress callback
+type tracker struct {
+	fn      Progress
+	every   int
+	started time.Time
+	report  ProgressReport
+}
+
+// newTracker returns a tracker for a batch of total records, or 0 if unknown. It returns nil if
+// the batch has no Progress callback.
+func (b *Batch) newTracker(total int) *tracker {
+	if b.progress == nil {
+		return nil
+	}
+	return &tracker{fn: b.progress, every: b.progressEvery, started: time.Now(), report: ProgressReport{Total: total}}
+}
+
+// record counts a validated record and reports progress after every "every" records
+func (t *tracker) record(failed bool) {
+	if t == nil {
+		return
+	}
+	t.report.Processed++
+	if failed {
+		t.report.Errors++
+	}
+	if t.report.Processed%t.every == 0 {
+		t.send()
+	}
+}
+
+// done reports the final progress, unless it was just reported
+func (t *tracker) done() {
+	if t == nil || (t.report.Processed > 0 && t.report.Processed%t.every == 0) {
+		return
+	}
+	t.send()
+}
+
+func (t *tracker) send() {
+	r := t.report
+	r.Elapsed = time.Since(t.started)
+	if r.Total > 0 && r.Processed > 0 {
+		remaining := max(r.Total-r.Processed, 0)
+		r.ETA = r.Elapsed / time.Duration(r.Processed) * time.Duration(remaining)
+	}
+	t.fn(r)
+}
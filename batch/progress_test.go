@@ -0,0 +1,107 @@
+package batch_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/batch"
+	"github.com/patrickward/datacop/is"
+)
+
+func skuBatch() *batch.Batch {
+	return batch.New(func(v *datacop.Validator, r batch.Record) {
+		v.Check(is.Required(r["sku"]), "sku", "sku is required")
+	})
+}
+
+func TestBatch_WithProgress(t *testing.T) {
+	records := []batch.Record{{"sku": "A-1"}, {}, {"sku": "A-3"}, {}, {"sku": "A-5"}}
+
+	tests := []struct {
+		name          string
+		every         int
+		wantProcessed []int
+		wantErrors    []int
+	}{
+		{"every two records", 2, []int{2, 4, 5}, []int{1, 2, 2}},
+		{"every record", 0, []int{1, 2, 3, 4, 5}, []int{0, 1, 1, 2, 2}},
+		{"last report not repeated", 5, []int{5}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reports []batch.ProgressReport
+			b := skuBatch().WithProgress(func(p batch.ProgressReport) {
+				reports = append(reports, p)
+			}, tt.every)
+
+			res := b.Validate(records)
+			assert.Equal(t, 5, res.Total)
+
+			var processed, errs []int
+			for _, p := range reports {
+				processed = append(processed, p.Processed)
+				errs = append(errs, p.Errors)
+				assert.Equal(t, 5, p.Total)
+				assert.GreaterOrEqual(t, p.ETA, time.Duration(0))
+			}
+			assert.Equal(t, tt.wantProcessed, processed)
+			assert.Equal(t, tt.wantErrors, errs)
+			assert.Zero(t, reports[len(reports)-1].ETA)
+		})
+	}
+}
+
+func TestBatch_WithProgress_UnknownTotal(t *testing.T) {
+	var reports []batch.ProgressReport
+	b := skuBatch().WithProgress(func(p batch.ProgressReport) {
+		reports = append(reports, p)
+	}, 10)
+
+	res, err := b.ValidateSeq(func(yield func(batch.Record, error) bool) {
+		yield(batch.Record{}, nil)
+	})
+	require.NoError(t, err)
+	assert.True(t, res.HasErrors())
+	require.Len(t, reports, 1)
+	assert.Equal(t, batch.ProgressReport{Processed: 1, Errors: 1, Elapsed: reports[0].Elapsed}, reports[0])
+
+	// The original batch validator is not changed
+	reports = nil
+	skuBatch().Validate([]batch.Record{{}})
+	assert.Empty(t, reports)
+}
+
+func TestBatch_ValidateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := skuBatch().WithProgress(func(p batch.ProgressReport) {
+		if p.Processed == 2 {
+			cancel()
+		}
+	}, 1)
+
+	res, err := b.ValidateContext(ctx, []batch.Record{{"sku": "A-1"}, {}, {}, {}})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, res.Total)
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, 1, res.Rows[0].Index)
+
+	res, err = skuBatch().ValidateSeqContext(ctx, func(yield func(batch.Record, error) bool) {
+		yield(batch.Record{}, nil)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, res.Total)
+
+	res, err = skuBatch().ValidateStreamContext(ctx, func(yield func(batch.Record, error) bool) {
+		yield(batch.Record{}, nil)
+	}, datacop.ErrorSinkFunc(func(datacop.ValidationError) error { return nil }), 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, res.Total)
+}
@@ -0,0 +1,124 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+// FlagOutliers returns a dataset rule that warns about records whose numeric value for field
+// lies more than zscore standard deviations from the batch mean. It is meant to help reviewers
+// spot typos such as an extra zero, so it never produces errors. Records without a numeric
+// value for field are ignored.
+//
+// Note that a single extreme value also inflates the standard deviation, so small batches
+// need a lower threshold to flag anything: with n values, no z-score can exceed (n-1)/sqrt(n).
+//
+// Example usage:
+// batch.New(rowFn, batch.FlagOutliers("price", 3))
+func FlagOutliers(field string, zscore float64) DatasetRule {
+	return func(records []Record, res *Result) {
+		indexes := make([]int, 0, len(records))
+		values := make([]float64, 0, len(records))
+		for i, r := range records {
+			if f, ok := numeric.Float(r[field]); ok {
+				indexes = append(indexes, i)
+				values = append(values, f)
+			}
+		}
+		if len(values) < 2 {
+			return
+		}
+
+		var sum float64
+		for _, f := range values {
+			sum += f
+		}
+		mean := sum / float64(len(values))
+
+		var variance float64
+		for _, f := range values {
+			variance += (f - mean) * (f - mean)
+		}
+		stddev := math.Sqrt(variance / float64(len(values)))
+		if stddev == 0 {
+			return
+		}
+
+		for i, f := range values {
+			if z := math.Abs(f-mean) / stddev; z > zscore {
+				res.Warn(indexes[i], field, fmt.Sprintf("value %v is unusually far from the batch average of %.4g (z-score %.2f)", f, mean, z))
+			}
+		}
+	}
+}
+
+// RequireNonNullRate returns a dataset rule that reports a dataset error when fewer than min of
+// the records, a fraction from 0 to 1, have a value for field. Missing fields, nil, and blank
+// strings count as null. An empty batch passes.
+//
+// Example usage:
+// batch.New(rowFn, batch.RequireNonNullRate("email", 0.95))
+func RequireNonNullRate(field string, min float64) DatasetRule {
+	return func(records []Record, res *Result) {
+		if len(records) == 0 {
+			return
+		}
+		present := 0
+		for _, r := range records {
+			if !isNull(r[field]) {
+				present++
+			}
+		}
+		if rate := float64(present) / float64(len(records)); rate < min {
+			res.AddDatasetError("non_null_rate", fmt.Sprintf("%s is present in %s%% of records (%d of %d), below the required %s%%",
+				field, percent(rate), present, len(records), percent(min)))
+		}
+	}
+}
+
+// DistinctRate returns a dataset rule that reports a dataset error when fewer than min of the
+// non-null values of field, a fraction from 0 to 1, are distinct, such as for a SKU column that
+// should be nearly unique. Values are compared by their printed form, so 1 and "1" are equal.
+// Null values, as defined by RequireNonNullRate, are ignored, and a batch without values passes.
+//
+// Example usage:
+// batch.New(rowFn, batch.DistinctRate("sku", 0.99))
+func DistinctRate(field string, min float64) DatasetRule {
+	return func(records []Record, res *Result) {
+		seen := make(map[string]bool)
+		values := 0
+		for _, r := range records {
+			if value := r[field]; !isNull(value) {
+				seen[fmt.Sprint(value)] = true
+				values++
+			}
+		}
+		if values == 0 {
+			return
+		}
+		if rate := float64(len(seen)) / float64(values); rate < min {
+			res.AddDatasetError("distinct_rate", fmt.Sprintf("%s values are %s%% distinct (%d of %d), below the required %s%%",
+				field, percent(rate), len(seen), values, percent(min)))
+		}
+	}
+}
+
+// isNull reports whether a record value is missing: nil or a blank string
+func isNull(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
+
+// percent formats a fraction as a percentage with at most two decimals
+func percent(f float64) string {
+	return strconv.FormatFloat(math.Round(f*10000)/100, 'f', -1, 64)
+}
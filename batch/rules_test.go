@@ -0,0 +1,117 @@
+package batch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/batch"
+)
+
+func TestFlagOutliers(t *testing.T) {
+	records := []batch.Record{
+		{"price": 10.5},
+		{"price": 11},
+		{"price": "9.75"},
+		{"price": 10},
+		{"price": 105},
+		{"price": 10.25},
+		{"price": 11.5},
+		{"price": 9.5},
+		{"price": "n/a"},
+		{},
+	}
+
+	res := batch.New(nil, batch.FlagOutliers("price", 2.5)).Validate(records)
+
+	assert.False(t, res.HasErrors())
+	require.Len(t, res.Rows, 1)
+	assert.Equal(t, 4, res.Rows[0].Index)
+	require.Len(t, res.Rows[0].Warnings, 1)
+	assert.Equal(t, "price", res.Rows[0].Warnings[0].Field)
+	assert.Contains(t, res.Rows[0].Warnings[0].Message, "value 105")
+}
+
+func TestFlagOutliers_UniformValues(t *testing.T) {
+	records := []batch.Record{{"qty": 1}, {"qty": 1}, {"qty": 1}}
+
+	res := batch.New(nil, batch.FlagOutliers("qty", 1)).Validate(records)
+	assert.False(t, res.HasWarnings())
+}
+
+func TestRequireNonNullRate(t *testing.T) {
+	records := []batch.Record{
+		{"email": "a@example.com"},
+		{"email": "b@example.com"},
+		{"email": " "},
+		{"email": nil},
+		{},
+		{"email": "c@example.com"},
+		{"email": "d@example.com"},
+		{"email": "e@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		records []batch.Record
+		min     float64
+		want    string
+	}{
+		{"below the threshold", records, 0.95, "email is present in 62.5% of records (5 of 8), below the required 95%"},
+		{"at the threshold", records, 0.625, ""},
+		{"empty batch", nil, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := batch.New(nil, batch.RequireNonNullRate("email", tt.min)).Validate(tt.records)
+			assert.Empty(t, res.Rows)
+			if tt.want == "" {
+				assert.False(t, res.HasErrors())
+				assert.Nil(t, res.Dataset)
+				return
+			}
+			assert.True(t, res.HasErrors())
+			require.Len(t, res.Dataset.OrderedErrors(), 1)
+			assert.Equal(t, "non_null_rate", res.Dataset.OrderedErrors()[0].Code)
+			assert.Equal(t, []string{tt.want}, res.Dataset.StandaloneErrors())
+		})
+	}
+}
+
+func TestDistinctRate(t *testing.T) {
+	records := []batch.Record{
+		{"sku": "A-1"},
+		{"sku": "A-2"},
+		{"sku": "A-2"},
+		{"sku": 7},
+		{"sku": "7"},
+		{"sku": ""},
+		{},
+	}
+
+	tests := []struct {
+		name    string
+		records []batch.Record
+		min     float64
+		want    string
+	}{
+		{"below the threshold", records, 0.99, "sku values are 60% distinct (3 of 5), below the required 99%"},
+		{"at the threshold", records, 0.6, ""},
+		{"no values", []batch.Record{{}, {"sku": nil}}, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := batch.New(nil, batch.DistinctRate("sku", tt.min)).Validate(tt.records)
+			if tt.want == "" {
+				assert.False(t, res.HasErrors())
+				return
+			}
+			require.Len(t, res.Dataset.OrderedErrors(), 1)
+			assert.Equal(t, "distinct_rate", res.Dataset.OrderedErrors()[0].Code)
+			assert.Equal(t, []string{tt.want}, res.Dataset.StandaloneErrors())
+		})
+	}
+}
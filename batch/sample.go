@@ -0,0 +1,81 @@
+package batch
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Estimate is an early estimate of the error rate of a batch from a sample of its records
+type Estimate struct {
+	Total     int         `json:"total"`          // records in the batch
+	Sampled   int         `json:"sampled"`        // records validated
+	Failed    int         `json:"failed"`         // sampled records with errors
+	ErrorRate float64     `json:"error_rate"`     // Failed / Sampled, or 0 if nothing was sampled
+	Low       float64     `json:"low"`            // lower bound of the 95% confidence interval of ErrorRate
+	High      float64     `json:"high"`           // upper bound of the 95% confidence interval of ErrorRate
+	Rows      []RowResult `json:"rows,omitempty"` // findings for the sampled records with errors
+}
+
+// Sample validates a stratified random sample of n records and estimates the error rate of the
+// whole batch, so users get fast feedback before validating a large file. The records are split
+// into n equal runs and one record is picked at random from each, so the sample covers the start,
+// middle, and end of the file even if errors cluster in one part of it.
+//
+// The interval is the Wilson score interval, which stays meaningful when no sampled record fails.
+// If n is at least the number of records, every record is validated and the rate is exact. Rows
+// are indexed by their position in records. Dataset rules are not applied to a sample.
+//
+// Example usage:
+//
+//	est := b.Sample(records, 500)
+//	fmt.Printf("about %.1f%% of rows have errors (%.1f%%-%.1f%%)\n", est.ErrorRate*100, est.Low*100, est.High*100)
+func (b *Batch) Sample(records []Record, n int) Estimate {
+	res := &Result{}
+	indexes := sampleIndexes(len(records), n)
+	est := Estimate{Total: len(records), Sampled: len(indexes)}
+	for _, i := range indexes {
+		if b.validateRow(res, i, records[i]).HasErrors() {
+			est.Failed++
+		}
+	}
+	res.sort()
+	est.Rows = res.Rows
+
+	if est.Sampled == 0 {
+		return est
+	}
+	est.ErrorRate = float64(est.Failed) / float64(est.Sampled)
+	if est.Sampled == est.Total {
+		est.Low, est.High = est.ErrorRate, est.ErrorRate
+	} else {
+		est.Low, est.High = wilson(est.Failed, est.Sampled)
+	}
+	return est
+}
+
+// sampleIndexes returns one random index from each of n equal runs of total indexes, in order,
+// or every index if n is at least total
+func sampleIndexes(total, n int) []int {
+	if n >= total {
+		n = total
+	}
+	if n <= 0 {
+		return nil
+	}
+	indexes := make([]int, n)
+	for s := range indexes {
+		start, end := s*total/n, (s+1)*total/n
+		indexes[s] = start + rand.IntN(end-start)
+	}
+	return indexes
+}
+
+// wilson returns the 95% Wilson score interval of the proportion of failed in n
+func wilson(failed, n int) (low, high float64) {
+	const z = 1.96
+	p, fn := float64(failed)/float64(n), float64(n)
+	denom := 1 + z*z/fn
+	center := (p + z*z/(2*fn)) / denom
+	half := z * math.Sqrt(p*(1-p)/fn+z*z/(4*fn*fn)) / denom
+	return math.Max(0, center-half), math.Min(1, center+half)
+}
@@ -0,0 +1,70 @@
+package batch_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/batch"
+)
+
+// skuRecords returns n records, every fifth of which has no sku
+func skuRecords(n int) []batch.Record {
+	records := make([]batch.Record, n)
+	for i := range records {
+		records[i] = batch.Record{}
+		if i%5 != 0 {
+			records[i]["sku"] = fmt.Sprintf("A-%d", i)
+		}
+	}
+	return records
+}
+
+func TestBatch_Sample(t *testing.T) {
+	records := skuRecords(1000)
+
+	est := skuBatch().Sample(records, 100)
+	assert.Equal(t, 1000, est.Total)
+	assert.Equal(t, 100, est.Sampled)
+	assert.Equal(t, est.Failed, len(est.Rows))
+	assert.InDelta(t, float64(est.Failed)/100, est.ErrorRate, 1e-9)
+	assert.Less(t, est.Low, est.ErrorRate)
+	assert.Greater(t, est.High, est.ErrorRate)
+
+	// One record from each run of ten, in order
+	for i, row := range est.Rows {
+		assert.Equal(t, 0, row.Index%5, "only records without a sku fail")
+		if i > 0 {
+			assert.Greater(t, row.Index/10, est.Rows[i-1].Index/10)
+		}
+	}
+}
+
+func TestBatch_Sample_Exact(t *testing.T) {
+	est := skuBatch().Sample(skuRecords(10), 50)
+
+	assert.Equal(t, 10, est.Sampled)
+	assert.Equal(t, 2, est.Failed)
+	assert.Equal(t, 0.2, est.ErrorRate)
+	assert.Equal(t, 0.2, est.Low)
+	assert.Equal(t, 0.2, est.High)
+	require.Len(t, est.Rows, 2)
+	assert.Equal(t, 5, est.Rows[1].Index)
+}
+
+func TestBatch_Sample_NoFailures(t *testing.T) {
+	records := make([]batch.Record, 200)
+	for i := range records {
+		records[i] = batch.Record{"sku": "A"}
+	}
+
+	est := skuBatch().Sample(records, 20)
+	assert.Zero(t, est.ErrorRate)
+	assert.Zero(t, est.Low)
+	assert.InDelta(t, 0.161, est.High, 0.001, "the interval is not empty when no sampled record fails")
+
+	assert.Equal(t, batch.Estimate{Total: 200}, skuBatch().Sample(records, 0))
+	assert.Equal(t, batch.Estimate{}, skuBatch().Sample(nil, 10))
+}
@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/patrickward/datacop"
+)
+
+// SARIFVersion is the version of the SARIF format written by ToSARIF
+const SARIFVersion = "2.1.0"
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// warningRuleID is the rule ID of warnings in SARIF output, which have no code of their own
+const warningRuleID = "warning"
+
+// SARIFOption configures ToSARIF
+type SARIFOption func(*sarifConfig)
+
+type sarifConfig struct {
+	uri       string
+	firstLine int
+}
+
+// WithArtifact locates results in the file the records were read from. firstLine is the line of
+// the first record, such as 2 for a CSV file with a header row; record i is reported at line
+// firstLine+i, so it assumes one record per line. SARIF lines start at 1, so a firstLine below
+// 1 leaves out the lines and locates results by file only.
+//
+// Example usage:
+// batch.ToSARIF(res, batch.WithArtifact("data/products.csv", 2))
+func WithArtifact(uri string, firstLine int) SARIFOption {
+	return func(c *sarifConfig) {
+		c.uri, c.firstLine = uri, firstLine
+	}
+}
+
+// ToSARIF encodes the findings of a batch as a SARIF 2.1.0 log, so data-quality results can be
+// shown by code review and security tools that read SARIF. Each error becomes a result with
+// level "error" and its code as the rule ID, and each warning a result with level "warning" and
+// the rule ID "warning". Results are located by a logical location such as "records[3].email",
+// and by a line in a file if WithArtifact is given. Dataset errors are located at "records".
+//
+// Example usage:
+//
+//	data, err := batch.ToSARIF(res, batch.WithArtifact("data/products.csv", 2))
+//	if err != nil {
+//		return err
+//	}
+//	os.WriteFile("datacop.sarif", data, 0o644)
+func ToSARIF(res *Result, opts ...SARIFOption) ([]byte, error) {
+	var cfg sarifConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "datacop", Rules: []sarifRule{}}}, Results: []sarifResult{}}
+	seen := make(map[string]bool)
+	add := func(ruleID, level, message string, index int, field string) {
+		if !seen[ruleID] {
+			seen[ruleID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: ruleID})
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     level,
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{cfg.location(index, field)},
+		})
+	}
+
+	if res != nil {
+		for _, row := range res.Rows {
+			if row.Errors != nil {
+				for _, e := range row.Errors.OrderedErrors() {
+					code := e.Code
+					if code == "" {
+						code = datacop.DefaultErrorCode
+					}
+					add(code, "error", describe(e.Field, e.Message), row.Index, e.Field)
+				}
+			}
+			for _, w := range row.Warnings {
+				add(warningRuleID, "warning", describe(w.Field, w.Message), row.Index, w.Field)
+			}
+		}
+		if res.Dataset != nil {
+			for _, e := range res.Dataset.OrderedErrors() {
+				code := e.Code
+				if code == "" {
+					code = datacop.DefaultErrorCode
+				}
+				add(code, "error", describe(e.Field, e.Message), datasetIndex, e.Field)
+			}
+		}
+	}
+
+	return json.Marshal(sarifLog{Schema: sarifSchema, Version: SARIFVersion, Runs: []sarifRun{run}})
+}
+
+// describe prefixes message with the field it is about, if any
+func describe(field, message string) string {
+	if field == "" || field == datacop.StandaloneErrorKey {
+		return message
+	}
+	return field + ": " + message
+}
+
+// datasetIndex is the index passed to location for findings about the batch as a whole
+const datasetIndex = -1
+
+// location returns the location of a finding for a field of the record at index, or for the
+// batch as a whole, located at "records" without a line, if index is datasetIndex
+func (c *sarifConfig) location(index int, field string) sarifLocation {
+	name := "records"
+	if index != datasetIndex {
+		name = fmt.Sprintf("records[%d]", index)
+	}
+	if field != "" && field != datacop.StandaloneErrorKey {
+		name += "." + field
+	}
+	loc := sarifLocation{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: name, Kind: "member"}}}
+	if c.uri != "" {
+		loc.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: c.uri}}
+		if index != datasetIndex && c.firstLine >= 1 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: c.firstLine + index}
+		}
+	}
+	return loc
+}
+
+// The subset of the SARIF 2.1.0 object model written by ToSARIF
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
@@ -0,0 +1,123 @@
+package batch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/batch"
+	"github.com/patrickward/datacop/is"
+)
+
+func sarifBatch() *batch.Result {
+	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+		v.Check(is.Required(r["sku"]), "sku", "is required")
+		v.Field("name", r["name"]).Validate(is.MinLength(3))
+		if r["discontinued"] == true {
+			v.CheckStandalone(false, "discontinued products cannot be imported")
+		}
+	}, func(records []batch.Record, res *batch.Result) {
+		res.Warn(0, "price", "looks unusually high")
+	})
+	return b.Validate([]batch.Record{
+		{"sku": "A-1", "name": "Widget"},
+		{"sku": "", "name": "Wi"},
+		{"sku": "A-3", "name": "Gadget", "discontinued": true},
+	})
+}
+
+func TestToSARIF(t *testing.T) {
+	data, err := batch.ToSARIF(sarifBatch())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {"name": "datacop", "rules": [{"id": "warning"}, {"id": "invalid"}, {"id": "min_length"}]}},
+			"results": [
+				{"ruleId": "warning", "level": "warning", "message": {"text": "price: looks unusually high"},
+				 "locations": [{"logicalLocations": [{"fullyQualifiedName": "records[0].price", "kind": "member"}]}]},
+				{"ruleId": "invalid", "level": "error", "message": {"text": "sku: is required"},
+				 "locations": [{"logicalLocations": [{"fullyQualifiedName": "records[1].sku", "kind": "member"}]}]},
+				{"ruleId": "min_length", "level": "error", "message": {"text": "name: must be at least 3 characters"},
+				 "locations": [{"logicalLocations": [{"fullyQualifiedName": "records[1].name", "kind": "member"}]}]},
+				{"ruleId": "invalid", "level": "error", "message": {"text": "discontinued products cannot be imported"},
+				 "locations": [{"logicalLocations": [{"fullyQualifiedName": "records[2]", "kind": "member"}]}]}
+			]
+		}]
+	}`, string(data))
+}
+
+func TestToSARIF_WithArtifact(t *testing.T) {
+	data, err := batch.ToSARIF(sarifBatch(), batch.WithArtifact("data/products.csv", 2))
+	require.NoError(t, err)
+
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &log))
+
+	var lines []int
+	for _, r := range log.Runs[0].Results {
+		loc := r.Locations[0].PhysicalLocation
+		assert.Equal(t, "data/products.csv", loc.ArtifactLocation.URI)
+		lines = append(lines, loc.Region.StartLine)
+	}
+	assert.Equal(t, []int{2, 3, 3, 4}, lines)
+}
+
+func TestToSARIF_WithArtifact_NoLines(t *testing.T) {
+	data, err := batch.ToSARIF(sarifBatch(), batch.WithArtifact("data/products.csv", 0))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"artifactLocation":{"uri":"data/products.csv"}`)
+	assert.NotContains(t, string(data), "startLine", "lines start at 1, so a first line of 0 leaves them out")
+}
+
+func TestToSARIF_NoFindings(t *testing.T) {
+	data, err := batch.ToSARIF(batch.New(nil).Validate(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{"tool": {"driver": {"name": "datacop", "rules": []}}, "results": []}]
+	}`, string(data))
+}
+
+func TestToSARIF_DatasetErrors(t *testing.T) {
+	res := batch.New(nil, batch.RequireNonNullRate("email", 1)).Validate([]batch.Record{{"email": "a@example.com"}, {}})
+
+	data, err := batch.ToSARIF(res, batch.WithArtifact("data/users.csv", 2))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {"name": "datacop", "rules": [{"id": "non_null_rate"}]}},
+			"results": [
+				{"ruleId": "non_null_rate", "level": "error",
+				 "message": {"text": "email is present in 50% of records (1 of 2), below the required 100%"},
+				 "locations": [{
+					"physicalLocation": {"artifactLocation": {"uri": "data/users.csv"}},
+					"logicalLocations": [{"fullyQualifiedName": "records", "kind": "member"}]
+				 }]}
+			]
+		}]
+	}`, string(data))
+}
@@ -0,0 +1,50 @@
+package datacop
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Canonical returns the recorded errors in a deterministic, normalized form: standalone errors
+// first, then fields sorted by name, with each field's errors sorted by code and message.
+// Messages are trimmed of surrounding whitespace, missing codes are set to DefaultErrorCode,
+// and duplicate errors are removed.
+//
+// Two validators that recorded the same errors in a different order have equal canonical forms.
+//
+// Example usage:
+// assert.Equal(t, expected.Canonical(), v.Canonical())
+func (v *Validator) Canonical() []ValidationError {
+	v.Wait()
+	var errs []ValidationError
+	for _, list := range v.errors {
+		for _, e := range list {
+			e = v.labeled(e)
+			e.Message = strings.TrimSpace(e.Message)
+			if e.Code == "" {
+				e.Code = DefaultErrorCode
+			}
+			errs = append(errs, e)
+		}
+	}
+
+	slices.SortFunc(errs, compareErrors)
+	return slices.CompactFunc(errs, func(a, b ValidationError) bool {
+		return compareErrors(a, b) == 0
+	})
+}
+
+// compareErrors orders errors by field, with standalone errors first, then by code and message
+func compareErrors(a, b ValidationError) int {
+	if a.Field != b.Field {
+		if a.Field == StandaloneErrorKey {
+			return -1
+		}
+		if b.Field == StandaloneErrorKey {
+			return 1
+		}
+		return strings.Compare(a.Field, b.Field)
+	}
+	return cmp.Or(strings.Compare(a.Code, b.Code), strings.Compare(a.Message, b.Message))
+}
@@ -0,0 +1,70 @@
+package datacop_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestValidator_Canonical(t *testing.T) {
+	first := datacop.New()
+	first.AddCodedError("name", "min_length", "is too short")
+	first.AddError("email", "is invalid ")
+	first.AddStandaloneError("form expired")
+	first.AddCodedError("name", "required", "is required")
+	first.AddError("email", "is invalid")
+
+	second := datacop.New()
+	second.AddError("email", "is invalid")
+	second.AddCodedError("name", "required", "is required")
+	second.AddStandaloneError("form expired")
+	second.AddCodedError("name", "min_length", "is too short")
+
+	want := []datacop.ValidationError{
+		{Field: datacop.StandaloneErrorKey, Code: datacop.DefaultErrorCode, Message: "form expired"},
+		{Field: "email", Code: datacop.DefaultErrorCode, Message: "is invalid"},
+		{Field: "name", Code: "min_length", Message: "is too short"},
+		{Field: "name", Code: "required", Message: "is required"},
+	}
+
+	assert.Equal(t, want, first.Canonical())
+	assert.Equal(t, first.Canonical(), second.Canonical())
+	assert.Empty(t, datacop.New().Canonical())
+}
+
+func TestWithStableOutput(t *testing.T) {
+	record := func(v *datacop.Validator, errs ...[2]string) {
+		for _, e := range errs {
+			v.AddError(e[0], e[1])
+		}
+	}
+
+	t.Run("stable output ignores recording order", func(t *testing.T) {
+		a := datacop.New(datacop.WithStableOutput())
+		b := datacop.New(datacop.WithStableOutput())
+		record(a, [2]string{"name", "is required"}, [2]string{"name", "is too short"}, [2]string{"age", "must be positive"})
+		record(b, [2]string{"age", "must be positive"}, [2]string{"name", "is too short"}, [2]string{"name", "is required"})
+
+		aJSON, err := json.Marshal(a)
+		require.NoError(t, err)
+		bJSON, err := json.Marshal(b)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"fields":{"age":"must be positive","name":"is required, is too short"}}`, string(aJSON))
+		assert.Equal(t, string(aJSON), string(bJSON))
+	})
+
+	t.Run("default output keeps recording order", func(t *testing.T) {
+		v := datacop.New()
+		v.AddError("name", "is too short")
+		v.AddError("name", "is required")
+
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"fields":{"name":"is too short, is required"}}`, string(data))
+	})
+}
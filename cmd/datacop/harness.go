@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+// testCase is an entry of a test file: a value for a field and the expected outcome
+type testCase struct {
+	Name  string `json:"name"`
+	Field string `json:"field"`
+	Value any    `json:"value"`
+	Valid *bool  `json:"valid"` // required, so a forgotten outcome is not read as invalid
+	Code  string `json:"code"`  // for invalid values, the code of the rule expected to fail first
+}
+
+// label returns the name of the case, or a description of its field and value
+func (c testCase) label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	value, _ := json.Marshal(c.Value)
+	return fmt.Sprintf("%s %s", c.Field, value)
+}
+
+// testCommand runs the test subcommand
+func testCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	verbose := flags.Bool("v", false, "list passing cases too")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: datacop test [-v] rules-file tests-file
+
+The rules file is a schema in the format of schema.ToClientRules. The tests file lists cases,
+as JSON or YAML:
+
+  - field: email
+    value: someone@example.com
+    valid: true
+  - name: email without a domain
+    field: email
+    value: someone@
+    valid: false
+    code: format     # optional: the rule expected to fail
+
+`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "datacop: %v\n", err)
+		return 1
+	}
+	cases, err := loadTests(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "datacop: %v\n", err)
+		return 1
+	}
+
+	passed, failed := 0, 0
+	for _, c := range cases {
+		if problem := runCase(s, c); problem != "" {
+			failed++
+			fmt.Fprintf(stdout, "FAIL  %s: %s\n", c.label(), problem)
+		} else {
+			passed++
+			if *verbose {
+				fmt.Fprintf(stdout, "ok    %s\n", c.label())
+			}
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// loadTests reads the cases of a test file
+func loadTests(path string) ([]testCase, error) {
+	data, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	var cases []testCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, c := range cases {
+		if c.Field == "" || c.Valid == nil {
+			return nil, fmt.Errorf("%s: case %d: field and valid are required", path, i+1)
+		}
+	}
+	return cases, nil
+}
+
+// runCase checks a case against the schema and describes how it failed, or returns "" if it
+// passed
+func runCase(s *schema.Schema, c testCase) string {
+	f, ok := s.Lookup(c.Field)
+	if !ok {
+		return fmt.Sprintf("unknown field %q", c.Field)
+	}
+
+	code, valid := f.Check(c.Value)
+	switch want := *c.Valid; {
+	case want && !valid:
+		return fmt.Sprintf("expected valid, but %s failed", code)
+	case !want && valid:
+		return "expected invalid, but it passed"
+	case !want && c.Code != "" && code != c.Code:
+		return fmt.Sprintf("expected %s to fail, but %s failed", c.Code, code)
+	}
+	return ""
+}
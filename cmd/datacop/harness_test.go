@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const harnessRules = `
+- field: email
+  type: string
+  required: true
+  format: email
+- field: age
+  type: integer
+  min: 18
+`
+
+// writeFile writes content to a file named name in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestTestCommand(t *testing.T) {
+	rules := writeFile(t, "rules.yaml", harnessRules)
+
+	tests := []struct {
+		name     string
+		cases    string
+		args     []string
+		wantCode int
+		want     []string
+		wantNot  []string
+	}{
+		{
+			name: "all pass",
+			cases: `
+- {field: email, value: someone@example.com, valid: true}
+- {name: missing email, field: email, valid: false, code: required}
+- {field: age, value: 21, valid: true}
+- {field: age, value: 17, valid: false, code: min}
+`,
+			want:    []string{"4 passed, 0 failed\n"},
+			wantNot: []string{"ok "},
+		},
+		{
+			name: "failures",
+			cases: `
+- {field: email, value: someone@, valid: true}
+- {field: age, value: 30, valid: false}
+- {field: age, value: 12.5, valid: false, code: min}
+- {field: phone, value: "555", valid: true}
+`,
+			wantCode: 1,
+			want: []string{
+				`FAIL  email "someone@": expected valid, but format failed`,
+				`FAIL  age 30: expected invalid, but it passed`,
+				`FAIL  age 12.5: expected min to fail, but type failed`,
+				`FAIL  phone "555": unknown field "phone"`,
+				"0 passed, 4 failed\n",
+			},
+		},
+		{
+			name:  "verbose",
+			cases: `[{"field": "age", "value": 40, "valid": true}]`,
+			args:  []string{"-v"},
+			want:  []string{"ok    age 40\n", "1 passed, 0 failed\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases := writeFile(t, "cases.yaml", tt.cases)
+			var stdout, stderr bytes.Buffer
+			args := append(append([]string{"test"}, tt.args...), rules, cases)
+			code := run(args, strings.NewReader(""), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+			for _, notWant := range tt.wantNot {
+				assert.NotContains(t, stdout.String(), notWant)
+			}
+		})
+	}
+}
+
+func TestTestCommand_Errors(t *testing.T) {
+	rules := writeFile(t, "rules.json", `[{"field": "name", "required": true}]`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{"missing arguments", []string{rules}, 2, "Usage: datacop test"},
+		{"missing rules file", []string{"nope.yaml", rules}, 1, "nope.yaml"},
+		{"invalid yaml", []string{rules, writeFile(t, "bad.yaml", "- [")}, 1, "bad.yaml"},
+		{"case without outcome", []string{rules, writeFile(t, "cases.yaml", "- {field: name, value: x}")}, 1, "case 1: field and valid are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(append([]string{"test"}, tt.args...), strings.NewReader(""), &stdout, &stderr)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Contains(t, stderr.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+// loadSchema reads a schema in the format of schema.ToClientRules from a JSON or, if the file
+// name ends in .yaml or .yml, YAML file
+func loadSchema(path string) (*schema.Schema, error) {
+	data, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := schema.FromClientRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// readDocument reads a JSON or YAML file and returns its content as JSON
+func readDocument(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return json.Marshal(doc)
+	}
+	return data, nil
+}
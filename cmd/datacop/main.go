@@ -0,0 +1,56 @@
+// Command datacop is a command-line companion to the datacop validation library.
+//
+// Usage:
+//
+//	datacop <command> [arguments]
+//
+// The commands are:
+//
+//	repl    load a schema and check sample values interactively
+//	test    check a schema against a file of sample values and expected outcomes
+//	replay  check a schema against recorded payloads and list the outcomes that change
+//
+// Schemas are read in the format produced by schema.ToClientRules, as JSON or YAML.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: datacop <command> [arguments]
+
+Commands:
+  repl    load a schema and check sample values interactively
+  test    check a schema against a file of sample values and expected outcomes
+  replay  check a schema against recorded payloads and list the outcomes that change
+
+Run "datacop <command> -h" for the arguments of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command named by args[0] and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "repl":
+		return replCommand(args[1:], stdin, stdout, stderr)
+	case "test":
+		return testCommand(args[1:], stdout, stderr)
+	case "replay":
+		return replayCommand(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	fmt.Fprintf(stderr, "datacop: unknown command %q\n\n%s", args[0], usage)
+	return 2
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{"no command", nil, 2, "", "Usage: datacop"},
+		{"help", []string{"help"}, 0, "Usage: datacop", ""},
+		{"help lists replay", []string{"help"}, 0, "  replay  ", ""},
+		{"unknown command", []string{"lint"}, 2, "", `unknown command "lint"`},
+		{"missing schema", []string{"repl", "-schema", "missing.json"}, 1, "", "missing.json"},
+		{"bad flag", []string{"repl", "-nope"}, 2, "", "Usage: datacop repl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(""), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code)
+			assert.Contains(t, stdout.String(), tt.wantStdout)
+			assert.Contains(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
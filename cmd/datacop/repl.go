@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+const replHelp = `Enter "<field> <value>" to check a value against a field's rules. Values are read
+as JSON when they parse, so 42, true, and ["a"] are a number, a boolean, and a list;
+anything else is a string. A field without a value checks an absent value.
+
+Commands:
+  :fields                   list the fields and their rules
+  :load <file>              load a schema, replacing the current one
+  :pattern <field> <regex>  set the pattern of a field, declaring a string field if needed
+  :help                     show this help
+  :quit                     exit
+`
+
+// replCommand runs the repl subcommand
+func replCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("repl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	schemaPath := flags.String("schema", "", "schema file in the format of schema.ToClientRules, as JSON or YAML")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: datacop repl [-schema file]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	r := &repl{schema: schema.New(), out: stdout}
+	if *schemaPath != "" {
+		if err := r.load(*schemaPath); err != nil {
+			fmt.Fprintf(stderr, "datacop: %v\n", err)
+			return 1
+		}
+	}
+	if err := r.run(stdin); err != nil {
+		fmt.Fprintf(stderr, "datacop: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// repl reads commands and sample values and reports the outcome of each rule
+type repl struct {
+	schema *schema.Schema
+	out    io.Writer
+}
+
+// errQuit ends the loop of run
+var errQuit = errors.New("quit")
+
+// run processes lines from in until it is exhausted or :quit is entered
+func (r *repl) run(in io.Reader) error {
+	fmt.Fprintln(r.out, `datacop repl; type :help for help`)
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(r.out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(r.out)
+			return scanner.Err()
+		}
+		if err := r.exec(strings.TrimSpace(scanner.Text())); err != nil {
+			if err == errQuit {
+				return nil
+			}
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+	}
+}
+
+// exec runs a single line
+func (r *repl) exec(line string) error {
+	if line == "" {
+		return nil
+	}
+	command, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+
+	switch command {
+	case ":quit", ":q", ":exit":
+		return errQuit
+	case ":help":
+		fmt.Fprint(r.out, replHelp)
+		return nil
+	case ":fields":
+		r.listFields()
+		return nil
+	case ":load":
+		if rest == "" {
+			return errors.New("usage: :load <file>")
+		}
+		if err := r.load(rest); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "loaded %d fields\n", len(r.schema.Fields()))
+		return nil
+	case ":pattern":
+		field, pattern, ok := strings.Cut(rest, " ")
+		if !ok {
+			return errors.New("usage: :pattern <field> <regex>")
+		}
+		return r.setPattern(field, strings.TrimSpace(pattern))
+	}
+	if strings.HasPrefix(command, ":") {
+		return fmt.Errorf("unknown command %s; type :help for help", command)
+	}
+	return r.check(command, rest)
+}
+
+// load replaces the schema with the one in the file at path
+func (r *repl) load(path string) error {
+	s, err := loadSchema(path)
+	if err != nil {
+		return err
+	}
+	r.schema = s
+	return nil
+}
+
+// setPattern sets the pattern of a field, declaring it as a string field if it does not exist
+func (r *repl) setPattern(name, pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return err
+	}
+	b := r.schema.Field(name)
+	if f, _ := r.schema.Lookup(name); f.Type == schema.TypeAny {
+		b.String()
+	}
+	b.Pattern(pattern)
+	fmt.Fprintf(r.out, "%s: pattern %s\n", name, pattern)
+	return nil
+}
+
+// listFields prints each field with the codes of its rules
+func (r *repl) listFields() {
+	if len(r.schema.Fields()) == 0 {
+		fmt.Fprintln(r.out, "no fields; use :load or :pattern")
+		return
+	}
+	for _, f := range r.schema.Fields() {
+		var codes []string
+		for _, result := range f.CheckEach(nil) {
+			codes = append(codes, result.Code)
+		}
+		fmt.Fprintf(r.out, "%s: %s\n", f.Name, strings.Join(codes, ", "))
+	}
+}
+
+// check evaluates raw against the rules of the named field and prints the outcome of each rule
+func (r *repl) check(name, raw string) error {
+	f, ok := r.schema.Lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown field %q; type :fields to list them", name)
+	}
+
+	messages := map[string]string{}
+	for _, rule := range r.schema.ClientRules() {
+		if rule.Field == name {
+			messages = rule.Messages
+		}
+	}
+
+	results := f.CheckEach(parseValue(raw))
+	if len(results) == 0 {
+		fmt.Fprintln(r.out, "no rules")
+		return nil
+	}
+	valid := true
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(r.out, "  skip  %s\n", result.Code)
+		case result.Passed:
+			fmt.Fprintf(r.out, "  pass  %s\n", result.Code)
+		default:
+			valid = false
+			fmt.Fprintf(r.out, "  FAIL  %s: %s\n", result.Code, messages[result.Code])
+		}
+	}
+	if valid {
+		fmt.Fprintln(r.out, "valid")
+	} else {
+		fmt.Fprintln(r.out, "invalid")
+	}
+	return nil
+}
+
+// parseValue reads raw as JSON if it parses, and as a string otherwise. An empty raw value is
+// absent.
+func parseValue(raw string) any {
+	if raw == "" {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+// writeSchema writes the client rules of s to a temporary file and returns its path
+func writeSchema(t *testing.T, s *schema.Schema) string {
+	data, err := s.ToClientRules()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// session runs the repl with the given input lines and returns its output without prompts
+func session(t *testing.T, args []string, lines ...string) string {
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"repl"}, args...), strings.NewReader(strings.Join(lines, "\n")), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	return strings.ReplaceAll(stdout.String(), "> ", "")
+}
+
+func TestREPL_Check(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required().MinLength(3)
+	s.Field("age").Integer().Min(18)
+	path := writeSchema(t, s)
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"valid", "name Alice", "  pass  required\n  pass  type\n  pass  min_length\nvalid\n"},
+		{"every rule reported", "name Al", "  pass  required\n  pass  type\n  FAIL  min_length: must be at least 3 characters\ninvalid\n"},
+		{"absent", "name", "  FAIL  required: is required\n  skip  type\n  skip  min_length\ninvalid\n"},
+		{"wrong type", "name 42", "  pass  required\n  FAIL  type: must be a string\n  skip  min_length\ninvalid\n"},
+		{"json number", "age 17", "  pass  type\n  FAIL  min: must be at least 18\ninvalid\n"},
+		{"quoted string", `age "20"`, "  FAIL  type: must be an integer\n  skip  min\ninvalid\n"},
+		{"unknown field", "email a@example.com", `error: unknown field "email"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := session(t, []string{"-schema", path}, tt.line)
+			assert.Contains(t, out, tt.want)
+		})
+	}
+}
+
+func TestREPL_Commands(t *testing.T) {
+	s := schema.New()
+	s.Field("plan").String().Enum("free", "pro")
+	path := writeSchema(t, s)
+
+	out := session(t, nil,
+		":fields",
+		":load "+path,
+		":fields",
+		`:pattern sku ^[A-Z]{3}-\d{4}$`,
+		"sku ABC-1234",
+		"sku abc",
+		":pattern sku (",
+		":bogus",
+		":help",
+		":quit",
+		"plan free",
+	)
+
+	assert.Contains(t, out, "no fields; use :load or :pattern\n")
+	assert.Contains(t, out, "loaded 1 fields\nplan: type, enum\n")
+	assert.Contains(t, out, "sku: pattern ^[A-Z]{3}-\\d{4}$\n  pass  type\n  pass  pattern\nvalid\n")
+	assert.Contains(t, out, "  FAIL  pattern: has an invalid format\ninvalid\n")
+	assert.Contains(t, out, "error: error parsing regexp")
+	assert.Contains(t, out, "error: unknown command :bogus")
+	assert.Contains(t, out, "Commands:")
+	assert.NotContains(t, out, "  pass  enum", "input after :quit is ignored")
+}
+
+func TestParseValue(t *testing.T) {
+	assert.Nil(t, parseValue(""))
+	assert.Equal(t, float64(42), parseValue("42"))
+	assert.Equal(t, true, parseValue("true"))
+	assert.Equal(t, []any{"a"}, parseValue(`["a"]`))
+	assert.Equal(t, "20", parseValue(`"20"`))
+	assert.Equal(t, "hello world", parseValue("hello world"))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/replay"
+)
+
+// replayCommand runs the replay subcommand
+func replayCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, `Usage: datacop replay rules-file recording-file
+
+The rules file is a schema in the format of schema.ToClientRules. The recording file holds
+payloads and their validation outcomes, written with replay.NewRecorder. Each payload is
+validated against the schema, and those whose outcome changes are listed. The exit code is 1
+if any did.
+
+`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "datacop: %v\n", err)
+		return 1
+	}
+	f, err := os.Open(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "datacop: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	report, err := replay.Replay(f, func(v *datacop.Validator, payload map[string]any) {
+		s.Validate(v, payload)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "datacop: %s: %v\n", flags.Arg(1), err)
+		return 1
+	}
+
+	fmt.Fprintln(stdout, report)
+	if len(report.Changes) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayCommand(t *testing.T) {
+	rules := writeFile(t, "rules.yaml", harnessRules)
+
+	tests := []struct {
+		name      string
+		recording string
+		wantCode  int
+		want      []string
+	}{
+		{
+			name: "unchanged",
+			recording: `{"id":"r1","time":"2026-01-02T15:04:05Z","payload":{"email":"a@example.com","age":30}}
+{"id":"r2","time":"2026-01-02T15:04:06Z","payload":{"age":30},"errors":[{"field":"email","code":"required","message":"is required"}]}
+`,
+			want: []string{"2 replayed, 0 changed (0 newly rejected, 0 newly accepted)\n"},
+		},
+		{
+			name: "changed",
+			recording: `{"id":"r1","time":"2026-01-02T15:04:05Z","payload":{"email":"a@example.com","age":17}}
+{"id":"r2","time":"2026-01-02T15:04:06Z","payload":{"email":"b@example.com","age":30}}
+`,
+			wantCode: 1,
+			want:     []string{"2 replayed, 1 changed (1 newly rejected, 0 newly accepted)", "r1:\n+ age: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recording := writeFile(t, "recording.jsonl", tt.recording)
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"replay", rules, recording}, strings.NewReader(""), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+func TestReplayCommand_Errors(t *testing.T) {
+	rules := writeFile(t, "rules.json", `[{"field": "name", "required": true}]`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{"missing arguments", []string{rules}, 2, "Usage: datacop replay"},
+		{"missing rules file", []string{"nope.yaml", rules}, 1, "nope.yaml"},
+		{"missing recording", []string{rules, "nope.jsonl"}, 1, "nope.jsonl"},
+		{"invalid recording", []string{rules, writeFile(t, "bad.jsonl", "{")}, 1, "bad.jsonl: replay: reading entry 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(append([]string{"replay"}, tt.args...), strings.NewReader(""), &stdout, &stderr)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Contains(t, stderr.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package datacop
+
+import "maps"
+
+// SetContextValue attaches metadata, such as a request or trace ID, to every error recorded on
+// the validator afterwards, so failures can be correlated across services, logs, and support
+// tickets. The values appear in ValidationError.Context, in detailed JSON and problem details
+// output, and in the Failure passed to reporters. Errors recorded earlier keep the values they
+// were recorded with. Clear keeps the values.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	v.SetContextValue("request_id", r.Header.Get("X-Request-ID"))
+//	v.Field("email", email).Validate(is.Email, "is invalid")
+//	// {"errors":[{"field":"email","code":"invalid","message":"is invalid","context":{"request_id":"abc123"}}]}
+func (v *Validator) SetContextValue(key string, value any) {
+	// Errors share the map they were recorded with, so it is replaced rather than modified
+	values := make(map[string]any, len(v.context)+1)
+	maps.Copy(values, v.context)
+	values[key] = value
+	v.context = values
+}
+
+// ContextValue returns the value set for key with SetContextValue, or nil
+func (v *Validator) ContextValue(key string) any {
+	return v.context[key]
+}
@@ -0,0 +1,61 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestValidator_SetContextValue(t *testing.T) {
+	var failures []datacop.Failure
+	v := datacop.New(datacop.WithReporter(datacop.ReporterFunc(func(f datacop.Failure) {
+		failures = append(failures, f)
+	})))
+
+	v.Check(false, "before", "is invalid")
+	v.SetContextValue("request_id", "abc123")
+	v.Field("email", "jane").Validate(is.EndsWith("@example.com"))
+	v.SetContextValue("tenant", 7)
+	v.CheckStandalone(false, "form is invalid")
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 3)
+	assert.Nil(t, errs[0].Context, "errors recorded earlier have no context")
+	assert.Equal(t, map[string]any{"request_id": "abc123"}, errs[1].Context, "later values do not change earlier errors")
+	assert.Equal(t, map[string]any{"request_id": "abc123", "tenant": 7}, errs[2].Context)
+
+	require.Len(t, failures, 3)
+	assert.Equal(t, map[string]any{"request_id": "abc123"}, failures[1].Context)
+
+	assert.Equal(t, "abc123", v.ContextValue("request_id"))
+	assert.Nil(t, v.ContextValue("missing"))
+}
+
+func TestValidator_SetContextValue_Output(t *testing.T) {
+	v := datacop.New()
+	v.SetContextValue("request_id", "abc123")
+	v.Check(false, "email", "is invalid")
+
+	data, err := v.MarshalJSONDetailed()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors":[{"field":"email","code":"invalid","message":"is invalid","context":{"request_id":"abc123"}}]}`, string(data))
+
+	problem := v.ToProblemDetails(422)
+	assert.Equal(t, map[string]any{"request_id": "abc123"}, problem.Errors[0].Context)
+
+	v.Clear()
+	v.Check(false, "email", "is invalid")
+	assert.Equal(t, "abc123", v.OrderedErrors()[0].Context["request_id"], "Clear keeps context values")
+}
+
+func TestValidator_SetContextValue_Nested(t *testing.T) {
+	v := datacop.New()
+	v.SetContextValue("request_id", "abc123")
+	v.Group("address").Validate(zipOnly{})
+
+	assert.Equal(t, map[string]any{"request_id": "abc123"}, v.OrderedErrors()[0].Context)
+}
@@ -0,0 +1,133 @@
+// Package contract checks that services agree on the shape of the data they exchange. A producer
+// describes what it emits with a schema.Schema, and each consumer describes what it relies on with
+// another: the fields it requires and the constraints it assumes. Check reports every expectation
+// the producer's schema does not guarantee, so schema changes can be reviewed against the
+// consumers they would break, for example in each consumer's test suite.
+//
+// Example usage:
+//
+//	producer := orders.EventSchema()
+//
+//	consumer := schema.New()
+//	consumer.Field("id").String().Required().Format(schema.FormatUUID)
+//	consumer.Field("status").String().Required().Enum("pending", "paid", "shipped")
+//
+//	if err := contract.Check(producer, consumer); err != nil {
+//		t.Fatal(err)
+//	}
+package contract
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+// Issue is a consumer expectation the producer's schema does not guarantee
+type Issue struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"` // the code of the consumer's rule, such as schema.CodeMaxLength
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+// Error is returned by Check when the producer's schema does not satisfy the consumer's
+// expectations. It lists every issue found, in the order the consumer declares its fields.
+type Error struct {
+	Issues []Issue
+}
+
+func (e *Error) Error() string {
+	issues := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		issues[i] = issue.String()
+	}
+	return "contract: producer does not satisfy consumer: " + strings.Join(issues, "; ")
+}
+
+// Check verifies that every payload valid against producer is also valid against consumer, as
+// far as can be decided from the schemas' declarations. It returns an *Error listing the issues,
+// or nil if the schemas are compatible.
+//
+// For each consumer field, the producer must declare a field of the same name whose rules are at
+// least as strict: required if the consumer requires it, of the same type (an integer satisfies a
+// number), with length and numeric bounds within the consumer's, and with allowed values, formats,
+// and patterns the consumer accepts. This is the consumer's schema being a compatible evolution
+// of the producer's, so the rules are compared as schema.Compat compares schema versions.
+// Optional fields the producer does not declare are ignored.
+func Check(producer, consumer *schema.Schema) error {
+	var issues []Issue
+	for _, c := range schema.Compat(producer, consumer).Breaking() {
+		issues = append(issues, Issue{Field: c.Field, Code: c.Code, Message: message(c)})
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return &Error{Issues: issues}
+}
+
+// message describes a breaking change from the producer's schema to the consumer's as an
+// expectation the producer does not meet
+func message(c schema.Change) string {
+	switch c.Code {
+	case schema.CodeRequired:
+		if c.Old == nil {
+			return "is required but not produced"
+		}
+		return "is required but optional in the producer"
+	case schema.CodeType:
+		return fmt.Sprintf("must be %s but the producer declares %s", describeType(c.New), describeType(c.Old))
+	case schema.CodeMinLength:
+		return fmt.Sprintf("must have a length of at least %v but the producer allows %s", c.New, describe(c.Old))
+	case schema.CodeMaxLength:
+		return fmt.Sprintf("must have a length of at most %v but the producer allows %s", c.New, describe(c.Old))
+	case schema.CodeMin:
+		return fmt.Sprintf("must be at least %v but the producer allows %s", c.New, describe(c.Old))
+	case schema.CodeMax:
+		return fmt.Sprintf("must be at most %v but the producer allows %s", c.New, describe(c.Old))
+	case schema.CodePattern:
+		return fmt.Sprintf("must match %q but the producer does not guarantee it", c.New)
+	case schema.CodeEnum:
+		allowed, _ := c.New.([]string)
+		produced, _ := c.Old.([]string)
+		if produced == nil {
+			return fmt.Sprintf("must be one of %s but the producer allows any value", strings.Join(allowed, ", "))
+		}
+		return fmt.Sprintf("must be one of %s but the producer also allows %s", strings.Join(allowed, ", "), strings.Join(missingFrom(produced, allowed), ", "))
+	case schema.CodeFormat:
+		return fmt.Sprintf("must be a valid %v but the producer does not guarantee it", c.New)
+	}
+	return c.Message
+}
+
+// missingFrom returns the values not in allowed
+func missingFrom(values, allowed []string) []string {
+	var missing []string
+	for _, v := range values {
+		if !slices.Contains(allowed, v) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+func describeType(t any) string {
+	if t == schema.TypeAny {
+		return "any type"
+	}
+	return fmt.Sprint(t)
+}
+
+// describe formats a rule's value, or "any" if the producer does not declare the rule
+func describe(value any) string {
+	if value == nil {
+		return "any"
+	}
+	return fmt.Sprint(value)
+}
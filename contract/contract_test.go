@@ -0,0 +1,120 @@
+package contract_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/contract"
+	"github.com/patrickward/datacop/schema"
+)
+
+func producerSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("id").String().Required().Format(schema.FormatUUID)
+	s.Field("status").String().Required().Enum("pending", "paid")
+	s.Field("total").Integer().Required().Min(0).Max(10000)
+	s.Field("currency").String().Required().Enum("USD", "EUR")
+	s.Field("note").String().MaxLength(200)
+	s.Field("sku").String().Pattern(`^[A-Z]{3}-\d+$`)
+	return s
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		declare func(c *schema.Schema)
+		want    []contract.Issue
+	}{
+		{
+			name: "compatible",
+			declare: func(c *schema.Schema) {
+				c.Field("id").String().Required().Format(schema.FormatUUID)
+				c.Field("status").String().Required().Enum("pending", "paid", "shipped")
+				c.Field("total").Number().Min(-100).Max(1000000)
+				c.Field("currency").String().Pattern(`^[A-Z]{3}$`).MinLength(3)
+				c.Field("sku").String().Pattern(`^[A-Z]{3}-\d+$`)
+				c.Field("discount").Number()
+			},
+		},
+		{
+			name: "missing and optional fields",
+			declare: func(c *schema.Schema) {
+				c.Field("customer").String().Required()
+				c.Field("note").Required()
+			},
+			want: []contract.Issue{
+				{Field: "customer", Code: schema.CodeRequired, Message: "is required but not produced"},
+				{Field: "note", Code: schema.CodeRequired, Message: "is required but optional in the producer"},
+			},
+		},
+		{
+			name: "looser producer constraints",
+			declare: func(c *schema.Schema) {
+				c.Field("id").Integer()
+				c.Field("status").Enum("pending")
+				c.Field("total").Integer().Min(1).Max(500)
+				c.Field("note").String().MinLength(1).MaxLength(100)
+				c.Field("sku").Pattern(`^[A-Z]+$`).Format(schema.FormatEmail)
+				c.Field("currency").Format(schema.FormatUUID)
+			},
+			want: []contract.Issue{
+				{Field: "id", Code: schema.CodeType, Message: "must be integer but the producer declares string"},
+				{Field: "status", Code: schema.CodeEnum, Message: "must be one of pending but the producer also allows paid"},
+				{Field: "total", Code: schema.CodeMin, Message: "must be at least 1 but the producer allows 0"},
+				{Field: "total", Code: schema.CodeMax, Message: "must be at most 500 but the producer allows 10000"},
+				{Field: "note", Code: schema.CodeMinLength, Message: "must have a length of at least 1 but the producer allows any"},
+				{Field: "note", Code: schema.CodeMaxLength, Message: "must have a length of at most 100 but the producer allows 200"},
+				{Field: "sku", Code: schema.CodePattern, Message: `must match "^[A-Z]+$" but the producer does not guarantee it`},
+				{Field: "sku", Code: schema.CodeFormat, Message: "must be a valid email but the producer does not guarantee it"},
+				{Field: "currency", Code: schema.CodeFormat, Message: "must be a valid uuid but the producer does not guarantee it"},
+			},
+		},
+		{
+			name: "unconstrained producer",
+			declare: func(c *schema.Schema) {
+				c.Field("note").Enum("a", "b")
+			},
+			want: []contract.Issue{
+				{Field: "note", Code: schema.CodeEnum, Message: "must be one of a, b but the producer allows any value"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := schema.New()
+			tt.declare(consumer)
+
+			err := contract.Check(producerSchema(), consumer)
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var cerr *contract.Error
+			require.True(t, errors.As(err, &cerr))
+			assert.Equal(t, tt.want, cerr.Issues)
+		})
+	}
+}
+
+func TestCheck_AnyType(t *testing.T) {
+	producer := schema.New()
+	producer.Field("payload")
+	consumer := schema.New()
+	consumer.Field("payload").Object()
+
+	err := contract.Check(producer, consumer)
+	assert.EqualError(t, err, "contract: producer does not satisfy consumer: payload: must be object but the producer declares any type")
+}
+
+func TestError_Error(t *testing.T) {
+	err := &contract.Error{Issues: []contract.Issue{
+		{Field: "id", Code: schema.CodeRequired, Message: "is required but not produced"},
+		{Field: "total", Code: schema.CodeMax, Message: "must be at most 500 but the producer allows 10000"},
+	}}
+	assert.Equal(t, "contract: producer does not satisfy consumer: id: is required but not produced; total: must be at most 500 but the producer allows 10000", err.Error())
+}
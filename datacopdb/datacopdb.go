@@ -0,0 +1,174 @@
+// Package datacopdb provides validation rules backed by a SQL database, such as checking that an
+// email address is not already registered.
+//
+// The rules run a parameterized "SELECT EXISTS (...)" query, which PostgreSQL, MySQL, and SQLite
+// support. Table and column names cannot be parameterized, so they must be plain identifiers
+// written by the programmer, never user input; the constructors panic otherwise.
+package datacopdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// Queryer runs queries that return a single row. It is satisfied by *sql.DB, *sql.Tx, and
+// *sql.Conn.
+type Queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var rgxIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// query is the EXISTS query built by Unique and Exists
+type query struct {
+	table     string
+	column    string
+	excluding []condition
+	dollar    bool
+}
+
+type condition struct {
+	column string
+	value  any
+}
+
+// Option configures the query run by Unique or Exists
+type Option func(*query)
+
+// Excluding leaves out rows whose column equals value, typically the record being updated, so
+// that saving a record without changing its email does not report the email as taken. Values are
+// compared as by IS DISTINCT FROM: rows whose column is NULL are kept, and a nil value leaves out
+// only those rows.
+//
+// Example usage:
+// datacopdb.Unique(ctx, db, "users", "email", datacopdb.Excluding("id", user.ID))
+func Excluding(column string, value any) Option {
+	mustIdentifier(column)
+	return func(q *query) {
+		q.excluding = append(q.excluding, condition{column: column, value: value})
+	}
+}
+
+// DollarPlaceholders numbers query parameters as $1, $2, and so on, as PostgreSQL requires,
+// instead of using ?
+func DollarPlaceholders() Option {
+	return func(q *query) {
+		q.dollar = true
+	}
+}
+
+// Unique returns a validation function that checks that no row of table has value in column,
+// such as an email address that is not yet registered. Nil values and blank strings pass
+// without a query, so combine it with is.Required when the value is mandatory.
+//
+// The rule is named "unique", with the table and column as params. If the query fails, the
+// check fails and, when it runs in Validate, a datacop.AnnotationWarning annotation records the
+// error.
+//
+// Example usage:
+//
+//	v.Field("email", email).
+//		Validate(is.Email, "is not a valid email address").
+//		Validate(datacopdb.Unique(ctx, db, "users", "email"), "is already registered")
+func Unique(ctx context.Context, q Queryer, table, column string, opts ...Option) datacop.NamedRule {
+	return rule(ctx, q, "unique", false, table, column, opts)
+}
+
+// Exists returns a validation function that checks that a row of table has value in column,
+// such as a foreign key referring to an existing record. Nil values and blank strings pass
+// without a query. The rule is named "exists" and otherwise behaves like Unique.
+//
+// Example usage:
+// v.Field("team_id", teamID).Validate(datacopdb.Exists(ctx, db, "teams", "id"), "is not a team")
+func Exists(ctx context.Context, q Queryer, table, column string, opts ...Option) datacop.NamedRule {
+	return rule(ctx, q, "exists", true, table, column, opts)
+}
+
+func rule(ctx context.Context, db Queryer, name string, want bool, table, column string, opts []Option) datacop.NamedRule {
+	mustIdentifier(table)
+	mustIdentifier(column)
+	q := &query{table: table, column: column}
+	for _, opt := range opts {
+		opt(q)
+	}
+	sqlText := q.sql()
+
+	params := datacop.Params{"table": table, "column": column}
+	return datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		if blank(value) {
+			return true
+		}
+
+		args := []any{value}
+		for _, c := range q.excluding {
+			if !isNull(c.value) {
+				args = append(args, c.value)
+			}
+		}
+
+		var exists bool
+		if err := db.QueryRowContext(ctx, sqlText, args...).Scan(&exists); err != nil {
+			annotate(datacop.AnnotationWarning, name+" check failed", map[string]any{"error": err.Error()})
+			return false
+		}
+		return exists == want
+	}).Named(name, params)
+}
+
+// sql returns the query text
+func (q *query) sql() string {
+	n := 0
+	placeholder := func() string {
+		n++
+		if q.dollar {
+			return "$" + strconv.Itoa(n)
+		}
+		return "?"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "SELECT EXISTS (SELECT 1 FROM %s WHERE %s = %s", q.table, q.column, placeholder())
+	// Written out rather than as IS DISTINCT FROM, which MySQL does not support, since <> is
+	// never true when either side is NULL
+	for _, c := range q.excluding {
+		if isNull(c.value) {
+			fmt.Fprintf(&b, " AND %s IS NOT NULL", c.column)
+		} else {
+			fmt.Fprintf(&b, " AND (%s <> %s OR %s IS NULL)", c.column, placeholder(), c.column)
+		}
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func mustIdentifier(name string) {
+	if !rgxIdentifier.MatchString(name) {
+		panic(fmt.Sprintf("datacopdb: invalid identifier %q", name))
+	}
+}
+
+// isNull reports whether value is passed to the database as NULL: nil or a nil pointer
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+func blank(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package datacopdb_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/datacopdb"
+	"github.com/patrickward/datacop/is"
+)
+
+// fakeDriver answers every query with the result of its answer function and records the
+// queries it receives
+type fakeDriver struct {
+	answer  func(query string, args []driver.NamedValue) (bool, error)
+	queries []string
+	args    [][]any
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.queries = append(c.d.queries, query)
+	values := make([]any, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	c.d.args = append(c.d.args, values)
+
+	exists, err := c.d.answer(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{value: exists}, nil
+}
+
+type fakeRows struct {
+	value bool
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"exists"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	// SQLite and MySQL report EXISTS as an integer
+	if r.value {
+		dest[0] = int64(1)
+	} else {
+		dest[0] = int64(0)
+	}
+	return nil
+}
+
+var driverCount int
+
+// openFake opens a database whose table holds the given values
+func openFake(t *testing.T, values ...any) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{answer: func(_ string, args []driver.NamedValue) (bool, error) {
+		for _, v := range values {
+			if v == args[0].Value && (len(args) < 2 || v != args[1].Value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}}
+	driverCount++
+	name := fmt.Sprintf("datacopdb-fake-%d", driverCount)
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, d
+}
+
+func TestUnique(t *testing.T) {
+	db, d := openFake(t, "taken@example.com")
+	ctx := context.Background()
+	unique := datacopdb.Unique(ctx, db, "users", "email")
+
+	assert.True(t, unique("new@example.com"))
+	assert.False(t, unique("taken@example.com"))
+	assert.Equal(t, "SELECT EXISTS (SELECT 1 FROM users WHERE email = ?)", d.queries[0])
+	assert.Equal(t, []any{"new@example.com"}, d.args[0])
+
+	// Blank values pass without a query
+	assert.True(t, unique(""))
+	assert.True(t, unique(nil))
+	assert.Len(t, d.queries, 2)
+
+	name, params, _ := datacop.RuleInfo(unique)
+	assert.Equal(t, "unique", name)
+	assert.Equal(t, datacop.Params{"table": "users", "column": "email"}, params)
+}
+
+func TestUnique_Options(t *testing.T) {
+	db, d := openFake(t, "taken@example.com")
+	unique := datacopdb.Unique(context.Background(), db, "public.users", "email",
+		datacopdb.Excluding("email", "taken@example.com"), datacopdb.DollarPlaceholders())
+
+	assert.True(t, unique("taken@example.com"), "the excluded row does not count")
+	assert.Equal(t, "SELECT EXISTS (SELECT 1 FROM public.users WHERE email = $1 AND (email <> $2 OR email IS NULL))", d.queries[0])
+	assert.Equal(t, []any{"taken@example.com", "taken@example.com"}, d.args[0])
+}
+
+func TestUnique_ExcludingNull(t *testing.T) {
+	db, d := openFake(t, "taken@example.com")
+	unique := datacopdb.Unique(context.Background(), db, "users", "email",
+		datacopdb.Excluding("id", (*int64)(nil)), datacopdb.Excluding("team_id", 3))
+
+	assert.False(t, unique("taken@example.com"))
+	assert.Equal(t, "SELECT EXISTS (SELECT 1 FROM users WHERE email = ? AND id IS NOT NULL AND (team_id <> ? OR team_id IS NULL))", d.queries[0])
+	assert.Equal(t, []any{"taken@example.com", int64(3)}, d.args[0])
+}
+
+func TestExists(t *testing.T) {
+	db, _ := openFake(t, int64(7))
+	exists := datacopdb.Exists(context.Background(), db, "teams", "id")
+
+	assert.True(t, exists(int64(7)))
+	assert.False(t, exists(int64(8)))
+
+	v := datacop.New()
+	v.Field("team_id", int64(8)).Validate(exists, "is not a team")
+	assert.Equal(t, "exists", v.OrderedErrors()[0].Code)
+}
+
+func TestUnique_QueryError(t *testing.T) {
+	db, d := openFake(t)
+	d.answer = func(string, []driver.NamedValue) (bool, error) { return false, errors.New("connection reset") }
+
+	v := datacop.New()
+	v.Field("email", "a@example.com").
+		Validate(is.Email, "is not valid").
+		Validate(datacopdb.Unique(context.Background(), db, "users", "email"), "is already registered")
+
+	assert.Equal(t, "is already registered", v.ErrorFor("email"))
+	warnings := v.AnnotationsOfKind(datacop.AnnotationWarning)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "unique check failed", warnings[0].Message)
+	assert.Equal(t, "connection reset", warnings[0].Data["error"])
+}
+
+func TestInvalidIdentifiers(t *testing.T) {
+	db, _ := openFake(t)
+	ctx := context.Background()
+
+	assert.Panics(t, func() { datacopdb.Unique(ctx, db, "users; DROP TABLE users", "email") })
+	assert.Panics(t, func() { datacopdb.Exists(ctx, db, "users", "email = email OR 1") })
+	assert.Panics(t, func() { datacopdb.Excluding("id)", 1) })
+	assert.NotPanics(t, func() { datacopdb.Unique(ctx, db, "app.users", "email_address") })
+}
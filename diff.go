@@ -0,0 +1,80 @@
+package datacop
+
+import "strings"
+
+// ErrorDiff lists the errors recorded by only one of two validators; see Diff
+type ErrorDiff struct {
+	OnlyA []ValidationError `json:"only_a,omitempty"`
+	OnlyB []ValidationError `json:"only_b,omitempty"`
+}
+
+// Empty reports whether both validators recorded the same errors
+func (d ErrorDiff) Empty() bool {
+	return len(d.OnlyA) == 0 && len(d.OnlyB) == 0
+}
+
+// String lists the errors only a recorded, prefixed with "-", followed by those only b recorded,
+// prefixed with "+", one per line
+func (d ErrorDiff) String() string {
+	var sb strings.Builder
+	write := func(prefix string, errs []ValidationError) {
+		for _, e := range errs {
+			if sb.Len() > 0 {
+				sb.WriteByte('\n')
+			}
+			sb.WriteString(prefix)
+			if e.Field != StandaloneErrorKey {
+				sb.WriteString(e.Field + ": ")
+			}
+			sb.WriteString(e.Message + " (" + e.Code + ")")
+		}
+	}
+	write("- ", d.OnlyA)
+	write("+ ", d.OnlyB)
+	return sb.String()
+}
+
+// Diff compares the errors recorded by two validators in their canonical form, so the order in
+// which they were recorded does not matter, and lists those recorded by only one of them. Errors
+// are compared by field, code, and message; params, context, and positions are ignored. A nil
+// validator has no errors.
+//
+// It is meant for checking that a refactor of validation logic produces the same outcomes, for
+// example on recorded production payloads.
+//
+// Example usage:
+//
+//	for _, payload := range recorded {
+//		if d := datacop.Diff(validateOld(payload), validateNew(payload)); !d.Empty() {
+//			t.Errorf("outcomes differ:\n%s", d)
+//		}
+//	}
+func Diff(a, b *Validator) ErrorDiff {
+	var d ErrorDiff
+	ea, eb := canonicalOf(a), canonicalOf(b)
+	i, j := 0, 0
+	for i < len(ea) && j < len(eb) {
+		switch c := compareErrors(ea[i], eb[j]); {
+		case c < 0:
+			d.OnlyA = append(d.OnlyA, ea[i])
+			i++
+		case c > 0:
+			d.OnlyB = append(d.OnlyB, eb[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	d.OnlyA = append(d.OnlyA, ea[i:]...)
+	d.OnlyB = append(d.OnlyB, eb[j:]...)
+	return d
+}
+
+// canonicalOf returns the canonical form of v's errors, or nil for a nil validator
+func canonicalOf(v *Validator) []ValidationError {
+	if v == nil {
+		return nil
+	}
+	return v.Canonical()
+}
@@ -0,0 +1,52 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestDiff(t *testing.T) {
+	a := datacop.New()
+	a.AddStandaloneError("form expired")
+	a.AddCodedError("name", "required", "is required")
+	a.AddError("email", "is invalid")
+
+	b := datacop.New()
+	b.AddError("email", "is invalid ")
+	b.AddCodedError("name", "min_length", "is too short")
+	b.AddStandaloneError("form expired")
+
+	d := datacop.Diff(a, b)
+	assert.False(t, d.Empty())
+	assert.Equal(t, []datacop.ValidationError{{Field: "name", Code: "required", Message: "is required"}}, d.OnlyA)
+	assert.Equal(t, []datacop.ValidationError{{Field: "name", Code: "min_length", Message: "is too short"}}, d.OnlyB)
+	assert.Equal(t, "- name: is required (required)\n+ name: is too short (min_length)", d.String())
+}
+
+func TestDiff_Equal(t *testing.T) {
+	a := datacop.New()
+	a.AddError("email", "is invalid")
+	a.AddCodedError("name", "required", "is required")
+
+	b := datacop.New()
+	b.AddCodedError("name", "required", "is required")
+	b.AddError("email", "is invalid")
+	b.AddError("email", "is invalid")
+
+	d := datacop.Diff(a, b)
+	assert.True(t, d.Empty())
+	assert.Empty(t, d.String())
+}
+
+func TestDiff_Nil(t *testing.T) {
+	b := datacop.New()
+	b.AddStandaloneError("form expired")
+
+	d := datacop.Diff(nil, b)
+	assert.Empty(t, d.OnlyA)
+	assert.Equal(t, "+ form expired (invalid)", d.String())
+	assert.True(t, datacop.Diff(nil, datacop.New()).Empty())
+}
@@ -32,8 +32,8 @@ The simplest way to use the validator is with direct Check calls:
 	v.Check(Email(email), "email", "invalid email format")
 	v.Check(MinLength(8)(password), "password", "password too short")
 
-	if v.HasErrors() {
-		return v.Error() // returns formatted error string
+	if err := v.ErrOrNil(); err != nil {
+		return err // err.Error() returns a formatted error string
 	}
 
 # Chainable Validation
@@ -55,6 +55,26 @@ For more complex validations, use the fluent Field builder:
 		Check(is.Match(`[A-Z]`)(password), "must contain uppercase").
 		Check(is.Match(`[0-9]`)(password), "must contain number")
 
+Transform normalizes a value before validating it with Validate, and Value returns the result:
+
+	email := v.Field("email", raw).
+		Transform(strings.TrimSpace, strings.ToLower).
+		Validate(is.Email, "invalid email format").
+		Value().(string)
+
+The typed getters String, Int, Float, and Bool, and the generic datacop.Value, return the value
+converted to a Go type, or the zero value if the field failed validation:
+
+	age := v.Field("age", r.FormValue("age")).Validate(is.IntString, "must be a number").Int()
+	name, ok := datacop.Value[string](v.Field("name", name).Validate(is.Required, "is required"))
+
+FieldOf starts a chain whose rules take the field's static type, so a rule for the wrong type
+does not compile instead of failing at runtime. Rule runs untyped rules from the is package:
+
+	datacop.FieldOf(v, "age", age).
+		Validate(func(n int) bool { return n >= 18 }, "must be at least 18").
+		Rule(is.Max(130))
+
 # Grouped Validation
 
 For nested structures, use Group to namespace validations:
@@ -75,6 +95,40 @@ For nested structures, use Group to namespace validations:
 	addrGroup.Field("city", city).
 		Check(is.Required(city), "city is required")
 
+Groups nest, and Index groups the elements of a slice, producing paths such as "user.address.zip"
+and "items[2].qty". GroupFunc runs the validations of a nested object in a closure:
+
+	v.Group("user").Group("address").Field("zip", zip).Validate(is.Required, "zip is required")
+
+	for i, item := range items {
+		v.Group("items").Index(i).Field("qty", item.Qty).Validate(is.Min(1), "must be at least {min}")
+	}
+
+	// The same, with ValidateSlice
+	datacop.ValidateSlice(v, "items", items, func(g *datacop.Group, item Item) {
+		g.Field("qty", item.Qty).Validate(is.Min(1), "must be at least {min}")
+	})
+
+	v.GroupFunc("billing", func(g *datacop.Group) {
+		g.Field("name", billing.Name).Validate(is.Required, "name is required")
+	})
+
+ErrorsFor and HasErrorsInGroup query the errors of a group, for per-section summaries:
+
+	if v.HasErrorsInGroup("address") {
+		summary := v.ErrorsFor("address") // "address.zip", "address.street", ...
+	}
+
+Types that validate themselves implement Validatable. Group.Validate runs an object's Validate
+method with its fields nested in the group, and ValidateAll validates several objects:
+
+	func (a Address) Validate(v *datacop.Validator) {
+		v.Field("zip", a.Zip).Validate(is.Required, "zip is required")
+	}
+
+	v.Group("shipping").Validate(order.Shipping) // records "shipping.zip"
+	datacop.ValidateAll(v, &user, &settings)
+
 # Conditional Validation
 
 Conditional validations using When are evaluated sequentially. When a condition is false, all subsequent checks are skipped until the next When condition:
@@ -102,6 +156,65 @@ Conditional validations using When are evaluated sequentially. When a condition
 
 Note: Each When condition affects only the Check calls that follow it, until another When is encountered. The validation chain is processed sequentially from left to right.
 
+Unless is the inverse of When, and Otherwise starts a branch that runs when the preceding conditions do not all hold:
+
+	v.Field("tax_id", taxID).
+		When(country == "US").
+		Validate(is.Match(`^\d{2}-\d{7}$`), "must be an EIN").
+		Otherwise().                                   // Only if country is not "US"
+		Validate(is.MinLength(5), "is too short")
+
+	v.Field("phone", phone).
+		Unless(hasEmail).                              // Only if hasEmail is false
+		Validate(is.Required, "is required without an email")
+
+WhenFunc and WhenValue take conditions that are evaluated lazily, when the first check that needs them runs, and at most once. WhenValue's condition receives the field's value:
+
+	v.Field("vat_id", vatID).
+		WhenFunc(order.ShipsAbroad).                   // Not called unless a check needs it
+		Validate(is.Required, "is required for exports")
+
+	v.Field("website", website).
+		WhenValue(func(value any) bool { return value != "" }).
+		Validate(is.StartsWith("https://"), "must be an https URL")
+
+# Concurrent Checks
+
+CheckAsync runs expensive checks, such as DNS lookups or database queries, concurrently. Every
+method that reads the errors, such as HasErrors, first waits for them and collects their results;
+Wait does so explicitly:
+
+	v.CheckAsync(func() bool { return users.UsernameFree(ctx, username) }, "username", "is taken")
+	v.CheckAsync(func() bool { return !breaches.Contains(ctx, password) }, "password", "appears in a data breach")
+	v.Wait()
+
+datacop.Intercept wraps the calls to a validation function, keeping its metadata. In staging,
+is.FaultyModerator and is.FaultyResolver delay or fail some calls to the services used by external
+validators, so timeouts and fallbacks can be tested; they only take effect with the
+faultinjection build tag or with DATACOP_FAULT_INJECTION=1 in the environment:
+
+	opts.Resolver = is.FaultyResolver(net.DefaultResolver, is.FaultConfig{LatencyRate: 0.2, Latency: 2 * time.Second})
+	rule := is.EmailWithContext(ctx, opts)
+
+# Memoization
+
+WithIntraRequestMemo makes a validator reuse the result of a built-in rule, such as
+is.MinLength(8), or of a rule that opted in with Memoized, when it validates the same value again,
+as happens when shared rule sets overlap with explicit chains:
+
+	v := datacop.New(datacop.WithIntraRequestMemo())
+
+# Explaining Results
+
+WithExplain records every rule, check, and condition a validator evaluates, with a snapshot of
+the input and the outcome. Fields marked with WithSensitiveFields are redacted:
+
+	v := datacop.New(datacop.WithExplain(), datacop.WithSensitiveFields("password"))
+	form.Validate(v)
+	fmt.Println(v.Explain())
+	// email: rule min_length map[min:3] on "ab": failed
+	// password: rule min_length map[min:8] on [redacted]: failed
+
 # Standalone Errors
 
 For validations not tied to specific fields:
@@ -116,6 +229,32 @@ For validations not tied to specific fields:
 	// Check standalone condition
 	v.CheckStandalone(password == confirmPassword, "passwords do not match")
 
+# Annotations
+
+Annotations record findings that are not errors, such as warnings or audit notes. They never
+affect HasErrors:
+
+	v.Annotate("bio", datacop.AnnotationWarning, "consider adding more detail", nil)
+	v.AnnotationsFor("bio")                       // annotations for a field
+	v.AnnotationsOfKind(datacop.AnnotationAudit)  // annotations of a kind
+
+Validation functions created with datacop.Annotated can record annotations too. For example,
+is.PassesModeration records the categories a content moderation service flagged:
+
+	v.Field("comment", comment).Validate(is.PassesModeration(ctx, moderator))
+	v.AnnotationsOfKind(datacop.AnnotationModeration) // flagged categories
+
+# Scoring
+
+Rules wrapped with datacop.Weighted count toward a quality score. With WithScoring, failing
+rules lighter than the error weight are recorded as suggestions instead of errors:
+
+	v := datacop.New(datacop.WithScoring(5))
+	v.Field("email", p.Email).Validate(datacop.Weighted(10, is.Required), "email is required")
+	v.Field("photo", p.Photo).Validate(datacop.Weighted(3, is.Required), "add a profile photo")
+	v.Score()       // 0.77 without a photo: 10 of 13
+	v.Suggestions() // the failed light rules, heaviest first
+
 # Custom Validation Functions
 
 Creating custom validation functions is straightforward - any function that returns a bool can be used:
@@ -146,6 +285,16 @@ Creating custom validation functions is straightforward - any function that retu
 	// Usage
 	v.Check(MultipleOf(3)(age), "age", "age must be multiple of 3")
 
+Every constructor in the is package returns a NamedRule, a ValidationFunc created with Named whose
+Name and Params methods describe it, so rules can be introspected, for example to send their names
+to a frontend that has its own messages. Plain predicates such as is.Required stay functions and
+have their names registered with RegisterRule. FailedRules returns the names of the rules that
+failed for each field:
+
+	is.MinLength(8).Name()   // "min_length"
+	is.MinLength(8).Params() // map[min:8]
+	v.FailedRules()          // map[password:[min_length strong_password]]
+
 # Common Validation Functions
 
 The package provides many built-in validation functions:
@@ -153,6 +302,11 @@ The package provides many built-in validation functions:
 		// Basic validations
 		is.Required(value)              // checks if value is non-empty
 	 	is.NotZero(value)               // checks if numeric value is not zero
+		is.Accepted(value)              // checkbox accepted (true, "on", "1", "yes")
+		is.RequiredIf(accountType, "business")(value) // required when accountType is "business"
+		is.RequiredUnless(country, "US")(value) // required unless country is "US"
+		is.RequiredWith("shipping_address", addr)(value) // required when addr is present
+		is.RequiredWithout("email", email)(value) // required when email is missing
 		is.Match(`[a-zA-Z0-9]+`)(value) // regex pattern
 
 		// Comparison validations
@@ -161,8 +315,14 @@ The package provides many built-in validation functions:
 		is.Equal(10)(value)            // equal to value
 		is.EqualStrings("a", "b")      // equal strings
 		is.In("a", "b", "c")(value)   // value in set
+		is.InFold("draft", "published")(value) // string in set, ignoring case
+		is.Enum(StatusActive, StatusSuspended).Rule()(value) // typed string enum; Parse returns the declared value
 		is.AllIn("a", "b", "c")([]string{"a", "b"}) // all values in set
 		is.NoDuplicates()([]string{})  // unique values in slice
+		is.Subset(rolePerms)(userPerms)     // every value also in another slice
+		is.ContainsAll("read")(perms)       // all listed values present
+		is.Superset(required)(granted)      // alias of ContainsAll for a slice
+		is.Disjoint(forbidden)(roles)       // no values in common with another slice
 		is.Min(18)(value)              // minimum value
 		is.Max(65)(value)              // maximum value
 		is.MinLength(5)(value)         // minimum length
@@ -171,16 +331,118 @@ The package provides many built-in validation functions:
 		is.LessThan(100)(value)        // less than value
 		is.GreaterOrEqual(100)(value)  // greater or equal to value
 		is.LessOrEqual(100)(value)     // less or equal to value
+		is.MinNumeric(5)(value)        // any numeric type or numeric string, at least 5
+		is.MaxNumeric(10)(value)       // any numeric type or numeric string, at most 10
+		is.BetweenNumeric(1, 10)(value) // any numeric type or numeric string, within range
+		is.IntString(value)            // string holding an integer
+		is.FloatString(value)          // string holding a decimal number
 
 		// Time-based validations
 
 		is.Before(time.Now())          // time before now
 		is.After(time.Now())           // time after now
-		is.BetweenTime(start, end)     // time between two values
+		is.BeforeOrEqual(time.Now())   // time before or equal to now
+		is.AfterOrEqual(time.Now())    // time after or equal to now
+		is.BetweenTime(start, end)     // time between two values, excluding boundaries
+		is.BetweenTimeInclusive(start, end) // time between two values, including boundaries
+		is.MinAge(18)(birthdate)       // at least 18 years old
+		is.MaxAge(120)(birthdate)      // at most 120 years old
+		is.WithinDuration(5*time.Minute)(value) // within 5 minutes of now, either direction
+		is.NotOlderThan(24*time.Hour)(value) // no more than a day old
+		is.Timezone(value)             // IANA time zone name
+		is.Weekday(time.Monday)(value) // falls on one of the given weekdays
+		is.WithinBusinessHours("09:00", "17:00", loc)(value) // time of day within opening hours
+		is.SignedValue(secret, time.Hour)(value) // created by is.Sign with secret, at most an hour ago
 
 		// String regex validations
 		is.Email(value)                // email format
+		is.EmailWith(opts)(value)      // email format plus MX and domain list checks
 		is.Phone(value)                // phone number format
+		is.UUID(value)                 // canonical 8-4-4-4-12 UUID
+		is.PhoneNumber(is.PhoneForRegion("US"))(value) // international / regional phone number
+		is.Pattern("sku")(value)       // pattern registered with is.RegisterPattern
+		is.SemVer()(value)             // Semantic Versioning 2.0.0 version
+		is.SemVerInRange("^1.2.0")(value) // version satisfying an npm-style range
+
+		// Substring validations (each has a case-insensitive ...Fold variant)
+		is.StartsWith("https://")(value) // string prefix (StartsWithFold)
+		is.EndsWith(".pdf")(value)     // string suffix (EndsWithFold)
+		is.Contains("@")(value)        // contains a substring (ContainsFold)
+		is.NotContains("admin")(value) // does not contain a substring (NotContainsFold)
+
+		// Character class validations
+		is.Alpha(value)                // ASCII letters (AlphaUnicode for any script)
+		is.Alphanumeric(value)         // ASCII letters and digits (AlphanumericUnicode)
+		is.Numeric(value)              // ASCII digits (NumericUnicode)
+		is.ASCII(value)                // ASCII characters only
+		is.PrintableASCII(value)       // printable ASCII characters only
+		is.Slug(value)                 // lowercase URL slug (SlugUnicode)
+
+		// Text validations
+		is.HumanName()(value)          // personal name in any script
+		is.HumanName("ru")(value)      // personal name in a locale's script
+		is.NoBidiControl(value)        // no bidirectional override characters
+		is.NoLeadingTrailingSpace(value) // no surrounding white space
+		is.SingleLine(value)           // no line breaks
+		is.NoControlChars(value)       // no control characters other than tab and line breaks
+		is.UTF8(value)                 // valid UTF-8 string or byte slice
+		is.PassesModeration(ctx, moderator)(value) // not flagged by a content moderation service
+		is.SpellCheckWarn(dict)(value)  // never fails; warns about unknown words in Validate
+		is.MaxReadingLevel(8)(value)   // Flesch-Kincaid grade level at most 8 (see is.ReadingLevel)
+		is.MeaningfulAltText(10)(value) // image alt text that is not a file name or placeholder
+
+		// Image upload validations (*multipart.FileHeader, io.Reader, or []byte)
+		is.ImageMaxDimensions(1024, 1024)(value) // at most 1024x1024 pixels
+		is.ImageAspectRatio(16.0/9, 0.01)(value) // width/height within 0.01 of 16:9
+
+		// Composite validations
+		is.Password(value)             // DefaultPasswordPolicy
+		is.StrongPassword(policy)(value) // configurable PasswordPolicy
+		is.Username(value)             // common username rules
+		is.DisplayName(policy)(value)  // display name with reserved-word and look-alike checks
+
+		// Payment validations
+		is.IBAN()(value)               // IBAN with country length and mod-97 checksum
+		is.IBAN("DE", "FR")(value)     // IBAN from one of the given countries
+		is.BIC()(value)                // 8 or 11 character BIC (SWIFT code)
+		is.ABARoutingNumber()(value)   // US routing number with 3-7-1 checksum
+
+		// Tax identifier validations
+		is.SSN()(value)                // US Social Security number
+		is.EIN()(value)                // US Employer Identification Number
+		is.VATNumber("DE")(value)      // VAT number with format and check digits per country
+
+		// Encoding and hash format validations
+		is.Hexadecimal()(value)        // hexadecimal digits
+		is.Base64()(value)             // standard base64 with padding
+		is.Base64URL()(value)          // URL-safe base64, padded or not
+		is.MD5()(value)                // 32 hex digit MD5 digest (format only)
+		is.SHA256()(value)             // 64 hex digit SHA-256 digest (format only)
+
+		// Network validations
+		is.Hostname()(value)           // RFC 1123 host name
+		is.FQDN()(value)               // fully qualified domain name, optional trailing dot
+		is.Domain()(value)             // domain name such as example.com
+		is.DomainWith(publicsuffix.List)(value) // domain that is not itself a public suffix
+		is.Port()(value)               // port number from 1 to 65535, int or string
+		is.MACAddress()(value)         // 48-bit MAC address with ":", "-", or "." separators
+		is.IMEI()(value)               // 15 digit IMEI with Luhn check digit
+
+		// Geographic validations (numbers or numeric strings)
+		is.Latitude()(value)           // -90 to 90 degrees
+		is.Longitude()(value)          // -180 to 180 degrees
+		is.WithinBoundingBox(49.9, -8.6, 60.9, 1.8)(value) // coordinate pair within a box
+
+		// Color validations
+		is.HexColor()(value)           // "#rgb", "#rgba", "#rrggbb", or "#rrggbbaa"
+		is.RGBColor()(value)           // CSS rgb() or rgba() color
+		is.HSLColor()(value)           // CSS hsl() or hsla() color
+
+		// Verification code validations
+		is.TOTPCode(value)             // 6-8 digit one-time password
+		is.NumericCode(6)(value)       // fixed-length numeric code
+		is.BackupCodeFormat(value)     // recovery code format
+		is.CodeMatches(expected)(value) // constant-time code comparison
 
 # Error Handling
 
@@ -189,10 +451,94 @@ Multiple ways to access validation errors:
 	v.HasErrors()               // returns true if any errors exist
 	v.HasErrorFor("field")      // checks for field-specific errors
 	v.ErrorFor("field")         // gets error message for field
+	v.FirstErrorFor("field")    // gets the first error message for field
 	v.Errors()                  // returns map[string]string of all errors
-	v.Error()                   // returns formatted error string
+	v.AllErrors()               // returns map[string][]string of all errors
+	v.Error()                   // returns formatted error string, or NoErrorsMessage
+	v.ErrOrNil()                // returns v as an error, or nil if there are no errors
+	v.MarshalJSONDetailed()     // returns {"errors":[{"field","code","message"}]}
+	v.ToProblemDetails(422)     // returns an RFC 7807 problem details object
 	v.ValidationErrors()        // returns full error structs
+	v.OrderedErrors()           // returns error structs in a deterministic order
+	v.Canonical()               // returns sorted, normalized error structs for comparisons
+	datacop.Diff(old, v)        // returns the errors recorded by only one of two validators
 	v.StandaloneErrors()        // returns non-field-specific errors
+	v.Result()                  // returns an immutable snapshot of the errors with timestamps
+	v.FailedRules()             // returns the names of the failed rules by field
+
+A Result deep-copies the params and context of each error, so it can be handed to another
+goroutine or encoded as JSON while the validator is cleared and reused:
+
+	result := v.Result()
+	go audit.Record(result) // {"passed":false,"errors":[...],"started_at":...,"completed_at":...}
+
+A *Validator unwraps to ErrValidation, so validation failures can be told apart from other
+errors anywhere up the call stack:
+
+	if errors.Is(err, datacop.ErrValidation) {
+		v, _ := datacop.AsValidator(err)
+		return v.Errors()
+	}
+
+Messages can be templates with named placeholders, filled in with Checkf or Interpolate:
+
+	v.Checkf(len(pw) >= 8, "password", "{field} must be at least {min} characters", datacop.Params{"min": 8})
+
+Parameterized rules in the is package carry their name and parameters. When one fails in Validate
+or a RuleSet, the name becomes the error's code and the parameters its Params, which are included
+in detailed JSON output and can be used as placeholders in the message:
+
+	v.Field("password", pw).Validate(is.MinLength(8), "must be at least {min} characters")
+	// code "min_length", params {"min": 8}
+
+Custom rules can do the same with datacop.Named.
+
+The message can be omitted for named rules. The rule's default message is used instead, which
+can be changed per validator with SetDefaultMessage or for every validator with the
+WithDefaultMessages option:
+
+	v := datacop.New(datacop.WithDefaultMessages(map[string]string{
+		"min_length": "needs {min} or more characters",
+	}))
+	v.Field("name", name).Validate(is.MinLength(3)) // "needs 3 or more characters"
+
+Labels give fields human-friendly names. A {field} placeholder in a field's messages is replaced
+with its label rather than its name when the messages are read, so labels can be set at any time
+and message catalogs see the templates:
+
+	v.SetLabel("dob", "Date of Birth")
+	v.Check(dob != "", "dob", "{field} is required") // "Date of Birth is required"
+
+A reporter added with the WithReporter option observes every error as it is recorded, with its
+field, rule name, and value type, for logging or metrics:
+
+	v := datacop.New(datacop.WithReporter(datacop.ReporterFunc(func(f datacop.Failure) {
+		failures.WithLabelValues(f.Field, f.Rule).Inc()
+	})))
+
+Validations that record millions of errors can write them to an ErrorSink instead, keeping only
+a sample in memory. HasErrors and ErrorCount still account for every error, and
+batch.ValidateStream does the same for every record of a batch:
+
+	v := datacop.New(datacop.WithErrorSink(sink, 100)) // keep the first 100 errors
+	v.ErrorCount()                                      // all errors, kept or not
+	v.SinkErr()                                         // the first error the sink returned
+
+Errors can point at the location of a field in the submitted document. Positions set with
+SetPosition, as schema.ValidateJSON, schema.ValidateYAML, and web.FormPositions do for every
+field, are attached to the errors recorded afterwards and included in detailed JSON output:
+
+	v.SetPosition("email", datacop.Position{Offset: 42, Line: 3, Column: 5})
+	v.Check(false, "email", "is invalid") // Position is line 3, column 5
+
+Metadata set with SetContextValue, such as a request ID, is attached to every error recorded
+afterwards and included in detailed JSON output and in the failures passed to reporters, so
+failures can be correlated across services:
+
+	v.SetContextValue("request_id", r.Header.Get("X-Request-ID"))
+
+Validators created with datacop.New(datacop.WithStableOutput()) render their JSON from the
+canonical form, so the output does not depend on the order in which checks ran.
 
 # Common Patterns
 
@@ -228,10 +574,10 @@ Form validation example:
 		v.Field("age", form.Age).
 			Check(is.Min(18)(form.Age), "must be 18 or older")
 
-		if v.HasErrors() {
-			return v
-		}
-		return nil
+		return v.ErrOrNil()
 	}
+
+Always return v.ErrOrNil() rather than v itself: an empty *Validator stored in an error
+interface is a non-nil error.
 */
 package datacop
@@ -0,0 +1,100 @@
+// Package dq runs data-quality assertions against the rows stored in a database, reusing the
+// rules of the write path. A nightly job can query a table, validate each row against the same
+// schema.Schema that validates incoming requests, and report the rows that no longer satisfy it,
+// for example because they were written before a rule was tightened or by another system.
+//
+// Example usage:
+//
+//	res, err := dq.ValidateQuery(ctx, db, "SELECT id, email, plan FROM users", signupSchema)
+//	if err != nil {
+//		return err
+//	}
+//	for _, row := range res.Rows {
+//		log.Printf("row %d: %v", row.Index, row.Errors)
+//	}
+package dq
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/batch"
+	"github.com/patrickward/datacop/schema"
+)
+
+// Queryer runs queries that return rows. It is satisfied by *sql.DB, *sql.Tx, and *sql.Conn.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ValidateQuery runs query with args and validates each row it returns against s, streaming the
+// rows through a batch validator so only rows with violations are kept in memory. Each row is a
+// batch.Record keyed by column name, with []byte values converted to strings; the index of a row
+// in the result is its position in the query's results. Select the table's key columns too, so
+// reported rows can be found again.
+//
+// If the query or reading its rows fails, the error is returned with the violations found in the
+// rows read so far.
+//
+// Example usage:
+//
+//	res, err := dq.ValidateQuery(ctx, db, "SELECT id, email FROM users WHERE created_at > ?", s, since)
+//	data, _ := batch.ToJUnitXML(res, batch.WithSuiteName("users"))
+func ValidateQuery(ctx context.Context, db Queryer, query string, s *schema.Schema, args ...any) (*batch.Result, error) {
+	b := batch.New(func(v *datacop.Validator, r batch.Record) {
+		s.Validate(v, r)
+	})
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return &batch.Result{}, fmt.Errorf("dq: query: %w", err)
+	}
+	defer rows.Close()
+
+	res, err := b.ValidateSeq(records(rows))
+	if err != nil {
+		return res, fmt.Errorf("dq: reading rows: %w", err)
+	}
+	return res, nil
+}
+
+// records yields the rows as records keyed by column name
+func records(rows *sql.Rows) iter.Seq2[batch.Record, error] {
+	return func(yield func(batch.Record, error) bool) {
+		columns, err := rows.Columns()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		for rows.Next() {
+			if err := rows.Scan(dest...); err != nil {
+				yield(nil, err)
+				return
+			}
+			r := make(batch.Record, len(columns))
+			for i, column := range columns {
+				if b, ok := values[i].([]byte); ok {
+					r[column] = string(b)
+				} else {
+					r[column] = values[i]
+				}
+			}
+			if !yield(r, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package dq_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/dq"
+	"github.com/patrickward/datacop/schema"
+)
+
+// fakeDriver answers every query with its rows, failing with rowErr after failAfter rows
+type fakeDriver struct {
+	columns   []string
+	rows      [][]driver.Value
+	queryErr  error
+	rowErr    error
+	failAfter int
+	queries   []string
+	args      [][]any
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.queries = append(c.d.queries, query)
+	values := make([]any, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	c.d.args = append(c.d.args, values)
+
+	if c.d.queryErr != nil {
+		return nil, c.d.queryErr
+	}
+	return &fakeRows{d: c.d}, nil
+}
+
+type fakeRows struct {
+	d    *fakeDriver
+	next int
+}
+
+func (r *fakeRows) Columns() []string { return r.d.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.d.rowErr != nil && r.next == r.d.failAfter {
+		return r.d.rowErr
+	}
+	if r.next >= len(r.d.rows) {
+		return io.EOF
+	}
+	copy(dest, r.d.rows[r.next])
+	r.next++
+	return nil
+}
+
+var driverCount int
+
+func openFake(t *testing.T, d *fakeDriver) *sql.DB {
+	driverCount++
+	name := fmt.Sprintf("dq-fake-%d", driverCount)
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func usersSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("id").Integer().Required()
+	s.Field("email").String().Required().Format(schema.FormatEmail)
+	s.Field("plan").String().Enum("free", "pro")
+	return s
+}
+
+func TestValidateQuery(t *testing.T) {
+	d := &fakeDriver{
+		columns: []string{"id", "email", "plan"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("ada@example.com"), "pro"},
+			{int64(2), "not-an-email", "free"},
+			{int64(3), "grace@example.com", nil},
+			{int64(4), nil, "enterprise"},
+		},
+	}
+	db := openFake(t, d)
+
+	res, err := dq.ValidateQuery(context.Background(), db, "SELECT id, email, plan FROM users WHERE id > ?", usersSchema(), 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"SELECT id, email, plan FROM users WHERE id > ?"}, d.queries)
+	assert.Equal(t, [][]any{{int64(0)}}, d.args)
+	assert.Equal(t, 4, res.Total)
+	require.Len(t, res.Rows, 2)
+
+	assert.Equal(t, 1, res.Rows[0].Index)
+	assert.Equal(t, "must be a valid email", res.Rows[0].Errors.Errors()["email"])
+
+	assert.Equal(t, 3, res.Rows[1].Index)
+	assert.Equal(t, "is required", res.Rows[1].Errors.Errors()["email"])
+	assert.Equal(t, "is not an allowed value", res.Rows[1].Errors.Errors()["plan"])
+}
+
+func TestValidateQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  *fakeDriver
+		wantErr string
+		total   int
+		rows    int
+	}{
+		{
+			name:    "query fails",
+			driver:  &fakeDriver{queryErr: errors.New("no such table: users")},
+			wantErr: "dq: query: no such table: users",
+		},
+		{
+			name: "reading rows fails",
+			driver: &fakeDriver{
+				columns: []string{"id", "email"},
+				rows: [][]driver.Value{
+					{int64(1), "bad"},
+					{int64(2), "ada@example.com"},
+					{int64(3), "grace@example.com"},
+				},
+				rowErr:    errors.New("connection reset"),
+				failAfter: 2,
+			},
+			wantErr: "dq: reading rows: connection reset",
+			total:   2,
+			rows:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFake(t, tt.driver)
+
+			res, err := dq.ValidateQuery(context.Background(), db, "SELECT id, email FROM users", usersSchema())
+			require.EqualError(t, err, tt.wantErr)
+			require.NotNil(t, res)
+			assert.Equal(t, tt.total, res.Total)
+			assert.Len(t, res.Rows, tt.rows)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package datacop
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of explain steps
+const (
+	StepRule      = "rule"      // a validation function run by Validate or RuleSet.Apply
+	StepCheck     = "check"     // a precomputed result passed to a chain's Check
+	StepCondition = "condition" // a When, Unless, or Otherwise condition
+)
+
+// RedactedInput replaces the input of sensitive fields in explain traces
+const RedactedInput = "[redacted]"
+
+// maxExplainInput is the number of characters of an input kept in an explain trace
+const maxExplainInput = 64
+
+// ExplainStep is one evaluation recorded by a validator created with WithExplain
+type ExplainStep struct {
+	Kind     string // StepRule, StepCheck, or StepCondition
+	Field    string
+	Rule     string // the rule's name, or "" for unnamed rules, checks, and conditions
+	Params   Params // the rule's params
+	Input    string // a snapshot of the value, or RedactedInput for sensitive fields
+	Passed   bool   // whether the rule or check passed, or the condition held
+	Skipped  bool   // whether the rule or check was skipped because a condition did not hold
+	Memoized bool   // whether the result was reused; see WithIntraRequestMemo
+}
+
+// String describes the step on one line, such as
+// `email: rule min_length map[min:3] on "ab": failed`
+func (s ExplainStep) String() string {
+	var b strings.Builder
+	b.WriteString(s.Field)
+	b.WriteString(": ")
+	b.WriteString(s.Kind)
+	if s.Rule != "" {
+		b.WriteString(" " + s.Rule)
+	}
+	if len(s.Params) > 0 {
+		fmt.Fprintf(&b, " %v", s.Params)
+	}
+	b.WriteString(" on " + s.Input + ": ")
+
+	switch {
+	case s.Skipped:
+		b.WriteString("skipped")
+	case s.Kind == StepCondition && s.Passed:
+		b.WriteString("holds")
+	case s.Kind == StepCondition:
+		b.WriteString("does not hold")
+	case s.Passed:
+		b.WriteString("passed")
+	default:
+		b.WriteString("failed")
+	}
+	if s.Memoized {
+		b.WriteString(" (memoized)")
+	}
+	return b.String()
+}
+
+// Trace is the list of steps recorded by a validator created with WithExplain
+type Trace []ExplainStep
+
+// String describes each step on its own line
+func (t Trace) String() string {
+	lines := make([]string, len(t))
+	for i, s := range t {
+		lines[i] = s.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WithExplain makes the validator record every rule, check, and condition it evaluates in the
+// chains of Field and Group and in RuleSet.Apply, with a snapshot of the input and the outcome,
+// for debugging why a value passed or failed. Recording costs time and memory, so enable it in
+// development or for a sampled request rather than everywhere.
+//
+// The inputs of fields marked with WithSensitiveFields are replaced with RedactedInput.
+//
+// Example usage:
+//
+//	v := datacop.New(datacop.WithExplain(), datacop.WithSensitiveFields("password"))
+//	form.Validate(v)
+//	log.Println(v.Explain())
+func WithExplain() Option {
+	return func(v *Validator) {
+		v.explain = true
+	}
+}
+
+// WithSensitiveFields marks fields whose values must not appear in explain traces, such as
+// passwords or personal data
+//
+// Example usage:
+// v := datacop.New(datacop.WithExplain(), datacop.WithSensitiveFields("password", "ssn"))
+func WithSensitiveFields(fields ...string) Option {
+	return func(v *Validator) {
+		if v.sensitive == nil {
+			v.sensitive = make(map[string]bool, len(fields))
+		}
+		for _, field := range fields {
+			v.sensitive[field] = true
+		}
+	}
+}
+
+// Explain returns the steps recorded so far, in evaluation order, or nil if the validator was not
+// created with WithExplain
+func (v *Validator) Explain() Trace {
+	if len(v.trace) == 0 {
+		return nil
+	}
+	return append(Trace(nil), v.trace...)
+}
+
+// explainStep records s if explaining is enabled, snapshotting value as its input
+func (v *Validator) explainStep(s ExplainStep, value any) {
+	if !v.explain {
+		return
+	}
+	s.Input = v.explainInput(s.Field, value)
+	v.trace = append(v.trace, s)
+}
+
+// explainInput returns the snapshot of value recorded in a trace
+func (v *Validator) explainInput(field string, value any) string {
+	if v.sensitive[v.prefix+field] {
+		return RedactedInput
+	}
+
+	var input string
+	switch value := value.(type) {
+	case string:
+		input = fmt.Sprintf("%q", value)
+	case nil:
+		input = "nil"
+	default:
+		input = fmt.Sprintf("%v", value)
+	}
+	if utf8.RuneCountInString(input) > maxExplainInput {
+		input = string([]rune(input)[:maxExplainInput]) + "..."
+	}
+	return input
+}
@@ -0,0 +1,90 @@
+package datacop_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestWithExplain(t *testing.T) {
+	v := datacop.New(datacop.WithExplain(), datacop.WithSensitiveFields("password"))
+	v.Field("email", "ab").
+		Validate(is.Required, "is required").
+		Validate(is.MinLength(3), "is too short").
+		Check(true, "unused")
+	v.Field("password", "hunter2").Validate(is.MinLength(8))
+
+	trace := v.Explain()
+	require.Len(t, trace, 4)
+	assert.Equal(t, datacop.ExplainStep{Kind: datacop.StepRule, Field: "email", Rule: "required", Input: `"ab"`, Passed: true}, trace[0])
+	assert.Equal(t, datacop.ExplainStep{
+		Kind: datacop.StepRule, Field: "email", Rule: "min_length", Params: datacop.Params{"min": 3}, Input: `"ab"`,
+	}, trace[1])
+	assert.Equal(t, datacop.ExplainStep{Kind: datacop.StepCheck, Field: "email", Input: `"ab"`, Passed: true}, trace[2])
+	assert.Equal(t, datacop.RedactedInput, trace[3].Input)
+
+	assert.Equal(t, strings.Join([]string{
+		`email: rule required on "ab": passed`,
+		`email: rule min_length map[min:3] on "ab": failed`,
+		`email: check on "ab": passed`,
+		`password: rule min_length map[min:8] on [redacted]: failed`,
+	}, "\n"), trace.String())
+}
+
+func TestWithExplain_Conditions(t *testing.T) {
+	v := datacop.New(datacop.WithExplain())
+	v.Field("vat_id", "").
+		When(true).
+		WhenValue(func(value any) bool { return value != "" }).
+		Validate(is.MinLength(5)).
+		Check(false, "skipped").
+		Otherwise().
+		Validate(is.Required, "is required")
+
+	assert.Equal(t, strings.Join([]string{
+		`vat_id: condition on "": holds`,
+		`vat_id: condition on "": does not hold`,
+		`vat_id: rule min_length map[min:5] on "": skipped`,
+		`vat_id: check on "": skipped`,
+		`vat_id: condition on "": holds`,
+		`vat_id: rule required on "": failed`,
+	}, "\n"), v.Explain().String())
+}
+
+func TestWithExplain_Memoized(t *testing.T) {
+	v := datacop.New(datacop.WithExplain(), datacop.WithIntraRequestMemo())
+	v.Field("a", "x").Validate(is.MinLength(3))
+	v.Field("b", "x").Validate(is.MinLength(3))
+
+	trace := v.Explain()
+	require.Len(t, trace, 2)
+	assert.False(t, trace[0].Memoized)
+	assert.True(t, trace[1].Memoized)
+	assert.Equal(t, `b: rule min_length map[min:3] on "x": failed (memoized)`, trace[1].String())
+}
+
+func TestWithExplain_Input(t *testing.T) {
+	v := datacop.New(datacop.WithExplain())
+	v.Field("bio", strings.Repeat("a", 100)).Check(true, "")
+	v.Field("tags", []string{"a", "b"}).Check(true, "")
+	v.Field("parent", nil).Check(true, "")
+
+	trace := v.Explain()
+	assert.Equal(t, `"`+strings.Repeat("a", 63)+"...", trace[0].Input)
+	assert.Equal(t, "[a b]", trace[1].Input)
+	assert.Equal(t, "nil", trace[2].Input)
+
+	v.Clear()
+	assert.Nil(t, v.Explain())
+}
+
+func TestExplain_Disabled(t *testing.T) {
+	v := datacop.New()
+	v.Field("email", "").Validate(is.Required, "is required")
+	assert.Nil(t, v.Explain())
+}
@@ -1,12 +1,14 @@
 module github.com/patrickward/datacop
 
-go 1.23.4
+go 1.24.0
 
-require golang.org/x/exp v0.0.0-20241217172543-b2144cdd0a67
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
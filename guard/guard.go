@@ -0,0 +1,116 @@
+// Package guard validates domain entities at the point where they are persisted, as a last line
+// of defense against invalid data reaching the database.
+//
+// Example usage:
+//
+//	func (s *Service) UpdateProfile(ctx context.Context, u *User) error {
+//		return guard.Save(ctx, u, s.repo.SaveUser)
+//	}
+package guard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/patrickward/datacop"
+)
+
+// AnnotationRejected is the kind of annotation recorded when an entity is refused persistence.
+// Its data holds the entity's type under "entity" and its ID, if known, under "id".
+const AnnotationRejected = "rejected"
+
+// Identifier is implemented by entities that report their own ID. Entities without an EntityID
+// method are identified by an exported ID field, if they have one.
+type Identifier interface {
+	EntityID() string
+}
+
+// Error is returned by Save when an entity fails validation. It unwraps to the validator holding
+// the failures, so datacop.AsValidator and errors.Is(err, datacop.ErrValidation) work on it.
+type Error struct {
+	Entity    string // the entity's type name, such as "User"
+	ID        string // the entity's ID, or "" if unknown or not yet assigned
+	Validator *datacop.Validator
+}
+
+func (e *Error) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("guard: refusing to save %s: %s", e.Entity, e.Validator.Error())
+	}
+	return fmt.Sprintf("guard: refusing to save %s %s: %s", e.Entity, e.ID, e.Validator.Error())
+}
+
+func (e *Error) Unwrap() error {
+	return e.Validator
+}
+
+// Save validates entity and calls save only if it has no errors. Otherwise save is not called
+// and an *Error is returned whose validator holds the failures, annotated with the entity's type
+// and ID. Errors returned by save are returned unchanged.
+//
+// Example usage:
+//
+//	err := guard.Save(ctx, order, repo.SaveOrder)
+//	if v, ok := datacop.AsValidator(err); ok {
+//		log.Printf("order rejected: %v", v.Errors())
+//	}
+func Save[T datacop.Validatable](ctx context.Context, entity T, save func(context.Context, T) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	v := datacop.New()
+	entity.Validate(v)
+	if !v.HasErrors() {
+		return save(ctx, entity)
+	}
+
+	e := &Error{Entity: entityType(entity), ID: entityID(entity), Validator: v}
+	data := map[string]any{"entity": e.Entity}
+	if e.ID != "" {
+		data["id"] = e.ID
+	}
+	v.Annotate("", AnnotationRejected, "refused to save invalid "+e.Entity, data)
+	return e
+}
+
+// entityType returns the name of the entity's type, without pointers
+func entityType(entity any) string {
+	t := reflect.TypeOf(entity)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.Name()
+}
+
+// entityID returns the entity's EntityID or the value of its exported ID field, or "" if it has
+// neither or the ID is the zero value
+func entityID(entity any) string {
+	if id, ok := entity.(Identifier); ok {
+		return id.EntityID()
+	}
+
+	rv := reflect.ValueOf(entity)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	field, ok := rv.Type().FieldByName("ID")
+	if !ok || !field.IsExported() {
+		return ""
+	}
+	id := rv.FieldByIndex(field.Index)
+	if id.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(id.Interface())
+}
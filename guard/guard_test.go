@@ -0,0 +1,93 @@
+package guard_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/guard"
+	"github.com/patrickward/datacop/is"
+)
+
+type User struct {
+	ID    int
+	Email string
+}
+
+func (u *User) Validate(v *datacop.Validator) {
+	v.Field("email", u.Email).Validate(is.Email, "is not a valid email address")
+}
+
+type Order struct {
+	Number string
+	Total  float64
+}
+
+func (o Order) EntityID() string {
+	return o.Number
+}
+
+func (o Order) Validate(v *datacop.Validator) {
+	v.Field("total", o.Total).Validate(is.GreaterThan(0.0), "must be positive")
+}
+
+func TestSave(t *testing.T) {
+	var saved []*User
+	save := func(_ context.Context, u *User) error {
+		saved = append(saved, u)
+		return nil
+	}
+
+	require.NoError(t, guard.Save(context.Background(), &User{ID: 1, Email: "ada@example.com"}, save))
+	assert.Len(t, saved, 1)
+
+	err := guard.Save(context.Background(), &User{ID: 42, Email: "not-an-email"}, save)
+	require.Error(t, err)
+	assert.Len(t, saved, 1, "invalid entities are not saved")
+
+	var gerr *guard.Error
+	require.ErrorAs(t, err, &gerr)
+	assert.Equal(t, "User", gerr.Entity)
+	assert.Equal(t, "42", gerr.ID)
+	assert.ErrorIs(t, err, datacop.ErrValidation)
+	assert.Equal(t, "guard: refusing to save User 42: email: [is not a valid email address]", err.Error())
+
+	v, ok := datacop.AsValidator(err)
+	require.True(t, ok)
+	assert.Equal(t, "is not a valid email address", v.ErrorFor("email"))
+
+	annotations := v.AnnotationsOfKind(guard.AnnotationRejected)
+	require.Len(t, annotations, 1)
+	assert.Equal(t, map[string]any{"entity": "User", "id": "42"}, annotations[0].Data)
+}
+
+func TestSave_EntityID(t *testing.T) {
+	save := func(context.Context, Order) error { return nil }
+
+	var gerr *guard.Error
+	require.ErrorAs(t, guard.Save(context.Background(), Order{Number: "A-17"}, save), &gerr)
+	assert.Equal(t, "Order", gerr.Entity)
+	assert.Equal(t, "A-17", gerr.ID)
+
+	// New entities without an ID
+	require.ErrorAs(t, guard.Save(context.Background(), &User{Email: "x"}, func(context.Context, *User) error { return nil }), &gerr)
+	assert.Empty(t, gerr.ID)
+	assert.Equal(t, "guard: refusing to save User: email: [is not a valid email address]", gerr.Error())
+}
+
+func TestSave_Errors(t *testing.T) {
+	failure := errors.New("connection refused")
+	err := guard.Save(context.Background(), Order{Total: 10}, func(context.Context, Order) error { return failure })
+	assert.ErrorIs(t, err, failure)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	err = guard.Save(ctx, Order{Total: 10}, func(context.Context, Order) error { called = true; return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
@@ -0,0 +1,61 @@
+package guard
+
+import (
+	"slices"
+
+	"github.com/patrickward/datacop"
+)
+
+// InvariantSet is a fixed list of invariants of a domain type. Build it once, typically as a
+// package variable, and run it after every mutation of an aggregate.
+type InvariantSet[T any] struct {
+	checks []func(T) (bool, string)
+}
+
+// Invariants creates an invariant set from checks. Each check reports whether the invariant
+// holds and the message recorded when it does not.
+//
+// Example usage:
+//
+//	var orderInvariants = guard.Invariants(
+//		func(o *Order) (bool, string) { return o.Total >= 0, "order total cannot be negative" },
+//		func(o *Order) (bool, string) { return o.Status != "shipped" || o.ShippedAt != nil, "shipped orders need a ship date" },
+//	)
+//
+//	func (o *Order) AddLine(line Line) error {
+//		o.Lines = append(o.Lines, line)
+//		o.Total += line.Amount
+//		return orderInvariants.Err(o)
+//	}
+func Invariants[T any](checks ...func(T) (bool, string)) *InvariantSet[T] {
+	return &InvariantSet[T]{checks: slices.Clone(checks)}
+}
+
+// Check runs every invariant against entity and records each violation on v as a standalone
+// error. It returns true if all invariants hold. Calling it from an entity's Validate method lets
+// Save enforce input validation and invariants together.
+//
+// Example usage:
+//
+//	func (o *Order) Validate(v *datacop.Validator) {
+//		v.Field("customer_id", o.CustomerID).Validate(is.Required, "is required")
+//		orderInvariants.Check(v, o)
+//	}
+func (s *InvariantSet[T]) Check(v *datacop.Validator, entity T) bool {
+	valid := true
+	for _, check := range s.checks {
+		if ok, message := check(entity); !ok {
+			v.AddStandaloneError(message)
+			valid = false
+		}
+	}
+	return valid
+}
+
+// Err runs every invariant against entity and returns a *datacop.Validator holding the
+// violations, or nil if all invariants hold
+func (s *InvariantSet[T]) Err(entity T) error {
+	v := datacop.New()
+	s.Check(v, entity)
+	return v.ErrOrNil()
+}
@@ -0,0 +1,67 @@
+package guard_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/guard"
+)
+
+type Account struct {
+	Balance int
+	Limit   int
+	Closed  bool
+}
+
+var accountInvariants = guard.Invariants(
+	func(a *Account) (bool, string) { return a.Balance >= -a.Limit, "balance exceeds the overdraft limit" },
+	func(a *Account) (bool, string) {
+		return !a.Closed || a.Balance == 0, "closed accounts must have a zero balance"
+	},
+)
+
+func (a *Account) Validate(v *datacop.Validator) {
+	accountInvariants.Check(v, a)
+}
+
+func TestInvariants(t *testing.T) {
+	a := &Account{Balance: 100, Limit: 50}
+	require.NoError(t, accountInvariants.Err(a))
+
+	a.Balance = -80
+	a.Closed = true
+	err := accountInvariants.Err(a)
+	require.Error(t, err)
+
+	v, ok := datacop.AsValidator(err)
+	require.True(t, ok)
+	assert.Equal(t, []string{
+		"balance exceeds the overdraft limit",
+		"closed accounts must have a zero balance",
+	}, v.StandaloneErrors())
+}
+
+func TestInvariants_Check(t *testing.T) {
+	v := datacop.New()
+	v.Field("owner", "").Check(false, "is required")
+
+	assert.False(t, accountInvariants.Check(v, &Account{Balance: -1}))
+	assert.Equal(t, "is required", v.ErrorFor("owner"))
+	assert.Equal(t, []string{"balance exceeds the overdraft limit"}, v.StandaloneErrors())
+
+	assert.True(t, accountInvariants.Check(datacop.New(), &Account{}))
+}
+
+func TestInvariants_Save(t *testing.T) {
+	saved := false
+	err := guard.Save(context.Background(), &Account{Closed: true, Balance: 10}, func(context.Context, *Account) error {
+		saved = true
+		return nil
+	})
+	assert.ErrorIs(t, err, datacop.ErrValidation)
+	assert.False(t, saved)
+}
@@ -0,0 +1,234 @@
+// Package hclvalid validates HCL configuration files, such as Terraform-style configuration,
+// with datacop rule sets.
+//
+// Rules are declared per attribute and nested per block type with a Schema. Every validated
+// attribute is recorded under a dotted path made of the block types, block labels, and attribute
+// name, such as "service.web.port", and its source range is kept so that failures can be reported
+// with file and line information.
+//
+// Whole numbers are validated as int64 and other numbers as float64, so numeric rules should be
+// instantiated with those types, such as is.Between[int64](1, 65535).
+//
+// The package is a separate module, github.com/patrickward/datacop/hclvalid, so that only
+// applications that validate HCL depend on the HCL libraries.
+package hclvalid
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/patrickward/datacop"
+)
+
+// Schema declares the rules for the attributes of a body and its nested blocks
+type Schema struct {
+	// Attributes maps attribute names to the rules applied to their values.
+	// Missing attributes are validated as nil, so is.Required can be used to require them.
+	Attributes map[string]datacop.RuleSet
+
+	// Blocks maps block types to the schema of their bodies
+	Blocks map[string]*Schema
+}
+
+// Ranges maps the field paths recorded on a validator to their location in the source
+type Ranges map[string]hcl.Range
+
+// Checker parses HCL sources and validates them against a schema
+type Checker struct {
+	schema *Schema
+	ctx    *hcl.EvalContext
+}
+
+// New creates a checker for the given schema
+//
+// Example usage:
+//
+//	checker := hclvalid.New(&hclvalid.Schema{
+//		Blocks: map[string]*hclvalid.Schema{
+//			"service": {
+//				Attributes: map[string]datacop.RuleSet{
+//					"image": {{Func: is.Required, Message: "image is required"}},
+//					"port":  {{Func: is.Between[int64](1, 65535), Message: "port must be between 1 and 65535"}},
+//				},
+//			},
+//		},
+//	})
+//
+//	v := datacop.New()
+//	ranges := checker.Check(v, "app.hcl", src)
+//	if v.HasErrors() {
+//		wr := hcl.NewDiagnosticTextWriter(os.Stderr, nil, 80, false)
+//		_ = wr.WriteDiagnostics(ranges.Diagnostics(v))
+//	}
+func New(schema *Schema) *Checker {
+	return &Checker{schema: schema}
+}
+
+// WithEvalContext sets the variables and functions available to attribute expressions.
+// Without it, only literal expressions can be evaluated.
+func (c *Checker) WithEvalContext(ctx *hcl.EvalContext) *Checker {
+	c.ctx = ctx
+	return c
+}
+
+// Check parses src as native HCL syntax and validates it, recording failures on v. Syntax
+// errors are recorded as standalone errors that include their location. Attributes whose
+// expressions cannot be evaluated are recorded as errors on the attribute.
+//
+// It returns the source range of every validated field. Missing attributes are given the range
+// of the block that should contain them.
+func (c *Checker) Check(v *datacop.Validator, filename string, src []byte) Ranges {
+	ranges := make(Ranges)
+
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		for _, d := range diags.Errs() {
+			v.AddStandaloneError(d.Error())
+		}
+		return ranges
+	}
+
+	body := file.Body.(*hclsyntax.Body)
+	c.checkBody(v, ranges, c.schema, "", body, body.SrcRange)
+	return ranges
+}
+
+func (c *Checker) checkBody(v *datacop.Validator, ranges Ranges, s *Schema, prefix string, body *hclsyntax.Body, blockRange hcl.Range) {
+	if s == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(s.Attributes) {
+		path := join(prefix, name)
+		attr, ok := body.Attributes[name]
+		if !ok {
+			ranges[path] = blockRange
+			s.Attributes[name].Apply(v, path, nil)
+			continue
+		}
+
+		ranges[path] = attr.SrcRange
+		value, diags := attr.Expr.Value(c.ctx)
+		if diags.HasErrors() {
+			v.AddError(path, firstError(diags).Summary)
+			continue
+		}
+		s.Attributes[name].Apply(v, path, goValue(value))
+	}
+
+	// Unlabeled blocks that appear more than once are told apart by their index
+	counts := make(map[string]int)
+	for _, block := range body.Blocks {
+		if len(block.Labels) == 0 {
+			counts[block.Type]++
+		}
+	}
+
+	seen := make(map[string]int)
+	for _, block := range body.Blocks {
+		nested, ok := s.Blocks[block.Type]
+		if !ok {
+			continue
+		}
+
+		path := join(prefix, block.Type)
+		if len(block.Labels) > 0 {
+			path = join(path, strings.Join(block.Labels, "."))
+		} else if counts[block.Type] > 1 {
+			path = fmt.Sprintf("%s[%d]", path, seen[block.Type])
+			seen[block.Type]++
+		}
+		c.checkBody(v, ranges, nested, path, block.Body, block.DefRange())
+	}
+}
+
+// Diagnostics converts the errors recorded on v into HCL diagnostics, using the source ranges
+// of their fields. Errors on fields without a known range are reported without a subject.
+func (r Ranges) Diagnostics(v *datacop.Validator) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, e := range v.OrderedErrors() {
+		if e.Field == datacop.StandaloneErrorKey {
+			continue
+		}
+		d := &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid " + e.Field,
+			Detail:   e.Message,
+		}
+		if rng, ok := r[e.Field]; ok {
+			d.Subject = rng.Ptr()
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// goValue converts a cty value into the plain Go values the is package works with. Numbers
+// become int64 when they are whole and fit, and float64 otherwise. Null and unknown values
+// become nil.
+func goValue(value cty.Value) any {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+
+	t := value.Type()
+	switch {
+	case t == cty.String:
+		return value.AsString()
+	case t == cty.Bool:
+		return value.True()
+	case t == cty.Number:
+		bf := value.AsBigFloat()
+		if n, acc := bf.Int64(); acc == big.Exact {
+			return n
+		}
+		f, _ := bf.Float64()
+		return f
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		out := make([]any, 0, value.LengthInt())
+		for it := value.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			out = append(out, goValue(elem))
+		}
+		return out
+	case t.IsMapType() || t.IsObjectType():
+		out := make(map[string]any, value.LengthInt())
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			out[key.AsString()] = goValue(elem)
+		}
+		return out
+	}
+	return nil
+}
+
+func firstError(diags hcl.Diagnostics) *hcl.Diagnostic {
+	for _, d := range diags {
+		if d.Severity == hcl.DiagError {
+			return d
+		}
+	}
+	return nil
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func sortedKeys(m map[string]datacop.RuleSet) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
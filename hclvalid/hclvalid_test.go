@@ -0,0 +1,154 @@
+package hclvalid_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/hclvalid"
+	"github.com/patrickward/datacop/is"
+)
+
+var schema = &hclvalid.Schema{
+	Attributes: map[string]datacop.RuleSet{
+		"region": {{Func: is.In("us-east-1", "eu-west-1"), Message: "unsupported region"}},
+	},
+	Blocks: map[string]*hclvalid.Schema{
+		"service": {
+			Attributes: map[string]datacop.RuleSet{
+				"image": {{Func: is.Required, Message: "image is required"}},
+				"port":  {{Func: is.Between[int64](1, 65535), Message: "port must be between 1 and 65535"}},
+			},
+			Blocks: map[string]*hclvalid.Schema{
+				"healthcheck": {
+					Attributes: map[string]datacop.RuleSet{
+						"interval": {{Func: is.Between[int64](1, 300), Message: "interval must be between 1 and 300"}},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid configuration",
+			src: `
+region = "us-east-1"
+
+service "web" {
+  image = "nginx:1.27"
+  port  = 8080
+
+  healthcheck {
+    interval = 30
+  }
+}
+`,
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "invalid attributes in nested blocks",
+			src: `
+region = "ap-south-1"
+
+service "web" {
+  port = 70000
+
+  healthcheck {
+    interval = 0
+  }
+  healthcheck {
+    interval = 10
+  }
+}
+`,
+			wantErrors: map[string]string{
+				"region":                              "unsupported region",
+				"service.web.image":                   "image is required",
+				"service.web.port":                    "port must be between 1 and 65535",
+				"service.web.healthcheck[0].interval": "interval must be between 1 and 300",
+			},
+		},
+		{
+			name: "expression that cannot be evaluated",
+			src: `
+region = var.region
+`,
+			wantErrors: map[string]string{
+				"region": "Variables not allowed",
+			},
+		},
+		{
+			name: "syntax error",
+			src: `
+service "web" {
+  image =
+}
+`,
+			wantErrors: map[string]string{
+				datacop.StandaloneErrorKey: "app.hcl:3,10-4,1: Invalid expression; Expected the start of an expression, but found an invalid expression token.",
+			},
+		},
+	}
+
+	checker := hclvalid.New(schema)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			checker.Check(v, "app.hcl", []byte(tt.src))
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestChecker_WithEvalContext(t *testing.T) {
+	checker := hclvalid.New(schema).WithEvalContext(&hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{"region": cty.StringVal("eu-west-1")}),
+		},
+	})
+
+	v := datacop.New()
+	checker.Check(v, "app.hcl", []byte(`region = var.region`))
+	assert.False(t, v.HasErrors(), v.Error())
+}
+
+func TestRanges_Diagnostics(t *testing.T) {
+	src := `region = "eu-west-1"
+
+service "web" {
+  image = "nginx"
+  port  = 0
+}
+
+service "api" {
+  port = 8080
+}
+`
+	v := datacop.New()
+	ranges := hclvalid.New(schema).Check(v, "app.hcl", []byte(src))
+
+	diags := ranges.Diagnostics(v)
+	require.Len(t, diags, 2)
+
+	assert.Equal(t, "Invalid service.web.port", diags[0].Summary)
+	assert.Equal(t, "port must be between 1 and 65535", diags[0].Detail)
+	require.NotNil(t, diags[0].Subject)
+	assert.Equal(t, "app.hcl", diags[0].Subject.Filename)
+	assert.Equal(t, 5, diags[0].Subject.Start.Line)
+
+	// Missing attributes point at the block that should contain them
+	assert.Equal(t, "Invalid service.api.image", diags[1].Summary)
+	require.NotNil(t, diags[1].Subject)
+	assert.Equal(t, 8, diags[1].Subject.Start.Line)
+}
@@ -0,0 +1,188 @@
+// Package i18n translates validation messages using message catalogs loaded from the file
+// system, typically an embed.FS.
+//
+// A catalog holds one set of messages per locale, read from files named after the locale, such
+// as "locales/fr-CA.json" or "locales/de.toml". Lookups fall back from a regional locale to its
+// base language and then to the catalog's default locale, so "fr-CA" tries "fr-CA", "fr", and
+// finally "en".
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultLocale is the last locale tried when looking up a message
+const DefaultLocale = "en"
+
+// Catalog holds translated messages keyed by locale and message key
+type Catalog struct {
+	// Default is the locale used when no other locale in the fallback chain has a message.
+	// It is DefaultLocale unless changed.
+	Default string
+
+	messages map[string]map[string]string
+}
+
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{
+		Default:  DefaultLocale,
+		messages: make(map[string]map[string]string),
+	}
+}
+
+// LoadCatalog reads every file in fsys matching pattern into a new catalog. Each file holds the
+// messages of the locale named by its base name without extension. Files ending in ".json" hold
+// a JSON object and files ending in ".toml" a TOML document; nested objects and tables are
+// flattened into dotted keys. Other files are ignored.
+//
+// Example usage:
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	catalog, err := i18n.LoadCatalog(locales, "locales/*")
+func LoadCatalog(fsys fs.FS, pattern string) (*Catalog, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCatalog()
+	for _, name := range files {
+		ext := path.Ext(name)
+		if ext != ".json" && ext != ".toml" {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		messages := make(map[string]string)
+		if ext == ".json" {
+			err = parseJSON(data, messages)
+		} else {
+			err = parseTOML(data, messages)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: %w", name, err)
+		}
+
+		c.Add(strings.TrimSuffix(path.Base(name), ext), messages)
+	}
+	return c, nil
+}
+
+// Add adds messages to a locale, replacing existing messages with the same keys
+func (c *Catalog) Add(locale string, messages map[string]string) {
+	locale = normalize(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string)
+	}
+	for key, message := range messages {
+		c.messages[locale][key] = message
+	}
+}
+
+// Locales returns the locales that have messages, in no particular order
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Has reports whether the catalog has any messages for a locale, without falling back
+func (c *Catalog) Has(locale string) bool {
+	return len(c.messages[normalize(locale)]) > 0
+}
+
+// Lookup returns the message for key, trying each locale in the fallback chain of locale
+func (c *Catalog) Lookup(locale, key string) (string, bool) {
+	for _, l := range c.Fallbacks(locale) {
+		if message, ok := c.messages[l][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// Fallbacks returns the locales tried for a lookup, from most to least specific, ending with
+// the default locale. Locale tags are matched case-insensitively, and underscores are treated as
+// hyphens.
+//
+// Example usage:
+// catalog.Fallbacks("fr_CA") // returns ["fr-ca", "fr", "en"]
+func (c *Catalog) Fallbacks(locale string) []string {
+	var chain []string
+	add := func(l string) {
+		for _, existing := range chain {
+			if existing == l {
+				return
+			}
+		}
+		chain = append(chain, l)
+	}
+
+	for l := normalize(locale); l != ""; {
+		add(l)
+		i := strings.LastIndexByte(l, '-')
+		if i < 0 {
+			break
+		}
+		l = l[:i]
+	}
+	if c.Default != "" {
+		add(normalize(c.Default))
+	}
+	return chain
+}
+
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func parseJSON(data []byte, messages map[string]string) error {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return flatten(messages, "", doc)
+}
+
+func parseTOML(data []byte, messages map[string]string) error {
+	var doc map[string]any
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return err
+	}
+	return flatten(messages, "", doc)
+}
+
+// flatten copies string values into messages, joining nested keys with dots
+func flatten(messages map[string]string, prefix string, doc map[string]any) error {
+	for key, value := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = v
+		case map[string]any:
+			if err := flatten(messages, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %q must be a string", key)
+		}
+	}
+	return nil
+}
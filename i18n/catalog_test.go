@@ -0,0 +1,102 @@
+package i18n_test
+
+import (
+	"embed"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/i18n"
+)
+
+//go:embed testdata/locales
+var locales embed.FS
+
+func TestLoadCatalog(t *testing.T) {
+	catalog, err := i18n.LoadCatalog(locales, "testdata/locales/*")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"en", "fr", "fr-ca"}, catalog.Locales())
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		want   string
+	}{
+		{"exact locale", "fr-CA", "email.required", "entrez votre adresse courriel"},
+		{"falls back to language", "fr-CA", "required", "est obligatoire"},
+		{"literal string value", "fr", "min_length", "est trop court"},
+		{"unicode escape", "fr", "form has expired", "le formulaire a expiré"},
+		{"multi-line string value", "fr", "not_found", "introuvable"},
+		{"falls back to default", "fr-CA", "max_length", "is too long"},
+		{"unknown locale uses default", "de-AT", "required", "is required"},
+		{"nested JSON keys are flattened", "en", "email.required", "enter your email address"},
+		{"TOML tables are flattened", "fr", "email.required", "saisissez votre adresse e-mail"},
+		{"underscore locale", "fr_ca", "email.required", "entrez votre adresse courriel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := catalog.Lookup(tt.locale, tt.key)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := catalog.Lookup("fr", "missing")
+	assert.False(t, ok)
+}
+
+func TestLoadCatalog_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+	}{
+		{"invalid JSON", "en.json", `{"required": `},
+		{"non-string JSON value", "en.json", `{"required": 1}`},
+		{"non-string TOML value", "en.toml", `required = 1`},
+		{"unterminated TOML string", "en.toml", `required = "is required`},
+		{"missing TOML equals", "en.toml", `required "is required"`},
+		{"TOML array of tables", "en.toml", `[[messages]]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"locales/" + tt.file: {Data: []byte(tt.data)}}
+			_, err := i18n.LoadCatalog(fsys, "locales/*")
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := i18n.LoadCatalog(fstest.MapFS{}, "[")
+	assert.Error(t, err)
+}
+
+func TestCatalog_Fallbacks(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	assert.Equal(t, []string{"fr-ca", "fr", "en"}, catalog.Fallbacks("fr-CA"))
+	assert.Equal(t, []string{"zh-hant-tw", "zh-hant", "zh", "en"}, catalog.Fallbacks("zh-Hant-TW"))
+	assert.Equal(t, []string{"en-gb", "en"}, catalog.Fallbacks("en-GB"))
+	assert.Equal(t, []string{"en"}, catalog.Fallbacks(""))
+
+	catalog.Default = "de"
+	assert.Equal(t, []string{"fr", "de"}, catalog.Fallbacks("fr"))
+}
+
+func TestCatalog_Add(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	assert.False(t, catalog.Has("es"))
+
+	catalog.Add("es", map[string]string{"required": "es obligatorio"})
+	catalog.Add("ES", map[string]string{"min_length": "es demasiado corto"})
+
+	assert.True(t, catalog.Has("es"))
+	got, _ := catalog.Lookup("es-MX", "required")
+	assert.Equal(t, "es obligatorio", got)
+	got, _ = catalog.Lookup("es", "min_length")
+	assert.Equal(t, "es demasiado corto", got)
+}
@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"github.com/patrickward/datacop"
+)
+
+// Translate returns the message for a validation error in locale. It looks up the keys
+// "<field>.<code>", "<code>", and finally the error's own message, so catalogs can translate by
+// code, override a code for one field, or translate literal messages. The original message is
+// returned if no key has a translation.
+//
+// Translations are message templates: a {field} placeholder is replaced with the error's field,
+// and placeholders named after the error's Params, such as {min}, with their values. Other
+// placeholders are left as they are.
+//
+// Example usage:
+//
+//	// locales/fr.json: {"required": "est obligatoire", "email": {"required": "l'adresse est obligatoire"}}
+//	catalog.Translate("fr-CA", datacop.ValidationError{Field: "email", Code: "required", Message: "is required"})
+//	// returns "l'adresse est obligatoire"
+func (c *Catalog) Translate(locale string, e datacop.ValidationError) string {
+	message := c.translate(locale, e)
+	if e.Field == "" || e.Field == datacop.StandaloneErrorKey {
+		return message
+	}
+	return datacop.Interpolate(message, datacop.Params{"field": e.Field})
+}
+
+// translate implements Translate, leaving the {field} placeholder in place
+func (c *Catalog) translate(locale string, e datacop.ValidationError) string {
+	message, ok := c.lookupError(locale, e)
+	if !ok {
+		return e.Message
+	}
+	return datacop.Interpolate(message, e.Params)
+}
+
+func (c *Catalog) lookupError(locale string, e datacop.ValidationError) (string, bool) {
+	if e.Code != "" {
+		if e.Field != "" && e.Field != datacop.StandaloneErrorKey {
+			if message, ok := c.Lookup(locale, e.Field+"."+e.Code); ok {
+				return message, true
+			}
+		}
+		if message, ok := c.Lookup(locale, e.Code); ok {
+			return message, true
+		}
+	}
+	return c.Lookup(locale, e.Message)
+}
+
+// Localize returns a new validator holding the errors of v with their messages translated into
+// locale, preserving fields, codes, parameters, and order. Messages are looked up as recorded,
+// before labels are applied, and the labels set on v are copied, so {field} placeholders in
+// translations are replaced with them. Annotations are copied, with their messages translated
+// when the catalog has them as keys. v is not modified.
+//
+// Example usage:
+//
+//	if v.HasErrors() {
+//		return catalog.Localize(v, "fr-CA").ToProblemDetails(http.StatusUnprocessableEntity)
+//	}
+func (c *Catalog) Localize(v *datacop.Validator, locale string) *datacop.Validator {
+	out := datacop.New(datacop.WithLabels(v.Labels()))
+	for _, e := range v.UnlabeledErrors() {
+		e.Message = c.translate(locale, e)
+		out.AddValidationError(e)
+	}
+	for _, a := range v.Annotations() {
+		message := a.Message
+		if translated, ok := c.Lookup(locale, a.Message); ok {
+			message = translated
+		}
+		out.Annotate(a.Field, a.Kind, message, a.Data)
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/i18n"
+)
+
+func TestCatalog_Translate(t *testing.T) {
+	catalog, err := i18n.LoadCatalog(locales, "testdata/locales/*")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		locale string
+		err    datacop.ValidationError
+		want   string
+	}{
+		{"field and code", "fr", datacop.ValidationError{Field: "email", Code: "required", Message: "is required"}, "saisissez votre adresse e-mail"},
+		{"code only", "fr", datacop.ValidationError{Field: "name", Code: "required", Message: "is required"}, "est obligatoire"},
+		{"message as key", "fr", datacop.ValidationError{Field: datacop.StandaloneErrorKey, Message: "form has expired"}, "le formulaire a expiré"},
+		{"untranslated", "fr", datacop.ValidationError{Field: "age", Code: "min", Message: "must be at least 18"}, "must be at least 18"},
+		{"regional override", "fr-CA", datacop.ValidationError{Field: "email", Code: "required", Message: "is required"}, "entrez votre adresse courriel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, catalog.Translate(tt.locale, tt.err))
+		})
+	}
+}
+
+func TestCatalog_Translate_Placeholders(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	catalog.Add("de", map[string]string{
+		"required":   "{field} ist erforderlich",
+		"min_length": "muss mindestens {min} Zeichen lang sein",
+	})
+
+	assert.Equal(t, "name ist erforderlich",
+		catalog.Translate("de", datacop.ValidationError{Field: "name", Code: "required", Message: "is required"}))
+	assert.Equal(t, "muss mindestens {min} Zeichen lang sein",
+		catalog.Translate("de", datacop.ValidationError{Field: "name", Code: "min_length", Message: "is too short"}))
+	assert.Equal(t, "muss mindestens 8 Zeichen lang sein",
+		catalog.Translate("de", datacop.ValidationError{Field: "name", Code: "min_length", Message: "is too short", Params: datacop.Params{"min": 8}}))
+}
+
+func TestCatalog_Localize(t *testing.T) {
+	catalog, err := i18n.LoadCatalog(locales, "testdata/locales/*")
+	require.NoError(t, err)
+
+	v := datacop.New()
+	v.AddValidationError(datacop.ValidationError{Field: "name", Code: "min_length", Message: "is too short", Params: datacop.Params{"min": 3}})
+	v.AddCodedError("email", "required", "is required")
+	v.AddStandaloneError("form has expired")
+	v.Annotate("name", datacop.AnnotationWarning, "form has expired", nil)
+
+	localized := catalog.Localize(v, "fr-CA")
+
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "name", Code: "min_length", Message: "est trop court", Params: datacop.Params{"min": 3}},
+		{Field: "email", Code: "required", Message: "entrez votre adresse courriel"},
+		{Field: datacop.StandaloneErrorKey, Message: "le formulaire a expiré"},
+	}, localized.OrderedErrors())
+	assert.Equal(t, "le formulaire a expiré", localized.Annotations()[0].Message)
+
+	// The original validator is unchanged
+	assert.Equal(t, "is too short", v.ErrorFor("name"))
+}
+
+func TestCatalog_Localize_Labels(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	catalog.Add("de", map[string]string{"required": "{field} ist erforderlich"})
+
+	v := datacop.New()
+	v.SetLabel("dob", "Geburtsdatum")
+	v.AddCodedError("dob", "required", "{field} is required")
+
+	localized := catalog.Localize(v, "de")
+	assert.Equal(t, "Geburtsdatum ist erforderlich", localized.ErrorFor("dob"))
+	assert.Equal(t, "Geburtsdatum", localized.Label("dob"))
+}
+
+func TestCatalog_Localize_MessageTemplates(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	catalog.Add("de", map[string]string{"{field} must be accepted": "{field} muss akzeptiert werden"})
+
+	v := datacop.New()
+	v.Check(false, "terms", "{field} must be accepted")
+	v.SetLabel("terms", "AGB")
+
+	localized := catalog.Localize(v, "de")
+	assert.Equal(t, "AGB muss akzeptiert werden", localized.ErrorFor("terms"), "messages are looked up before labels are applied")
+}
@@ -0,0 +1,59 @@
+// Package numeric converts the numeric values datacop's packages validate to float64.
+package numeric
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Float converts integer, float, and numeric string values, as well as values with a Float64
+// method such as json.Number, to float64. Surrounding whitespace in strings is ignored. NaN and
+// infinite values are rejected.
+func Float(value any) (float64, bool) {
+	var n float64
+	switch v := value.(type) {
+	case int:
+		n = float64(v)
+	case int8:
+		n = float64(v)
+	case int16:
+		n = float64(v)
+	case int32:
+		n = float64(v)
+	case int64:
+		n = float64(v)
+	case uint:
+		n = float64(v)
+	case uint8:
+		n = float64(v)
+	case uint16:
+		n = float64(v)
+	case uint32:
+		n = float64(v)
+	case uint64:
+		n = float64(v)
+	case float32:
+		n = float64(v)
+	case float64:
+		n = v
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		n = f
+	case interface{ Float64() (float64, error) }: // json.Number, without importing encoding/json
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		n = f
+	default:
+		return 0, false
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false
+	}
+	return n, true
+}
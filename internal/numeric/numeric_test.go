@@ -0,0 +1,43 @@
+package numeric_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  float64
+		ok    bool
+	}{
+		{"int", 42, 42, true},
+		{"int8", int8(-8), -8, true},
+		{"uint64", uint64(7), 7, true},
+		{"float32", float32(1.5), 1.5, true},
+		{"float64", 2.25, 2.25, true},
+		{"string", " 3.5 ", 3.5, true},
+		{"json number", json.Number("1e3"), 1000, true},
+		{"invalid json number", json.Number("x"), 0, false},
+		{"non-numeric string", "abc", 0, false},
+		{"NaN string", "NaN", 0, false},
+		{"NaN", math.NaN(), 0, false},
+		{"infinity", math.Inf(1), 0, false},
+		{"bool", true, 0, false},
+		{"nil", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, ok := numeric.Float(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, n)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package is
+
+import (
+	"strings"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// DateLayout is the layout used to parse date strings when no layout is given
+const DateLayout = time.DateOnly
+
+// MinAge returns a validation function that checks if a birthdate is at least years ago.
+// See Age for accepted values and how age is calculated.
+//
+// Example usage:
+// MinAge(18)(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)) // returns true
+// MinAge(18)("2000-01-01") // returns true
+// MinAge(18, "01/02/2006")("01/02/2000") // returns true
+func MinAge(years int, layouts ...string) datacop.NamedRule {
+	return datacop.Named("min_age", datacop.Params{"years": years, "layouts": layouts}, func(value any) bool {
+		age, ok := Age(value, layouts...)
+		return ok && age >= years
+	})
+}
+
+// MaxAge returns a validation function that checks if a birthdate is at most years ago.
+// See Age for accepted values and how age is calculated.
+//
+// Example usage:
+// MaxAge(120)("1990-06-15") // returns true
+// MaxAge(120)("1850-06-15") // returns false
+func MaxAge(years int, layouts ...string) datacop.NamedRule {
+	return datacop.Named("max_age", datacop.Params{"years": years, "layouts": layouts}, func(value any) bool {
+		age, ok := Age(value, layouts...)
+		return ok && age <= years
+	})
+}
+
+// Age returns the age in whole years of a birthdate relative to the package clock (see SetClock).
+// The value may be a time.Time, a *time.Time, or a string parsed with the first matching layout
+// (DateLayout if none are given). ok is false if the value cannot be parsed or lies in the future.
+//
+// Age is calculated on calendar dates in the birthdate's location, so a birthdate parsed from a
+// string is compared with today's date in UTC. People born on 29 February turn a year older on
+// 1 March in non-leap years.
+func Age(value any, layouts ...string) (age int, ok bool) {
+	birth, ok := parseDate(value, layouts)
+	if !ok {
+		return 0, false
+	}
+
+	today := now().In(birth.Location())
+	if today.Before(birth) {
+		return 0, false
+	}
+
+	age = today.Year() - birth.Year()
+	if today.Month() < birth.Month() || (today.Month() == birth.Month() && today.Day() < birth.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// parseDate converts a time.Time, *time.Time, or date string into a time
+func parseDate(value any, layouts []string) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, !v.IsZero()
+	case string:
+		if len(layouts) == 0 {
+			layouts = []string{DateLayout}
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
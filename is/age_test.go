@@ -0,0 +1,86 @@
+package is_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestAge(t *testing.T) {
+	restore := is.SetClock(func() time.Time { return time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC) })
+	defer restore()
+
+	tests := []struct {
+		name    string
+		value   any
+		layouts []string
+		want    int
+		wantOK  bool
+	}{
+		{"birthday passed", "2000-01-01", nil, 23, true},
+		{"birthday today", "2000-03-15", nil, 23, true},
+		{"birthday tomorrow", "2000-03-16", nil, 22, true},
+		{"leap day before march", time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), nil, 19, true},
+		{"custom layout", "03/16/2000", []string{"01/02/2006"}, 22, true},
+		{"second layout matches", "16.03.2000", []string{"01/02/2006", "02.01.2006"}, 22, true},
+		{"pointer", ptr(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)), nil, 23, true},
+		{"future date", "2030-01-01", nil, 0, false},
+		{"unparseable", "yesterday", nil, 0, false},
+		{"zero time", time.Time{}, nil, 0, false},
+		{"nil pointer", (*time.Time)(nil), nil, 0, false},
+		{"unsupported type", 2000, nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			age, ok := is.Age(tt.value, tt.layouts...)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, age)
+		})
+	}
+}
+
+func TestAge_LeapDay(t *testing.T) {
+	birth := time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	restore := is.SetClock(func() time.Time { return time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC) })
+	age, _ := is.Age(birth)
+	assert.Equal(t, 18, age)
+	restore()
+
+	restore = is.SetClock(func() time.Time { return time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC) })
+	age, _ = is.Age(birth)
+	assert.Equal(t, 19, age)
+	restore()
+}
+
+func TestAge_Location(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	// 20:00 UTC on 14 March is already 15 March in Tokyo
+	restore := is.SetClock(func() time.Time { return time.Date(2023, 3, 14, 20, 0, 0, 0, time.UTC) })
+	defer restore()
+
+	age, _ := is.Age(time.Date(2005, 3, 15, 0, 0, 0, 0, tokyo))
+	assert.Equal(t, 18, age)
+
+	age, _ = is.Age(time.Date(2005, 3, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 17, age)
+}
+
+func TestMinAgeMaxAge(t *testing.T) {
+	restore := is.SetClock(func() time.Time { return time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC) })
+	defer restore()
+
+	assert.True(t, is.MinAge(18)("2005-03-15"))
+	assert.False(t, is.MinAge(18)("2005-03-16"))
+	assert.True(t, is.MinAge(18, "01/02/2006")("03/15/2005"))
+	assert.False(t, is.MinAge(18)("not a date"))
+
+	assert.True(t, is.MaxAge(120)("1950-01-01"))
+	assert.False(t, is.MaxAge(120)("1850-01-01"))
+	assert.False(t, is.MaxAge(120)("2030-01-01"))
+}
@@ -0,0 +1,86 @@
+package is
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/patrickward/datacop"
+)
+
+// placeholderAltText are words that describe that there is an image rather than what it shows.
+// Alt text made only of these words, digits, and punctuation is rejected by MeaningfulAltText.
+var placeholderAltText = map[string]bool{
+	"image": true, "images": true, "img": true, "picture": true, "pic": true, "photo": true,
+	"photograph": true, "graphic": true, "icon": true, "alt": true, "alt text": true,
+	"placeholder": true, "untitled": true, "screenshot": true, "screen shot": true,
+	"thumbnail": true, "banner": true, "spacer": true, "blank": true, "none": true, "null": true,
+	"undefined": true, "todo": true, "tbd": true,
+	// Names assigned by cameras and phones, such as "IMG_1234" or "DSC01234"
+	"dsc": true, "dscn": true, "dcim": true, "pxl": true, "mvimg": true,
+}
+
+// imageExtensions are file extensions that mark alt text as a file name
+var imageExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true, ".svg": true,
+	".bmp": true, ".tif": true, ".tiff": true, ".avif": true, ".heic": true, ".ico": true,
+}
+
+// MeaningfulAltText returns a validation function that checks that the alt text of an image
+// describes it, for image fields in content management systems. It rejects text shorter than
+// min characters, file names such as "photo123.jpg" or "IMG_1234", and placeholders such as
+// "image" or "Picture 2" that only say there is an image. Text containing any of bannedPhrases,
+// ignoring case, is also rejected, such as "click here" or a product's boilerplate caption.
+//
+// Decorative images should have empty alt text, so make the field optional for them rather than
+// relaxing this check.
+//
+// Example usage:
+// MeaningfulAltText(10)("A golden retriever catching a frisbee") // returns true
+// MeaningfulAltText(10)("photo123.jpg") // returns false
+// MeaningfulAltText(3, "click here")("Click here for more") // returns false
+func MeaningfulAltText(min int, bannedPhrases ...string) datacop.NamedRule {
+	banned := make([]string, 0, len(bannedPhrases))
+	for _, p := range bannedPhrases {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			banned = append(banned, p)
+		}
+	}
+
+	return pure("meaningful_alt_text", datacop.Params{"min": min, "banned": banned}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+		if str == "" || utf8.RuneCountInString(str) < min {
+			return false
+		}
+
+		lower := strings.ToLower(str)
+		for _, p := range banned {
+			if strings.Contains(lower, p) {
+				return false
+			}
+		}
+
+		if !strings.ContainsRune(lower, ' ') && imageExtensions[path.Ext(lower)] {
+			return false
+		}
+		return !isPlaceholderAltText(lower)
+	})
+}
+
+// isPlaceholderAltText reports whether lowercase text is a placeholder once digits and
+// punctuation are removed, so "Image 1" and "photo_123" count as placeholders. Text without any
+// letters is a placeholder too.
+func isPlaceholderAltText(text string) bool {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return true
+	}
+	return placeholderAltText[strings.Join(words, " ")]
+}
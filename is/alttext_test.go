@@ -0,0 +1,51 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestMeaningfulAltText(t *testing.T) {
+	tests := []struct {
+		name   string
+		min    int
+		banned []string
+		value  any
+		want   bool
+	}{
+		{"description", 10, nil, "A golden retriever catching a frisbee", true},
+		{"short description", 3, nil, "Map", true},
+		{"too short", 10, nil, "Dog", false},
+		{"empty", 0, nil, "", false},
+		{"blank", 0, nil, "   ", false},
+		{"placeholder", 0, nil, "image", false},
+		{"placeholder with number", 0, nil, "Picture 2", false},
+		{"placeholder words", 0, nil, "Alt Text", false},
+		{"file name", 0, nil, "photo123.jpg", false},
+		{"file name with path", 0, nil, "uploads/team-offsite.PNG", false},
+		{"camera file name", 0, nil, "IMG_1234", false},
+		{"phone file name", 0, nil, "PXL_20240101_123456", false},
+		{"digits only", 0, nil, "12345", false},
+		{"sentence ending in extension", 0, nil, "Diagram of how a .png file is compressed", true},
+		{"banned phrase", 3, []string{"click here"}, "Click here for more", false},
+		{"banned phrase absent", 3, []string{"click here"}, "Sales by region, 2024", true},
+		{"non-string", 0, nil, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.MeaningfulAltText(tt.min, tt.banned...)(tt.value))
+		})
+	}
+}
+
+func TestMeaningfulAltText_RuleInfo(t *testing.T) {
+	name, params, ok := datacop.RuleInfo(is.MeaningfulAltText(10, "Click here"))
+	assert.True(t, ok)
+	assert.Equal(t, "meaningful_alt_text", name)
+	assert.Equal(t, datacop.Params{"min": 10, "banned": []string{"click here"}}, params)
+}
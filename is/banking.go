@@ -0,0 +1,146 @@
+package is
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// ibanLengths holds the IBAN length of each country in the ISO 13616 registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22,
+	"BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27,
+	"DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27,
+	"GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22,
+	"IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28, "TL": 23,
+	"TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// IBAN returns a validation function for International Bank Account Numbers. The value may be
+// written in the electronic format or in groups of four separated by spaces, in either case. The
+// country code must be in the ISO 13616 registry, the length must match the country's, and the
+// check digits must pass the ISO 7064 mod 97-10 checksum.
+//
+// If countries are given, as ISO 3166-1 alpha-2 codes, only IBANs from those countries are
+// accepted.
+//
+// Example usage:
+// IBAN()("GB82 WEST 1234 5698 7654 32") // returns true
+// IBAN()("GB82 WEST 1234 5698 7654 33") // returns false
+// IBAN("DE", "FR")("GB82WEST12345698765432") // returns false
+func IBAN(countries ...string) datacop.NamedRule {
+	allowed := make([]string, len(countries))
+	for i, c := range countries {
+		allowed[i] = strings.ToUpper(c)
+	}
+
+	return pure("iban", datacop.Params{"countries": allowed}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		iban := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(str), " ", ""))
+		if len(iban) < 5 {
+			return false
+		}
+
+		country := iban[:2]
+		if n, ok := ibanLengths[country]; !ok || len(iban) != n {
+			return false
+		}
+		if len(allowed) > 0 && !slices.Contains(allowed, country) {
+			return false
+		}
+		if !isDigit(iban[2]) || !isDigit(iban[3]) {
+			return false
+		}
+		return mod97(iban[4:]+iban[:4]) == 1
+	})
+}
+
+// mod97 returns the remainder of the number formed by replacing each letter of str with its
+// value, A = 10 through Z = 35, divided by 97. It returns -1 if str has other characters.
+func mod97(str string) int {
+	remainder := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case isDigit(c):
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return remainder
+}
+
+// BIC returns a validation function for Business Identifier Codes (SWIFT codes) as defined by
+// ISO 9362: a 4-character institution code, a 2-letter country code, a 2-character location
+// code, and an optional 3-character branch code, for 8 or 11 characters in total. Lower case
+// letters are accepted.
+//
+// Example usage:
+// BIC()("DEUTDEFF") // returns true
+// BIC()("DEUTDEFF500") // returns true
+// BIC()("DEUTDEFF50") // returns false
+func BIC() datacop.NamedRule {
+	return pure("bic", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok || (len(str) != 8 && len(str) != 11) {
+			return false
+		}
+		str = strings.ToUpper(str)
+		for i := 0; i < len(str); i++ {
+			c := str[i]
+			isLetter := c >= 'A' && c <= 'Z'
+			if i >= 4 && i < 6 {
+				if !isLetter {
+					return false
+				}
+			} else if !isLetter && !isDigit(c) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ABARoutingNumber returns a validation function for US bank routing transit numbers assigned
+// by the American Bankers Association. The value must be 9 digits, start with a prefix assigned
+// to the Federal Reserve (00-12), thrift institutions (21-32), electronic transactions (61-72),
+// or traveler's checks (80), and pass the 3-7-1 weighted checksum.
+//
+// Example usage:
+// ABARoutingNumber()("021000021") // returns true
+// ABARoutingNumber()("021000022") // returns false
+func ABARoutingNumber() datacop.NamedRule {
+	return pure("aba_routing_number", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok || len(str) != 9 {
+			return false
+		}
+		sum := 0
+		weights := [3]int{3, 7, 1}
+		for i := 0; i < len(str); i++ {
+			if !isDigit(str[i]) {
+				return false
+			}
+			sum += int(str[i]-'0') * weights[i%3]
+		}
+
+		prefix := int(str[0]-'0')*10 + int(str[1]-'0')
+		validPrefix := prefix <= 12 || (prefix >= 21 && prefix <= 32) || (prefix >= 61 && prefix <= 72) || prefix == 80
+		return validPrefix && sum%10 == 0
+	})
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,110 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestIBAN(t *testing.T) {
+	tests := []struct {
+		name      string
+		countries []string
+		value     any
+		want      bool
+	}{
+		{"grouped", nil, "GB82 WEST 1234 5698 7654 32", true},
+		{"electronic", nil, "GB82WEST12345698765432", true},
+		{"lower case", nil, "gb82 west 1234 5698 7654 32", true},
+		{"letters in account", nil, "FR14 2004 1010 0505 0001 3M02 606", true},
+		{"shortest", nil, "NO9386011117947", true},
+		{"longest in common use", nil, "MT84MALT011000012345MTLCAST001S", true},
+		{"surrounding spaces", nil, " BE71096123456769 ", true},
+		{"bad checksum", nil, "GB82 WEST 1234 5698 7654 33", false},
+		{"transposed digits", nil, "GB82 WEST 1234 5698 7654 23", false},
+		{"wrong length for country", nil, "GB82 WEST 1234 5698 7654 3", false},
+		{"unknown country", nil, "XX82WEST12345698765432", false},
+		{"letters in check digits", nil, "GBAAWEST12345698765432", false},
+		{"punctuation", nil, "GB82-WEST-1234-5698-7654-32", false},
+		{"allowed country", []string{"de", "GB"}, "GB82WEST12345698765432", true},
+		{"other country", []string{"DE", "FR"}, "GB82WEST12345698765432", false},
+		{"empty", nil, "", false},
+		{"non-string", nil, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.IBAN(tt.countries...)(tt.value))
+		})
+	}
+}
+
+func TestBIC(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"8 characters", "DEUTDEFF", true},
+		{"11 characters", "DEUTDEFF500", true},
+		{"primary office branch", "NEDSZAJJXXX", true},
+		{"digits in location", "CHASUS33", true},
+		{"lower case", "deutdeff", true},
+		{"10 characters", "DEUTDEFF50", false},
+		{"digit in country", "DEUT1EFF", false},
+		{"punctuation", "DEUT-DEFF", false},
+		{"spaces", "DEUT DE FF", false},
+		{"empty", "", false},
+		{"non-string", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.BIC()(tt.value))
+		})
+	}
+}
+
+func TestABARoutingNumber(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"federal reserve", "011000015", true},
+		{"commercial bank", "021000021", true},
+		{"thrift", "322271627", true},
+		{"bad checksum", "021000022", false},
+		{"unassigned prefix", "501000017", false},
+		{"too short", "02100002", false},
+		{"too long", "0210000210", false},
+		{"letters", "02100002A", false},
+		{"empty", "", false},
+		{"non-string", 21000021, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.ABARoutingNumber()(tt.value))
+		})
+	}
+}
+
+func TestBanking_DefaultMessages(t *testing.T) {
+	v := datacop.New()
+	v.Field("iban", "GB00").Validate(is.IBAN())
+	v.Field("bic", "BANK").Validate(is.BIC())
+	v.Field("routing", "123").Validate(is.ABARoutingNumber())
+
+	assert.Equal(t, map[string][]string{
+		"iban":    {"iban"},
+		"bic":     {"bic"},
+		"routing": {"aba_routing_number"},
+	}, v.FailedRules())
+	assert.Equal(t, "must be a valid IBAN", v.Errors()["iban"])
+	assert.Equal(t, "must be a valid BIC", v.Errors()["bic"])
+	assert.Equal(t, "must be a valid routing number", v.Errors()["routing"])
+}
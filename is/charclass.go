@@ -0,0 +1,157 @@
+package is
+
+import (
+	"regexp"
+	"unicode"
+)
+
+var rgxSlug = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Alpha checks if a value is a non-empty string of ASCII letters
+//
+// Example usage:
+// Alpha("abcXYZ") // returns true
+// Alpha("abc123") // returns false
+func Alpha(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	})
+}
+
+// AlphaUnicode checks if a value is a non-empty string of letters from any script,
+// including combining marks such as diacritics
+//
+// Example usage:
+// AlphaUnicode("Zoë") // returns true
+// AlphaUnicode("Zoë1") // returns false
+func AlphaUnicode(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r)
+	})
+}
+
+// Alphanumeric checks if a value is a non-empty string of ASCII letters and digits
+//
+// Example usage:
+// Alphanumeric("abc123") // returns true
+// Alphanumeric("abc-123") // returns false
+func Alphanumeric(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+	})
+}
+
+// AlphanumericUnicode checks if a value is a non-empty string of letters, marks, and decimal
+// digits from any script
+//
+// Example usage:
+// AlphanumericUnicode("Straße12") // returns true
+// AlphanumericUnicode("Straße 12") // returns false
+func AlphanumericUnicode(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+	})
+}
+
+// Numeric checks if a value is a non-empty string of ASCII digits. Signs, decimal points,
+// and separators are not accepted.
+//
+// Example usage:
+// Numeric("0123") // returns true
+// Numeric("-12") // returns false
+func Numeric(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return r >= '0' && r <= '9'
+	})
+}
+
+// NumericUnicode checks if a value is a non-empty string of decimal digits from any script,
+// such as Arabic-Indic or Devanagari digits
+//
+// Example usage:
+// NumericUnicode("١٢٣") // returns true
+// NumericUnicode("12.5") // returns false
+func NumericUnicode(value any) bool {
+	return allRunes(value, unicode.IsDigit)
+}
+
+// ASCII checks if a value is a non-empty string containing only ASCII characters
+//
+// Example usage:
+// ASCII("hello!") // returns true
+// ASCII("héllo") // returns false
+func ASCII(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return r <= unicode.MaxASCII
+	})
+}
+
+// PrintableASCII checks if a value is a non-empty string containing only printable ASCII
+// characters, i.e. space through tilde. Tabs, newlines, and other control characters are rejected.
+//
+// Example usage:
+// PrintableASCII("hello world!") // returns true
+// PrintableASCII("hello\tworld") // returns false
+func PrintableASCII(value any) bool {
+	return allRunes(value, func(r rune) bool {
+		return r >= ' ' && r <= '~'
+	})
+}
+
+// Slug checks if a value is a URL slug: lowercase ASCII letters and digits in groups
+// separated by single hyphens
+//
+// Example usage:
+// Slug("my-first-post") // returns true
+// Slug("My First Post") // returns false
+// Slug("trailing-") // returns false
+func Slug(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return rgxSlug.MatchString(str)
+}
+
+// SlugUnicode checks if a value is a URL slug whose groups may contain lowercase letters,
+// marks, and digits from any script, separated by single hyphens
+//
+// Example usage:
+// SlugUnicode("café-crème") // returns true
+// SlugUnicode("Café-Crème") // returns false
+func SlugUnicode(value any) bool {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return false
+	}
+
+	prevHyphen := true // disallows a leading hyphen
+	for _, r := range str {
+		switch {
+		case r == '-':
+			if prevHyphen {
+				return false
+			}
+			prevHyphen = true
+		case unicode.IsLetter(r) && !unicode.IsUpper(r), unicode.IsMark(r), unicode.IsDigit(r):
+			prevHyphen = false
+		default:
+			return false
+		}
+	}
+	return !prevHyphen
+}
+
+// allRunes reports whether value is a non-empty string whose runes all satisfy fn
+func allRunes(value any, fn func(rune) bool) bool {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return false
+	}
+	for _, r := range str {
+		if !fn(r) {
+			return false
+		}
+	}
+	return true
+}
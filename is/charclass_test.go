@@ -0,0 +1,69 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestCharacterClasses(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    datacop.ValidationFunc
+		value any
+		want  bool
+	}{
+		{"alpha letters", is.Alpha, "abcXYZ", true},
+		{"alpha digits", is.Alpha, "abc123", false},
+		{"alpha accented", is.Alpha, "Zoë", false},
+		{"alpha empty", is.Alpha, "", false},
+		{"alpha non-string", is.Alpha, 1, false},
+
+		{"alpha unicode accented", is.AlphaUnicode, "Zoë", true},
+		{"alpha unicode combining mark", is.AlphaUnicode, "Zoë", true},
+		{"alpha unicode cyrillic", is.AlphaUnicode, "Анна", true},
+		{"alpha unicode digit", is.AlphaUnicode, "Zoë1", false},
+
+		{"alphanumeric", is.Alphanumeric, "abc123", true},
+		{"alphanumeric hyphen", is.Alphanumeric, "abc-123", false},
+		{"alphanumeric unicode", is.AlphanumericUnicode, "Straße12", true},
+		{"alphanumeric unicode space", is.AlphanumericUnicode, "Straße 12", false},
+
+		{"numeric digits", is.Numeric, "0123", true},
+		{"numeric sign", is.Numeric, "-12", false},
+		{"numeric decimal", is.Numeric, "1.5", false},
+		{"numeric arabic-indic", is.Numeric, "١٢٣", false},
+		{"numeric unicode arabic-indic", is.NumericUnicode, "١٢٣", true},
+		{"numeric unicode decimal", is.NumericUnicode, "12.5", false},
+
+		{"ascii", is.ASCII, "hello!\n", true},
+		{"ascii accented", is.ASCII, "héllo", false},
+		{"printable ascii", is.PrintableASCII, "hello world!~", true},
+		{"printable ascii tab", is.PrintableASCII, "hello\tworld", false},
+		{"printable ascii delete", is.PrintableASCII, "hello\x7f", false},
+
+		{"slug", is.Slug, "my-first-post-2", true},
+		{"slug single group", is.Slug, "post", true},
+		{"slug uppercase", is.Slug, "My-Post", false},
+		{"slug spaces", is.Slug, "my post", false},
+		{"slug leading hyphen", is.Slug, "-post", false},
+		{"slug trailing hyphen", is.Slug, "post-", false},
+		{"slug double hyphen", is.Slug, "my--post", false},
+		{"slug empty", is.Slug, "", false},
+
+		{"slug unicode", is.SlugUnicode, "café-crème", true},
+		{"slug unicode uppercase", is.SlugUnicode, "Café-Crème", false},
+		{"slug unicode double hyphen", is.SlugUnicode, "café--crème", false},
+		{"slug unicode trailing hyphen", is.SlugUnicode, "café-", false},
+		{"slug unicode empty", is.SlugUnicode, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fn(tt.value))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package is
+
+import (
+	"crypto/subtle"
+	"regexp"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+var rgxBackupCode = regexp.MustCompile(`^[A-Za-z0-9]{4,5}(?:-?[A-Za-z0-9]{4,5}){1,3}$`)
+
+// TOTPCode checks if a value is a time-based one-time password code of 6 to 8 digits.
+// Surrounding whitespace is ignored.
+//
+// Example usage:
+// TOTPCode("123456") // returns true
+// TOTPCode("12345") // returns false
+// TOTPCode("12a456") // returns false
+func TOTPCode(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	str = strings.TrimSpace(str)
+	return len(str) >= 6 && len(str) <= 8 && isDigits(str)
+}
+
+// NumericCode returns a validation function that checks for a code of exactly length digits,
+// such as an SMS or email verification code. Surrounding whitespace is ignored.
+//
+// Example usage:
+// NumericCode(4)("0123") // returns true
+// NumericCode(4)("01234") // returns false
+func NumericCode(length int) datacop.NamedRule {
+	return pure("numeric_code", datacop.Params{"length": length}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+		return len(str) == length && isDigits(str)
+	})
+}
+
+// BackupCodeFormat checks if a value looks like a 2FA recovery code: two to four groups of
+// 4 or 5 letters or digits, optionally separated by hyphens.
+//
+// Example usage:
+// BackupCodeFormat("a1b2-c3d4") // returns true
+// BackupCodeFormat("a1b2c3d4e5") // returns true
+// BackupCodeFormat("a1b2") // returns false
+func BackupCodeFormat(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return rgxBackupCode.MatchString(strings.TrimSpace(str))
+}
+
+// CodeMatches returns a validation function that compares a submitted code to the expected
+// code in constant time, so response timing does not leak how much of the code was correct.
+// Surrounding whitespace in the submitted code is ignored. An empty expected code never matches.
+//
+// Example usage:
+// CodeMatches("123456")("123456") // returns true
+// CodeMatches("123456")("123455") // returns false
+func CodeMatches(expected string) datacop.NamedRule {
+	return datacop.Named("code_matches", nil, func(value any) bool {
+		str, ok := value.(string)
+		if !ok || expected == "" {
+			return false
+		}
+		return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(str)), []byte(expected)) == 1
+	})
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
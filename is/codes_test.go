@@ -0,0 +1,97 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"six digits", "123456", true},
+		{"eight digits", "12345678", true},
+		{"surrounding whitespace", " 123456 ", true},
+		{"too short", "12345", false},
+		{"too long", "123456789", false},
+		{"contains letter", "12a456", false},
+		{"internal space", "123 456", false},
+		{"non-string value", 123456, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.TOTPCode(tt.value))
+		})
+	}
+}
+
+func TestNumericCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		value  any
+		want   bool
+	}{
+		{"exact length", 4, "0123", true},
+		{"too long", 4, "01234", false},
+		{"too short", 4, "012", false},
+		{"non-digit", 4, "01b3", false},
+		{"non-string value", 4, 1234, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.NumericCode(tt.length)(tt.value))
+		})
+	}
+}
+
+func TestBackupCodeFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"hyphenated groups", "a1b2-c3d4", true},
+		{"five character groups", "a1b2c-3d4e5-f6g7h", true},
+		{"without hyphens", "a1b2c3d4e5", true},
+		{"single group", "a1b2", false},
+		{"invalid characters", "a1b2-c3d!", false},
+		{"too many groups", "aaaa-bbbb-cccc-dddd-eeee", false},
+		{"non-string value", 12345678, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.BackupCodeFormat(tt.value))
+		})
+	}
+}
+
+func TestCodeMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		value    any
+		want     bool
+	}{
+		{"matching code", "123456", "123456", true},
+		{"matching with whitespace", "123456", " 123456\n", true},
+		{"different code", "123456", "123455", false},
+		{"different length", "123456", "1234567", false},
+		{"empty expected", "", "", false},
+		{"non-string value", "123456", 123456, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.CodeMatches(tt.expected)(tt.value))
+		})
+	}
+}
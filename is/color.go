@@ -0,0 +1,145 @@
+package is
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+var rgxCSSNumber = regexp.MustCompile(`^[+-]?(?:\d+\.?\d*|\.\d+)$`)
+
+// HexColor returns a validation function for hexadecimal colors as written in CSS: a "#"
+// followed by 3, 4, 6, or 8 hexadecimal digits, in either case, the 4 and 8 digit forms
+// including an alpha channel.
+//
+// Example usage:
+// HexColor()("#1e90ff") // returns true
+// HexColor()("#FFF") // returns true
+// HexColor()("1e90ff") // returns false
+func HexColor() datacop.NamedRule {
+	return pure("hex_color", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok || !strings.HasPrefix(str, "#") {
+			return false
+		}
+		switch digits := str[1:]; len(digits) {
+		case 3, 4, 6, 8:
+			return isHexDigits(digits)
+		}
+		return false
+	})
+}
+
+// RGBColor returns a validation function for CSS rgb() and rgba() colors, in the comma-separated
+// form, such as "rgb(30, 144, 255)" or "rgba(30, 144, 255, 0.5)", or the space-separated form,
+// such as "rgb(30 144 255 / 50%)". Channels are numbers from 0 to 255 or percentages, and the
+// optional alpha is a number from 0 to 1 or a percentage.
+//
+// Example usage:
+// RGBColor()("rgb(30, 144, 255)") // returns true
+// RGBColor()("rgb(100%, 0%, 0%)") // returns true
+// RGBColor()("rgb(256, 0, 0)") // returns false
+func RGBColor() datacop.NamedRule {
+	return pure("rgb_color", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		args, ok := colorArgs(str, "rgb")
+		if !ok {
+			return false
+		}
+		for _, arg := range args[:3] {
+			if !isPercent(arg) && !inRange(arg, 0, 255) {
+				return false
+			}
+		}
+		return len(args) == 3 || isAlpha(args[3])
+	})
+}
+
+// HSLColor returns a validation function for CSS hsl() and hsla() colors, in the
+// comma-separated form, such as "hsl(210, 100%, 56%)", or the space-separated form, such as
+// "hsl(210deg 100% 56% / 0.5)". The hue is a number of degrees, optionally with the "deg" unit,
+// saturation and lightness are percentages, and the optional alpha is a number from 0 to 1 or a
+// percentage.
+//
+// Example usage:
+// HSLColor()("hsl(210, 100%, 56%)") // returns true
+// HSLColor()("hsla(210, 100%, 56%, 0.5)") // returns true
+// HSLColor()("hsl(210, 100, 56)") // returns false
+func HSLColor() datacop.NamedRule {
+	return pure("hsl_color", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		args, ok := colorArgs(str, "hsl")
+		if !ok {
+			return false
+		}
+		hue := strings.TrimSuffix(strings.ToLower(args[0]), "deg")
+		if !rgxCSSNumber.MatchString(hue) || !isPercent(args[1]) || !isPercent(args[2]) {
+			return false
+		}
+		return len(args) == 3 || isAlpha(args[3])
+	})
+}
+
+// colorArgs returns the 3 or 4 arguments of a CSS color function named name or name+"a", such as
+// rgb() or rgba(), in either the comma-separated or the space-separated form. The function name
+// is matched case-insensitively.
+func colorArgs(str, name string) ([]string, bool) {
+	str = strings.TrimSpace(str)
+	open := strings.IndexByte(str, '(')
+	if open < 0 || !strings.HasSuffix(str, ")") {
+		return nil, false
+	}
+	if fn := strings.ToLower(str[:open]); fn != name && fn != name+"a" {
+		return nil, false
+	}
+	inner := strings.TrimSpace(str[open+1 : len(str)-1])
+
+	var args []string
+	if strings.Contains(inner, ",") {
+		args = strings.Split(inner, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+	} else {
+		channels, alpha, hasAlpha := strings.Cut(inner, "/")
+		args = strings.Fields(channels)
+		if hasAlpha {
+			if len(args) != 3 {
+				return nil, false
+			}
+			args = append(args, strings.TrimSpace(alpha))
+		}
+	}
+	if len(args) != 3 && len(args) != 4 {
+		return nil, false
+	}
+	return args, true
+}
+
+// isPercent reports whether arg is a percentage from 0% to 100%
+func isPercent(arg string) bool {
+	n, ok := strings.CutSuffix(arg, "%")
+	return ok && inRange(n, 0, 100)
+}
+
+// isAlpha reports whether arg is an alpha value: a number from 0 to 1 or a percentage
+func isAlpha(arg string) bool {
+	return isPercent(arg) || inRange(arg, 0, 1)
+}
+
+// inRange reports whether arg is a plain decimal number from low to high
+func inRange(arg string, low, high float64) bool {
+	if !rgxCSSNumber.MatchString(arg) {
+		return false
+	}
+	f, err := strconv.ParseFloat(arg, 64)
+	return err == nil && f >= low && f <= high
+}
@@ -0,0 +1,60 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestColorFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  datacop.NamedRule
+		value any
+		want  bool
+	}{
+		{"hex six digits", is.HexColor(), "#1e90ff", true},
+		{"hex three digits", is.HexColor(), "#FFF", true},
+		{"hex with alpha", is.HexColor(), "#1e90ff80", true},
+		{"hex short with alpha", is.HexColor(), "#fff8", true},
+		{"hex without hash", is.HexColor(), "1e90ff", false},
+		{"hex five digits", is.HexColor(), "#1e90f", false},
+		{"hex non-hex digit", is.HexColor(), "#1e90fg", false},
+		{"hex non-string", is.HexColor(), 0x1e90ff, false},
+
+		{"rgb commas", is.RGBColor(), "rgb(30, 144, 255)", true},
+		{"rgba commas", is.RGBColor(), "rgba(30,144,255,0.5)", true},
+		{"rgb percentages", is.RGBColor(), "rgb(100%, 0%, 0%)", true},
+		{"rgb spaces with alpha", is.RGBColor(), "rgb(30 144 255 / 50%)", true},
+		{"rgb upper case", is.RGBColor(), "RGB(0, 0, 0)", true},
+		{"rgb channel too high", is.RGBColor(), "rgb(256, 0, 0)", false},
+		{"rgb negative channel", is.RGBColor(), "rgb(-1, 0, 0)", false},
+		{"rgb alpha too high", is.RGBColor(), "rgba(0, 0, 0, 1.5)", false},
+		{"rgb two channels", is.RGBColor(), "rgb(0, 0)", false},
+		{"rgb five arguments", is.RGBColor(), "rgba(0, 0, 0, 1, 1)", false},
+		{"rgb slash without three channels", is.RGBColor(), "rgb(0 0 / 1)", false},
+		{"rgb not a number", is.RGBColor(), "rgb(red, 0, 0)", false},
+		{"rgb exponent", is.RGBColor(), "rgb(1e2, 0, 0)", false},
+		{"rgb missing parenthesis", is.RGBColor(), "rgb(0, 0, 0", false},
+		{"rgb hsl function", is.RGBColor(), "hsl(0, 0%, 0%)", false},
+
+		{"hsl commas", is.HSLColor(), "hsl(210, 100%, 56%)", true},
+		{"hsla commas", is.HSLColor(), "hsla(210, 100%, 56%, 0.5)", true},
+		{"hsl spaces with deg and alpha", is.HSLColor(), "hsl(210deg 100% 56% / 0.5)", true},
+		{"hsl hue above 360", is.HSLColor(), "hsl(720, 50%, 50%)", true},
+		{"hsl missing percent", is.HSLColor(), "hsl(210, 100, 56)", false},
+		{"hsl saturation too high", is.HSLColor(), "hsl(210, 101%, 56%)", false},
+		{"hsl hue not a number", is.HSLColor(), "hsl(blue, 100%, 56%)", false},
+		{"hsl rgb function", is.HSLColor(), "rgb(0, 0, 0)", false},
+		{"hsl non-string", is.HSLColor(), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule(tt.value))
+		})
+	}
+}
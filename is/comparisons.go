@@ -5,8 +5,6 @@ import (
 	"strings"
 	"unicode/utf8"
 
-	"golang.org/x/exp/constraints"
-
 	"github.com/patrickward/datacop"
 )
 
@@ -15,28 +13,28 @@ import (
 //
 // Between(10, 20)(15) // returns true
 // Between(10, 20)(25) // returns false
-func Between[T constraints.Ordered](min, max T) datacop.ValidationFunc {
-	return func(value any) bool {
+func Between[T cmp.Ordered](min, max T) datacop.NamedRule {
+	return pure("between", datacop.Params{"min": min, "max": max}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v >= min && v <= max
-	}
+	})
 }
 
 // Equal checks if values are equal
 // Example usage:
 // Equal(10)(10) // returns true
 // Equal(10)(5) // returns false
-func Equal[T comparable](other T) datacop.ValidationFunc {
-	return func(value any) bool {
+func Equal[T comparable](other T) datacop.NamedRule {
+	return pure("equal", datacop.Params{"value": other}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v == other
-	}
+	})
 }
 
 // EqualStrings checks if two strings are equal
@@ -55,8 +53,8 @@ func EqualStrings(value, other string) bool {
 // In(1, 2, 3)(4) // returns false
 // In("a", "b", "c")("b") // returns true
 // In("a", "b", "c")("d") // returns false
-func In[T comparable](allowed ...T) datacop.ValidationFunc {
-	return func(value any) bool {
+func In[T comparable](allowed ...T) datacop.NamedRule {
+	return pure("in", datacop.Params{"allowed": allowed}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
@@ -67,7 +65,24 @@ func In[T comparable](allowed ...T) datacop.ValidationFunc {
 			}
 		}
 		return false
-	}
+	})
+}
+
+// InFold checks if a string is one of the allowed values, ignoring case. Non-string values fail.
+// See Enum for typed string enums and for the canonical spelling of a value.
+//
+// Example usage:
+// InFold("draft", "published")("Published") // returns true
+// InFold("draft", "published")("archived") // returns false
+func InFold(allowed ...string) datacop.NamedRule {
+	return pure("in_fold", datacop.Params{"allowed": allowed}, stringCheck(func(s string) bool {
+		for _, a := range allowed {
+			if strings.EqualFold(s, a) {
+				return true
+			}
+		}
+		return false
+	}))
 }
 
 // AllIn checks if all values in a slice are in a set of allowed values
@@ -77,8 +92,8 @@ func In[T comparable](allowed ...T) datacop.ValidationFunc {
 // AllIn(1, 2, 3)([]int{1, 4}) // returns false
 // AllIn("a", "b", "c")([]string{"a", "b"}) // returns true
 // AllIn("a", "b", "c")([]string{"a", "d"}) // returns false
-func AllIn[T comparable](allowed ...T) datacop.ValidationFunc {
-	return func(value any) bool {
+func AllIn[T comparable](allowed ...T) datacop.NamedRule {
+	return pure("all_in", datacop.Params{"allowed": allowed}, func(value any) bool {
 		values, ok := value.([]T)
 		if !ok {
 			return false
@@ -96,7 +111,7 @@ func AllIn[T comparable](allowed ...T) datacop.ValidationFunc {
 			}
 		}
 		return true
-	}
+	})
 }
 
 // NoDuplicates checks if a slice contains any duplicate values
@@ -104,8 +119,8 @@ func AllIn[T comparable](allowed ...T) datacop.ValidationFunc {
 // Example usage:
 // NoDuplicates()([]int{1, 2, 3}) // returns true
 // NoDuplicates()([]int{1, 2, 2}) // returns false
-func NoDuplicates[T comparable]() datacop.ValidationFunc {
-	return func(value any) bool {
+func NoDuplicates[T comparable]() datacop.NamedRule {
+	return pure("no_duplicates", nil, func(value any) bool {
 		values, ok := value.([]T)
 		if !ok {
 			return false
@@ -119,7 +134,90 @@ func NoDuplicates[T comparable]() datacop.ValidationFunc {
 			seen[v] = struct{}{}
 		}
 		return true
+	})
+}
+
+// Subset checks if every value in a slice is also in set, such as the permissions granted to a
+// user against those of their role. Unlike AllIn, set is a slice, so it can come from another
+// field. An empty slice is a subset of any set.
+//
+// Example usage:
+// Subset(rolePerms)(userPerms) // returns true if the user has no permission outside their role
+// Subset([]string{"read", "write"})([]string{"read"}) // returns true
+// Subset([]string{"read", "write"})([]string{"admin"}) // returns false
+func Subset[T comparable](set []T) datacop.NamedRule {
+	return pure("subset", datacop.Params{"set": set}, sliceCheck(func(values []T) bool {
+		return containsEvery(set, values)
+	}))
+}
+
+// Superset is an alias of ContainsAll for a set held in a slice, such as another field. The rule
+// is named "contains_all".
+//
+// Example usage:
+// Superset([]string{"read"})([]string{"read", "write"}) // returns true
+// Superset([]string{"read", "write"})([]string{"read"}) // returns false
+func Superset[T comparable](set []T) datacop.NamedRule {
+	return ContainsAll(set...)
+}
+
+// ContainsAll checks if a slice contains every one of the required values
+//
+// Example usage:
+// ContainsAll("read")([]string{"read", "write"}) // returns true
+// ContainsAll("read", "admin")([]string{"read", "write"}) // returns false
+func ContainsAll[T comparable](required ...T) datacop.NamedRule {
+	return pure("contains_all", datacop.Params{"required": required}, sliceCheck(func(values []T) bool {
+		return containsEvery(values, required)
+	}))
+}
+
+// Disjoint checks if a slice has no values in common with other, such as roles that must not be
+// held together
+//
+// Example usage:
+// Disjoint([]string{"auditor"})([]string{"admin", "editor"}) // returns true
+// Disjoint([]string{"auditor"})([]string{"admin", "auditor"}) // returns false
+func Disjoint[T comparable](other []T) datacop.NamedRule {
+	return pure("disjoint", datacop.Params{"other": other}, sliceCheck(func(values []T) bool {
+		set := setOf(other)
+		for _, v := range values {
+			if _, exists := set[v]; exists {
+				return false
+			}
+		}
+		return true
+	}))
+}
+
+// sliceCheck adapts fn to a ValidationFunc that fails for values that are not a []T
+func sliceCheck[T comparable](check func([]T) bool) datacop.ValidationFunc {
+	return func(value any) bool {
+		values, ok := value.([]T)
+		if !ok {
+			return false
+		}
+		return check(values)
+	}
+}
+
+// containsEvery reports whether values contains every value in want
+func containsEvery[T comparable](values, want []T) bool {
+	set := setOf(values)
+	for _, w := range want {
+		if _, exists := set[w]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+func setOf[T comparable](values []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
 	}
+	return set
 }
 
 // MinLength returns a validation function that checks minimum string length
@@ -127,14 +225,14 @@ func NoDuplicates[T comparable]() datacop.ValidationFunc {
 // Example usage:
 // MinLength(5)("hello") // returns true
 // MinLength(5)("hi") // returns false
-func MinLength(min int) datacop.ValidationFunc {
-	return func(value any) bool {
+func MinLength(min int) datacop.NamedRule {
+	return pure("min_length", datacop.Params{"min": min}, func(value any) bool {
 		str, ok := value.(string)
 		if !ok {
 			return false
 		}
 		return utf8.RuneCountInString(strings.TrimSpace(str)) >= min
-	}
+	})
 }
 
 // MaxLength returns a validation function that checks maximum string length
@@ -142,14 +240,14 @@ func MinLength(min int) datacop.ValidationFunc {
 // Example usage:
 // MaxLength(5)("hello") // returns false
 // MaxLength(5)("hi") // returns true
-func MaxLength(max int) datacop.ValidationFunc {
-	return func(value any) bool {
+func MaxLength(max int) datacop.NamedRule {
+	return pure("max_length", datacop.Params{"max": max}, func(value any) bool {
 		str, ok := value.(string)
 		if !ok {
 			return false
 		}
 		return utf8.RuneCountInString(strings.TrimSpace(str)) <= max
-	}
+	})
 }
 
 // EqualLength returns a validation function that checks if a string has a specific length
@@ -158,44 +256,46 @@ func MaxLength(max int) datacop.ValidationFunc {
 // EqualLength(5)("hello") // returns true
 // EqualLength(5)("hi") // returns false
 // EqualLength(5)("hello!") // returns false
-func EqualLength(length int) datacop.ValidationFunc {
-	return func(value any) bool {
+func EqualLength(length int) datacop.NamedRule {
+	return pure("equal_length", datacop.Params{"length": length}, func(value any) bool {
 		str, ok := value.(string)
 		if !ok {
 			return false
 		}
 		return utf8.RuneCountInString(strings.TrimSpace(str)) == length
-	}
+	})
 }
 
-// Min returns a validation function that checks minimum value
+// Min returns a validation function that checks minimum value. The value must have exactly the
+// type T, so Min(5) fails for an int64; use MinNumeric to compare across numeric types.
 //
 // Example usage:
 // Min(10)(15) // returns true
 // Min(10)(5) // returns false
-func Min[T cmp.Ordered](min T) datacop.ValidationFunc {
-	return func(value any) bool {
+func Min[T cmp.Ordered](min T) datacop.NamedRule {
+	return pure("min", datacop.Params{"min": min}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v >= min
-	}
+	})
 }
 
-// Max returns a validation function that checks maximum value
+// Max returns a validation function that checks maximum value. Like Min, the value must have
+// exactly the type T; use MaxNumeric to compare across numeric types.
 //
 // Example usage:
 // Max(10)(5) // returns true
 // Max(10)(15) // returns false
-func Max[T cmp.Ordered](max T) datacop.ValidationFunc {
-	return func(value any) bool {
+func Max[T cmp.Ordered](max T) datacop.NamedRule {
+	return pure("max", datacop.Params{"max": max}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v <= max
-	}
+	})
 }
 
 // GreaterThan returns a validation function that checks if a value is greater than a specified value
@@ -204,14 +304,14 @@ func Max[T cmp.Ordered](max T) datacop.ValidationFunc {
 //
 // GreaterThan(10)(15) // returns true
 // GreaterThan(10)(5) // returns false
-func GreaterThan[T cmp.Ordered](n T) datacop.ValidationFunc {
-	return func(value any) bool {
+func GreaterThan[T cmp.Ordered](n T) datacop.NamedRule {
+	return pure("greater_than", datacop.Params{"limit": n}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v > n
-	}
+	})
 }
 
 // LessThan returns a validation function that checks if a value is less than a specified value
@@ -219,14 +319,14 @@ func GreaterThan[T cmp.Ordered](n T) datacop.ValidationFunc {
 // Example usage:
 // LessThan(10)(5) // returns true
 // LessThan(10)(15) // returns false
-func LessThan[T cmp.Ordered](n T) datacop.ValidationFunc {
-	return func(value any) bool {
+func LessThan[T cmp.Ordered](n T) datacop.NamedRule {
+	return pure("less_than", datacop.Params{"limit": n}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v < n
-	}
+	})
 }
 
 // GreaterOrEqual returns a validation function that checks if a value is greater than or equal to a specified value
@@ -235,14 +335,14 @@ func LessThan[T cmp.Ordered](n T) datacop.ValidationFunc {
 // GreaterOrEqual(10)(15) // returns true
 // GreaterOrEqual(10)(10) // returns true
 // GreaterOrEqual(10)(5) // returns false
-func GreaterOrEqual[T cmp.Ordered](n T) datacop.ValidationFunc {
-	return func(value any) bool {
+func GreaterOrEqual[T cmp.Ordered](n T) datacop.NamedRule {
+	return pure("greater_or_equal", datacop.Params{"limit": n}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v >= n
-	}
+	})
 }
 
 // LessOrEqual returns a validation function that checks if a value is less than or equal to a specified value
@@ -251,12 +351,12 @@ func GreaterOrEqual[T cmp.Ordered](n T) datacop.ValidationFunc {
 // LessOrEqual(10)(5) // returns true
 // LessOrEqual(10)(10) // returns true
 // LessOrEqual(10)(15) // returns false
-func LessOrEqual[T cmp.Ordered](n T) datacop.ValidationFunc {
-	return func(value any) bool {
+func LessOrEqual[T cmp.Ordered](n T) datacop.NamedRule {
+	return pure("less_or_equal", datacop.Params{"limit": n}, func(value any) bool {
 		v, ok := value.(T)
 		if !ok {
 			return false
 		}
 		return v <= n
-	}
+	})
 }
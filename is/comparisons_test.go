@@ -2,8 +2,10 @@ package is_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/patrickward/datacop"
 	"github.com/patrickward/datacop/is"
@@ -103,6 +105,29 @@ func TestInValidation(t *testing.T) {
 	}
 }
 
+func TestInFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"same case", "draft", true},
+		{"different case", "Published", true},
+		{"upper case", "DRAFT", true},
+		{"not allowed", "archived", false},
+		{"empty", "", false},
+		{"non-string", 1, false},
+	}
+
+	rule := is.InFold("draft", "published")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rule(tt.value))
+		})
+	}
+	assert.Equal(t, "in_fold", rule.Name())
+}
+
 func TestAllInValidation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -506,3 +531,139 @@ func TestLessOrEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		fn     datacop.ValidationFunc
+		code   string
+		params datacop.Params
+	}{
+		{"MinLength", is.MinLength(8), "min_length", datacop.Params{"min": 8}},
+		{"MaxLength", is.MaxLength(64), "max_length", datacop.Params{"max": 64}},
+		{"Between", is.Between(1, 10), "between", datacop.Params{"min": 1, "max": 10}},
+		{"In", is.In("free", "pro"), "in", datacop.Params{"allowed": []string{"free", "pro"}}},
+		{"GreaterThan", is.GreaterThan(0), "greater_than", datacop.Params{"limit": 0}},
+		{"MinNumeric", is.MinNumeric(1.5), "min_numeric", datacop.Params{"min": 1.5}},
+		{"Match", is.Match(`^\d+$`), "match", datacop.Params{"pattern": `^\d+$`}},
+		{"Required", is.Required, "required", nil},
+		{"Email", is.Email, "email", nil},
+		{"IntString", is.IntString, "int_string", nil},
+		{"UUID", is.UUID, "uuid", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, params, ok := datacop.RuleInfo(tt.fn)
+			assert.True(t, ok)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.params, params)
+		})
+	}
+}
+
+func TestNamedRule(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		rule   datacop.NamedRule
+		want   string
+		params datacop.Params
+	}{
+		{"MinAge", is.MinAge(18), "min_age", datacop.Params{"years": 18, "layouts": []string(nil)}},
+		{"MaxAge", is.MaxAge(120, "01/02/2006"), "max_age", datacop.Params{"years": 120, "layouts": []string{"01/02/2006"}}},
+		{"NumericCode", is.NumericCode(6), "numeric_code", datacop.Params{"length": 6}},
+		{"CodeMatches keeps the code secret", is.CodeMatches("123456"), "code_matches", nil},
+		{"Equal", is.Equal(10), "equal", datacop.Params{"value": 10}},
+		{"NoDuplicates", is.NoDuplicates[int](), "no_duplicates", nil},
+		{"Superset is an alias of ContainsAll", is.Superset([]string{"read"}), "contains_all", datacop.Params{"required": []string{"read"}}},
+		{"StrongPassword", is.StrongPassword(is.PasswordPolicy{MinLength: 12, BannedSubstrings: []string{" Acme "}}), "strong_password", datacop.Params{
+			"min_length": 12, "max_length": 0, "require_upper": false, "require_lower": false,
+			"require_digit": false, "require_symbol": false, "banned": []string{"acme"},
+		}},
+		{"PhoneNumber", is.PhoneNumber(is.PhoneForRegion("gb")), "phone_number", datacop.Params{"e164": false, "extensions": false, "regions": []string{"GB"}}},
+		{"Pattern", is.Pattern("sku"), "pattern", datacop.Params{"name": "sku"}},
+		{"SignedValue keeps the secret", is.SignedValue([]byte("secret"), time.Hour), "signed_value", nil},
+		{"StartsWith", is.StartsWith("https://"), "starts_with", datacop.Params{"prefix": "https://"}},
+		{"EndsWithFold", is.EndsWithFold("@Example.com"), "ends_with_fold", datacop.Params{"suffix": "@example.com"}},
+		{"NotContains", is.NotContains("password"), "not_contains", datacop.Params{"substr": "password"}},
+		{"HumanName", is.HumanName("ru"), "human_name", datacop.Params{"locales": []string{"ru"}}},
+		{"Before", is.Before(start), "before", datacop.Params{"time": start}},
+		{"BetweenTime", is.BetweenTime(start, end), "between_time", datacop.Params{"start": start, "end": end}},
+		{"NotOlderThan", is.NotOlderThan(time.Hour), "not_older_than", datacop.Params{"duration": time.Hour}},
+		{"Weekday", is.Weekday(time.Saturday), "weekday", datacop.Params{"days": []time.Weekday{time.Saturday}}},
+		{"WithinBusinessHours", is.WithinBusinessHours("09:00", "17:00", nil), "within_business_hours", datacop.Params{"start": "09:00", "end": "17:00", "location": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Name())
+			assert.Equal(t, tt.params, tt.rule.Params())
+		})
+	}
+}
+
+func TestNamedRule_DefaultMessages(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	v := datacop.New()
+	v.Field("url", "http://example.com").Validate(is.StartsWith("https://"))
+	v.Field("starts_at", start.Add(-time.Hour)).Validate(is.After(start))
+	v.Field("code", "12").Validate(is.NumericCode(6))
+
+	assert.Equal(t, "must start with https://", v.ErrorFor("url"))
+	assert.Equal(t, "must be after 2026-01-01T09:00:00Z", v.ErrorFor("starts_at"))
+	assert.Equal(t, "must be a 6-digit code", v.ErrorFor("code"))
+}
+
+func TestSetRelationValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     datacop.NamedRule
+		value    any
+		expected bool
+	}{
+		{"subset", is.Subset([]string{"read", "write"}), []string{"read"}, true},
+		{"subset equal", is.Subset([]string{"read", "write"}), []string{"write", "read"}, true},
+		{"subset empty", is.Subset([]string{"read"}), []string{}, true},
+		{"subset with duplicates", is.Subset([]int{1, 2}), []int{1, 1, 2}, true},
+		{"not a subset", is.Subset([]string{"read", "write"}), []string{"read", "admin"}, false},
+		{"subset of empty set", is.Subset([]string{}), []string{"read"}, false},
+
+		{"superset", is.Superset([]string{"read"}), []string{"read", "write"}, true},
+		{"superset of empty set", is.Superset([]int{}), []int{}, true},
+		{"not a superset", is.Superset([]string{"read", "write"}), []string{"read"}, false},
+		{"superset is contains all", is.Superset([]string{"read"}), []string{"write"}, is.ContainsAll("read")([]string{"write"})},
+
+		{"contains all", is.ContainsAll(1, 2), []int{3, 2, 1}, true},
+		{"contains all none required", is.ContainsAll[int](), []int{}, true},
+		{"missing one", is.ContainsAll("read", "admin"), []string{"read", "write"}, false},
+
+		{"disjoint", is.Disjoint([]string{"auditor"}), []string{"admin", "editor"}, true},
+		{"disjoint empty", is.Disjoint([]string{"auditor"}), []string{}, true},
+		{"not disjoint", is.Disjoint([]string{"auditor"}), []string{"admin", "auditor"}, false},
+
+		{"wrong slice type", is.Subset([]int{1, 2}), []string{"1"}, false},
+		{"not a slice", is.ContainsAll("read"), "read", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule(tt.value))
+		})
+	}
+}
+
+func TestSetRelationMessages(t *testing.T) {
+	v := datacop.New()
+	v.Field("permissions", []string{"read", "admin"}).Validate(is.Subset([]string{"read", "write"}))
+	v.Field("roles", []string{"admin", "auditor"}).Validate(is.Disjoint([]string{"auditor"}))
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "subset", errs[0].Code)
+	assert.Equal(t, datacop.Params{"set": []string{"read", "write"}}, errs[0].Params)
+	assert.Equal(t, "disjoint", errs[1].Code)
+}
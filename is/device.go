@@ -0,0 +1,60 @@
+package is
+
+import (
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// MACAddress returns a validation function for 48-bit MAC addresses (EUI-48) written as six
+// pairs of hexadecimal digits separated by colons or hyphens, or as three groups of four
+// separated by dots, in either case. The separator must be used consistently.
+//
+// Example usage:
+// MACAddress()("00:1a:2b:3c:4d:5e") // returns true
+// MACAddress()("00-1A-2B-3C-4D-5E") // returns true
+// MACAddress()("001a.2b3c.4d5e") // returns true
+// MACAddress()("00:1a:2b-3c:4d:5e") // returns false
+func MACAddress() datacop.NamedRule {
+	return pure("mac_address", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		var groups []string
+		var size int
+		switch {
+		case len(str) == 17 && str[2] == ':':
+			groups, size = strings.Split(str, ":"), 2
+		case len(str) == 17 && str[2] == '-':
+			groups, size = strings.Split(str, "-"), 2
+		case len(str) == 14 && str[4] == '.':
+			groups, size = strings.Split(str, "."), 4
+		default:
+			return false
+		}
+		for _, g := range groups {
+			if len(g) != size || !isHexDigits(g) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// IMEI returns a validation function for International Mobile Equipment Identity numbers: 15
+// digits, the last of which is a Luhn check digit. Surrounding whitespace is ignored.
+//
+// Example usage:
+// IMEI()("490154203237518") // returns true
+// IMEI()("490154203237519") // returns false
+func IMEI() datacop.NamedRule {
+	return pure("imei", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+		return len(str) == 15 && isDigits(str) && luhn(str)
+	})
+}
@@ -0,0 +1,43 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestDeviceIdentifiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  datacop.NamedRule
+		value any
+		want  bool
+	}{
+		{"mac colons", is.MACAddress(), "00:1a:2b:3c:4d:5e", true},
+		{"mac hyphens upper case", is.MACAddress(), "00-1A-2B-3C-4D-5E", true},
+		{"mac dots", is.MACAddress(), "001a.2b3c.4d5e", true},
+		{"mac mixed separators", is.MACAddress(), "00:1a:2b-3c:4d:5e", false},
+		{"mac no separators", is.MACAddress(), "001a2b3c4d5e", false},
+		{"mac non-hex digit", is.MACAddress(), "00:1a:2b:3c:4d:5g", false},
+		{"mac eui-64", is.MACAddress(), "00:1a:2b:3c:4d:5e:6f:70", false},
+		{"mac short group", is.MACAddress(), "0:1a:2b:3c:4d:5e:6", false},
+		{"mac non-string", is.MACAddress(), []byte{0, 1, 2, 3, 4, 5}, false},
+
+		{"imei", is.IMEI(), "490154203237518", true},
+		{"imei surrounding space", is.IMEI(), " 356938035643809 ", true},
+		{"imei bad check digit", is.IMEI(), "490154203237519", false},
+		{"imei too short", is.IMEI(), "49015420323751", false},
+		{"imei imeisv length", is.IMEI(), "4901542032375181", false},
+		{"imei hyphens", is.IMEI(), "49-015420-323751-8", false},
+		{"imei non-string", is.IMEI(), 490154203237518, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule(tt.value))
+		})
+	}
+}
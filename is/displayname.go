@@ -0,0 +1,214 @@
+package is
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/patrickward/datacop"
+)
+
+// DisplayNamePolicy describes the requirements checked by DisplayName
+type DisplayNamePolicy struct {
+	MinLength int // minimum number of characters, ignoring surrounding spaces; 0 means no minimum
+	MaxLength int // maximum number of characters, ignoring surrounding spaces; 0 means no maximum
+
+	// Scripts restricts letters to the given scripts, such as unicode.Latin; nil allows any script
+	Scripts []*unicode.RangeTable
+	// AllowMixedScripts allows letters from more than one script in a name. By default a name
+	// must use a single script, which blocks look-alikes such as "paypal" spelled with a Cyrillic
+	// "a" (U+0430).
+	// Han, Hiragana, Katakana, and Hangul count as one script, as Japanese and Korean mix them.
+	AllowMixedScripts bool
+
+	// Reserved lists names that may not be used, such as "admin". A name is rejected if it
+	// matches a reserved name after transliteration; see below.
+	Reserved []string
+	// Blocklist lists words that may not appear anywhere in a name, such as profanity. A name is
+	// rejected if it contains a blocked word after transliteration, so keep the list to words
+	// that are unlikely to occur inside innocent ones.
+	Blocklist []string
+}
+
+// DefaultReservedNames are names commonly reserved for staff and system accounts
+var DefaultReservedNames = []string{
+	"admin", "administrator", "root", "system", "support", "staff", "moderator", "mod",
+	"official", "security", "help", "null", "undefined", "anonymous",
+}
+
+// DefaultDisplayNamePolicy is a policy suitable for public display names
+var DefaultDisplayNamePolicy = DisplayNamePolicy{
+	MinLength: 2,
+	MaxLength: 32,
+	Reserved:  DefaultReservedNames,
+}
+
+// DisplayName returns a validation function that checks a public display name against policy.
+// Names may contain letters, combining marks, digits, spaces, and the punctuation _ - . ' and
+// must contain at least one letter; control, formatting, and bidirectional characters are
+// rejected.
+//
+// Reserved names and blocked words are matched after transliteration: letters are lowercased,
+// accents are removed, common look-alike Cyrillic and Greek letters and digit substitutions
+// ("4dm1n") are mapped to Latin letters, and separators are ignored, so "A.d.m.1.n" and "Ädmin"
+// both match "admin".
+//
+// Example usage:
+//
+//	policy := is.DefaultDisplayNamePolicy
+//	policy.Blocklist = []string{"badword"}
+//	DisplayName(policy)("Zoë the Builder") // returns true
+//	DisplayName(policy)("4dm1n") // returns false
+//	DisplayName(policy)("p\u0430ypal") // returns false, mixes Latin and Cyrillic
+func DisplayName(policy DisplayNamePolicy) datacop.NamedRule {
+	reserved := skeletons(policy.Reserved)
+	blocked := skeletons(policy.Blocklist)
+
+	return datacop.Named("display_name", nil, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+		if !utf8.ValidString(str) {
+			return false
+		}
+
+		length := utf8.RuneCountInString(str)
+		if length == 0 || length < policy.MinLength || (policy.MaxLength > 0 && length > policy.MaxLength) {
+			return false
+		}
+
+		hasLetter := false
+		script := ""
+		for _, r := range str {
+			switch {
+			case unicode.IsLetter(r):
+				if len(policy.Scripts) > 0 && !unicode.In(r, policy.Scripts...) {
+					return false
+				}
+				if !policy.AllowMixedScripts {
+					s := scriptOf(r)
+					if script != "" && s != script {
+						return false
+					}
+					script = s
+				}
+				hasLetter = true
+			case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Mc, r), unicode.IsDigit(r):
+			case r == ' ', r == '_', r == '-', r == '.', r == '\'':
+			default:
+				return false
+			}
+		}
+		if !hasLetter {
+			return false
+		}
+
+		name := skeleton(str)
+		for _, r := range reserved {
+			if name == r {
+				return false
+			}
+		}
+		for _, b := range blocked {
+			if strings.Contains(name, b) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// scriptTables are the scripts told apart when checking for mixed scripts
+var scriptTables = []struct {
+	name   string
+	tables []*unicode.RangeTable
+}{
+	{"latin", []*unicode.RangeTable{unicode.Latin}},
+	{"cyrillic", []*unicode.RangeTable{unicode.Cyrillic}},
+	{"greek", []*unicode.RangeTable{unicode.Greek}},
+	{"arabic", []*unicode.RangeTable{unicode.Arabic}},
+	{"hebrew", []*unicode.RangeTable{unicode.Hebrew}},
+	{"armenian", []*unicode.RangeTable{unicode.Armenian}},
+	{"georgian", []*unicode.RangeTable{unicode.Georgian}},
+	{"devanagari", []*unicode.RangeTable{unicode.Devanagari}},
+	{"thai", []*unicode.RangeTable{unicode.Thai}},
+	{"cjk", []*unicode.RangeTable{unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul}},
+}
+
+// scriptOf returns the name of the script of a letter, or "other" for scripts not listed
+func scriptOf(r rune) string {
+	for _, s := range scriptTables {
+		if unicode.In(r, s.tables...) {
+			return s.name
+		}
+	}
+	return "other"
+}
+
+// transliterations maps letters, digits, and symbols to the Latin letter they resemble
+var transliterations = map[rune]rune{}
+
+func init() {
+	for latin, variants := range map[rune]string{
+		// Accented Latin letters
+		'a': "\u00e0\u00e1\u00e2\u00e3\u00e4\u00e5\u0101\u0103\u0105",
+		'c': "\u00e7\u0107\u0109\u010b\u010d",
+		'd': "\u010f\u0111",
+		'e': "\u00e8\u00e9\u00ea\u00eb\u0113\u0115\u0117\u0119\u011b",
+		'g': "\u011d\u011f\u0121\u0123",
+		'i': "\u00ec\u00ed\u00ee\u00ef\u0129\u012b\u012d\u012f\u0131",
+		'l': "\u013a\u013c\u013e\u0142",
+		'n': "\u00f1\u0144\u0146\u0148",
+		'o': "\u00f2\u00f3\u00f4\u00f5\u00f6\u00f8\u014d\u014f\u0151",
+		'r': "\u0155\u0157\u0159",
+		's': "\u015b\u015d\u015f\u0161",
+		't': "\u0163\u0165",
+		'u': "\u00f9\u00fa\u00fb\u00fc\u0169\u016b\u016d\u016f\u0171\u0173",
+		'y': "\u00fd\u00ff",
+		'z': "\u017a\u017c\u017e",
+	} {
+		for _, r := range variants {
+			transliterations[r] = latin
+		}
+	}
+
+	// Look-alike Cyrillic and Greek letters, digit and symbol substitutions
+	for _, pair := range []string{
+		"a\u0430", "c\u0441", "e\u0435", "i\u0456", "j\u0458", "k\u043a", "o\u043e", "p\u0440",
+		"s\u0455", "x\u0445", "y\u0443",
+		"a\u03b1", "i\u03b9", "k\u03ba", "o\u03bf", "p\u03c1", "t\u03c4", "u\u03c5", "v\u03bd",
+		"o0", "i1", "e3", "a4", "s5", "t7", "b8", "a@", "s$", "i!",
+	} {
+		latin, size := utf8.DecodeRuneInString(pair)
+		r, _ := utf8.DecodeRuneInString(pair[size:])
+		transliterations[r] = latin
+	}
+}
+
+// skeleton transliterates s for matching: it lowercases letters, maps them with
+// transliterations, and drops everything that is not a letter or digit
+func skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if latin, ok := transliterations[r]; ok {
+			b.WriteRune(latin)
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func skeletons(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if s := skeleton(w); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package is_test
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestDisplayName(t *testing.T) {
+	policy := is.DefaultDisplayNamePolicy
+	policy.Blocklist = []string{"darn"}
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"plain", "Zoë the Builder", true},
+		{"digits and punctuation", "jean-luc_42.o'neil", true},
+		{"other script", "\u0410\u043d\u043d\u0430", true},
+		{"japanese mixes scripts", "さくら花子", true},
+		{"surrounding spaces ignored", "  Al  ", true},
+		{"too short", "A", false},
+		{"too long", "abcdefghijklmnopqrstuvwxyzabcdefg", false},
+		{"no letters", "1234", false},
+		{"symbols", "hello<world>", false},
+		{"bidi control", "abc\u202edef", false},
+		{"zero width", "ad\u200bmin", false},
+		{"mixed scripts", "p\u0430ypal", false},
+		{"reserved", "Admin", false},
+		{"reserved with digits", "4dm1n", false},
+		{"reserved with separators", "A.d.m.i.n", false},
+		{"reserved with accents", "Ädmïn", false},
+		{"reserved with spaces", "Root ", false},
+		{"reserved word inside a name", "admiral", true},
+		{"blocked word", "xXdarnXx", false},
+		{"blocked word with substitutions", "d4rn_it", false},
+		{"non-string", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.DisplayName(policy)(tt.value))
+		})
+	}
+}
+
+func TestDisplayName_Policy(t *testing.T) {
+	latin := is.DisplayName(is.DisplayNamePolicy{Scripts: []*unicode.RangeTable{unicode.Latin}})
+	assert.True(t, latin("Anna"))
+	assert.False(t, latin("\u0410\u043d\u043d\u0430"))
+
+	mixed := is.DisplayName(is.DisplayNamePolicy{AllowMixedScripts: true})
+	assert.True(t, mixed("p\u0430ypal"))
+
+	// Reserved names are transliterated too
+	reserved := is.DisplayName(is.DisplayNamePolicy{Reserved: []string{"\u0440\u0430ypal"}})
+	assert.False(t, reserved("PayPal"))
+}
@@ -0,0 +1,154 @@
+//go:build !tinygo
+
+package is
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// disposableDomains is a small built-in list of well-known disposable email providers.
+// Extend it with EmailOptions.DisposableDomains.
+var disposableDomains = []string{
+	"10minutemail.com",
+	"discard.email",
+	"dispostable.com",
+	"fakeinbox.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"maildrop.cc",
+	"sharklasers.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// MXResolver looks up the mail exchangers for a domain, and the addresses of domains without
+// any. *net.Resolver implements it.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailOptions configures the checks performed by EmailWith in addition to the format check
+// performed by Email. Domain lists match the domain itself and any of its subdomains,
+// ignoring case.
+type EmailOptions struct {
+	// CheckMX requires the domain to accept mail: to publish at least one usable MX record or,
+	// if it publishes none, an A or AAAA record, which senders use as an implicit MX (RFC 5321)
+	CheckMX bool
+
+	// Resolver is used for MX lookups. Defaults to net.DefaultResolver.
+	Resolver MXResolver
+
+	// Timeout bounds each MX lookup made by EmailWith. Defaults to 5 seconds.
+	// EmailWithContext uses the deadline of its context instead.
+	Timeout time.Duration
+
+	// BlockDisposable rejects addresses at known disposable email providers
+	BlockDisposable bool
+
+	// DisposableDomains adds domains to the built-in disposable provider list
+	DisposableDomains []string
+
+	// AllowDomains, when not empty, only accepts addresses at these domains
+	AllowDomains []string
+
+	// DenyDomains rejects addresses at these domains
+	DenyDomains []string
+}
+
+// EmailWith returns a validation function that checks an email address's format and then
+// applies the checks configured in opts. MX lookups are bounded by opts.Timeout; use
+// EmailWithContext to tie them to a request's context instead.
+//
+// Example usage:
+//
+//	EmailWith(is.EmailOptions{
+//		CheckMX:         true,
+//		BlockDisposable: true,
+//		DenyDomains:     []string{"competitor.com"},
+//	})("jane@example.com")
+func EmailWith(opts EmailOptions) datacop.NamedRule {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return datacop.Named("email", nil, func(value any) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return checkEmail(ctx, opts, value)
+	})
+}
+
+// EmailWithContext is like EmailWith, but MX lookups use ctx, so they are cancelled along
+// with the request that triggered them
+func EmailWithContext(ctx context.Context, opts EmailOptions) datacop.NamedRule {
+	return datacop.Named("email", nil, func(value any) bool {
+		return checkEmail(ctx, opts, value)
+	})
+}
+
+func checkEmail(ctx context.Context, opts EmailOptions, value any) bool {
+	if !Email(value) {
+		return false
+	}
+	str := value.(string)
+	domain := strings.ToLower(str[strings.LastIndex(str, "@")+1:])
+
+	if len(opts.AllowDomains) > 0 && !domainIn(domain, opts.AllowDomains) {
+		return false
+	}
+	if domainIn(domain, opts.DenyDomains) {
+		return false
+	}
+	if opts.BlockDisposable && (domainIn(domain, disposableDomains) || domainIn(domain, opts.DisposableDomains)) {
+		return false
+	}
+
+	if opts.CheckMX {
+		resolver := opts.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		records, err := resolver.LookupMX(ctx, domain)
+		var dnsErr *net.DNSError
+		if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return false
+		}
+		if len(records) == 0 {
+			// Without MX records, mail is delivered to the domain's own address
+			addrs, err := resolver.LookupHost(ctx, domain)
+			return err == nil && len(addrs) > 0
+		}
+		// A single "." host is a null MX (RFC 7505): the domain accepts no mail
+		for _, mx := range records {
+			if mx.Host != "" && mx.Host != "." {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// domainIn reports whether domain equals, or is a subdomain of, any domain in list
+func domainIn(domain string, list []string) bool {
+	for _, d := range list {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+//go:build !tinygo
+
+package is_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+// fakeResolver returns canned MX records and addresses per domain
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+}
+
+func (f fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if name == "broken.test" {
+		return nil, errors.New("server misbehaving")
+	}
+	records, ok := f.mx[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func (f fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	addrs, ok := f.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+func TestEmailWith(t *testing.T) {
+	resolver := fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.com.", Pref: 10}},
+			"nomail.com":  {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"nomail.com":  {"192.0.2.1"},
+			"address.com": {"192.0.2.2", "2001:db8::2"},
+			"broken.test": {"192.0.2.3"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		opts  is.EmailOptions
+		value any
+		want  bool
+	}{
+		{"format only", is.EmailOptions{}, "jane@anything.test", true},
+		{"invalid format", is.EmailOptions{}, "not-an-email", false},
+		{"non-string value", is.EmailOptions{}, 42, false},
+
+		{"mx found", is.EmailOptions{CheckMX: true, Resolver: resolver}, "jane@Example.com", true},
+		{"mx missing", is.EmailOptions{CheckMX: true, Resolver: resolver}, "jane@unknown.test", false},
+		{"null mx", is.EmailOptions{CheckMX: true, Resolver: resolver}, "jane@nomail.com", false},
+		{"address without mx", is.EmailOptions{CheckMX: true, Resolver: resolver}, "jane@address.com", true},
+		{"lookup error", is.EmailOptions{CheckMX: true, Resolver: resolver}, "jane@broken.test", false},
+
+		{"disposable blocked", is.EmailOptions{BlockDisposable: true}, "jane@mailinator.com", false},
+		{"disposable subdomain blocked", is.EmailOptions{BlockDisposable: true}, "jane@eu.MAILINATOR.com", false},
+		{"custom disposable blocked", is.EmailOptions{BlockDisposable: true, DisposableDomains: []string{"burner.test"}}, "jane@burner.test", false},
+		{"disposable allowed by default", is.EmailOptions{}, "jane@mailinator.com", true},
+		{"regular domain not disposable", is.EmailOptions{BlockDisposable: true}, "jane@example.com", true},
+
+		{"allow list match", is.EmailOptions{AllowDomains: []string{"corp.com"}}, "jane@corp.com", true},
+		{"allow list subdomain", is.EmailOptions{AllowDomains: []string{"corp.com"}}, "jane@eu.corp.com", true},
+		{"allow list lookalike", is.EmailOptions{AllowDomains: []string{"corp.com"}}, "jane@evilcorp.com", false},
+		{"allow list miss", is.EmailOptions{AllowDomains: []string{"corp.com"}}, "jane@example.com", false},
+		{"deny list", is.EmailOptions{DenyDomains: []string{"competitor.com"}}, "jane@competitor.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.EmailWith(tt.opts)(tt.value))
+		})
+	}
+}
+
+func TestEmailWithContext(t *testing.T) {
+	resolver := fakeResolver{mx: map[string][]*net.MX{"example.com": {{Host: "mx.example.com.", Pref: 10}}}}
+	opts := is.EmailOptions{CheckMX: true, Resolver: resolver}
+
+	assert.True(t, is.EmailWithContext(context.Background(), opts)("jane@example.com"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, is.EmailWithContext(ctx, opts)("jane@example.com"))
+}
+
+func TestEmailWith_Name(t *testing.T) {
+	assert.Equal(t, "email", is.EmailWith(is.EmailOptions{}).Name())
+	assert.Equal(t, "email", is.EmailWithContext(context.Background(), is.EmailOptions{}).Name())
+	assert.Nil(t, is.EmailWith(is.EmailOptions{CheckMX: true}).Params())
+}
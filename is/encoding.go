@@ -0,0 +1,98 @@
+package is
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// Hexadecimal returns a validation function for non-empty strings of hexadecimal digits, in
+// either case, without a "0x" prefix.
+//
+// Example usage:
+// Hexadecimal()("deadBEEF") // returns true
+// Hexadecimal()("0xdeadbeef") // returns false
+func Hexadecimal() datacop.NamedRule {
+	return pure("hexadecimal", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && str != "" && isHexDigits(str)
+	})
+}
+
+// Base64 returns a validation function for non-empty strings in the standard base64 encoding of
+// RFC 4648, with padding. Line breaks are not accepted.
+//
+// Example usage:
+// Base64()("aGVsbG8=") // returns true
+// Base64()("aGVsbG8") // returns false
+func Base64() datacop.NamedRule {
+	return pure("base64", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && isBase64(base64.StdEncoding, str)
+	})
+}
+
+// Base64URL returns a validation function for non-empty strings in the URL-safe base64 encoding
+// of RFC 4648, with or without padding, as used in JWTs and webhook signatures. Line breaks are
+// not accepted.
+//
+// Example usage:
+// Base64URL()("aGVsbG8_") // returns true
+// Base64URL()("aGVsbG8") // returns true
+// Base64URL()("aGVsbG8+") // returns false
+func Base64URL() datacop.NamedRule {
+	return pure("base64url", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if strings.HasSuffix(str, "=") {
+			return isBase64(base64.URLEncoding, str)
+		}
+		return isBase64(base64.RawURLEncoding, str)
+	})
+}
+
+// MD5 returns a validation function for MD5 digests written as 32 hexadecimal digits, in either
+// case. Only the format is checked.
+//
+// Example usage:
+// MD5()("d41d8cd98f00b204e9800998ecf8427e") // returns true
+func MD5() datacop.NamedRule {
+	return pure("md5", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && len(str) == 32 && isHexDigits(str)
+	})
+}
+
+// SHA256 returns a validation function for SHA-256 digests written as 64 hexadecimal digits, in
+// either case. Only the format is checked.
+//
+// Example usage:
+// SHA256()("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855") // returns true
+func SHA256() datacop.NamedRule {
+	return pure("sha256", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && len(str) == 64 && isHexDigits(str)
+	})
+}
+
+// isBase64 reports whether str is non-empty and decodes with enc. Line breaks, which the decoder
+// skips, are rejected.
+func isBase64(enc *base64.Encoding, str string) bool {
+	if str == "" || strings.ContainsAny(str, "\r\n") {
+		return false
+	}
+	_, err := enc.Strict().DecodeString(str)
+	return err == nil
+}
+
+func isHexDigits(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isHexDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
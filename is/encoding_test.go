@@ -0,0 +1,59 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestEncodingFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  datacop.NamedRule
+		value any
+		want  bool
+	}{
+		{"hex lower case", is.Hexadecimal(), "deadbeef01", true},
+		{"hex mixed case", is.Hexadecimal(), "DeadBeef", true},
+		{"hex odd length", is.Hexadecimal(), "abc", true},
+		{"hex prefix", is.Hexadecimal(), "0xdeadbeef", false},
+		{"hex non-hex digit", is.Hexadecimal(), "deadbeeg", false},
+		{"hex empty", is.Hexadecimal(), "", false},
+		{"hex non-string", is.Hexadecimal(), 255, false},
+
+		{"base64 padded", is.Base64(), "aGVsbG8=", true},
+		{"base64 no padding needed", is.Base64(), "aGVsbG8h", true},
+		{"base64 plus and slash", is.Base64(), "+/+/", true},
+		{"base64 missing padding", is.Base64(), "aGVsbG8", false},
+		{"base64 url alphabet", is.Base64(), "-_-_", false},
+		{"base64 line break", is.Base64(), "aGVs\nbG8=", false},
+		{"base64 trailing bits", is.Base64(), "aGVsbG9=", false},
+		{"base64 empty", is.Base64(), "", false},
+
+		{"base64url unpadded", is.Base64URL(), "aGVsbG8", true},
+		{"base64url padded", is.Base64URL(), "aGVsbG8=", true},
+		{"base64url dash and underscore", is.Base64URL(), "-_-_", true},
+		{"base64url standard alphabet", is.Base64URL(), "+/+/", false},
+		{"base64url bad length", is.Base64URL(), "aGVsb", false},
+		{"base64url empty", is.Base64URL(), "", false},
+		{"base64url non-string", is.Base64URL(), []byte("aGVsbG8"), false},
+
+		{"md5", is.MD5(), "d41d8cd98f00b204e9800998ecf8427e", true},
+		{"md5 upper case", is.MD5(), "D41D8CD98F00B204E9800998ECF8427E", true},
+		{"md5 too short", is.MD5(), "d41d8cd98f00b204e9800998ecf8427", false},
+		{"md5 sha256 length", is.MD5(), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", false},
+
+		{"sha256", is.SHA256(), "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", true},
+		{"sha256 non-hex digit", is.SHA256(), "z3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", false},
+		{"sha256 md5 length", is.SHA256(), "d41d8cd98f00b204e9800998ecf8427e", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule(tt.value))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package is
+
+import "github.com/patrickward/datacop"
+
+// EnumSet is the set of values of a typed string enum, created with Enum. Its Rule validates
+// values against the set and Parse returns the declared spelling of a value, so a value matched
+// after normalization can be stored in its canonical form.
+type EnumSet[T ~string] struct {
+	values    []T
+	normalize func(string) string
+}
+
+// Enum returns the set of values of a typed string enum. Values are matched exactly unless a
+// normalization is set with Normalize.
+//
+// Example usage:
+//
+//	type Status string
+//
+//	var statuses = is.Enum[Status]("active", "suspended").Normalize(strings.ToLower)
+//
+//	v.Field("status", raw).Validate(statuses.Rule(), "must be active or suspended")
+//	status, ok := statuses.Parse(raw) // Status("active") for "Active"
+func Enum[T ~string](values ...T) EnumSet[T] {
+	return EnumSet[T]{values: values}
+}
+
+// Normalize returns a copy of the set that compares values after applying fn to both the value
+// and the declared values, such as strings.ToLower for case-insensitive matching or a function
+// that also trims spaces or replaces hyphens with underscores.
+func (e EnumSet[T]) Normalize(fn func(string) string) EnumSet[T] {
+	e.normalize = fn
+	return e
+}
+
+// Values returns the declared values
+func (e EnumSet[T]) Values() []T {
+	return e.values
+}
+
+// Rule returns a validation function that checks that a value of type T or string is in the set
+func (e EnumSet[T]) Rule() datacop.NamedRule {
+	return pure("enum", datacop.Params{"allowed": e.values}, func(value any) bool {
+		_, ok := e.Parse(value)
+		return ok
+	})
+}
+
+// Parse returns the declared value matching a value of type T or string, and false if there is
+// none
+func (e EnumSet[T]) Parse(value any) (T, bool) {
+	var str string
+	switch v := value.(type) {
+	case T:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return "", false
+	}
+	if e.normalize != nil {
+		str = e.normalize(str)
+	}
+	for _, declared := range e.values {
+		d := string(declared)
+		if e.normalize != nil {
+			d = e.normalize(d)
+		}
+		if d == str {
+			return declared, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,67 @@
+package is_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+type status string
+
+const (
+	statusActive    status = "active"
+	statusSuspended status = "suspended"
+)
+
+func TestEnum(t *testing.T) {
+	exact := is.Enum(statusActive, statusSuspended)
+	folded := exact.Normalize(strings.ToLower)
+	slugs := is.Enum[status]("on_hold", "closed").Normalize(func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	})
+
+	tests := []struct {
+		name  string
+		enum  is.EnumSet[status]
+		value any
+		want  status
+		ok    bool
+	}{
+		{"typed value", exact, statusActive, statusActive, true},
+		{"string value", exact, "suspended", statusSuspended, true},
+		{"case differs without normalization", exact, "Active", "", false},
+		{"case differs with normalization", folded, "ACTIVE", statusActive, true},
+		{"typed value with normalization", folded, status("Suspended"), statusSuspended, true},
+		{"not declared", folded, "deleted", "", false},
+		{"custom normalization", slugs, " On-Hold ", "on_hold", true},
+		{"other type", exact, 1, "", false},
+		{"nil", exact, nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.enum.Parse(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ok, tt.enum.Rule()(tt.value))
+		})
+	}
+}
+
+func TestEnum_Rule(t *testing.T) {
+	rule := is.Enum(statusActive, statusSuspended).Rule()
+	assert.Equal(t, "enum", rule.Name())
+	assert.Equal(t, datacop.Params{"allowed": []status{statusActive, statusSuspended}}, rule.Params())
+
+	v := datacop.New()
+	v.Field("status", "deleted").Validate(rule)
+	assert.Equal(t, "must be one of [active suspended]", v.ErrorFor("status"))
+
+	plain := is.Enum("a", "b")
+	assert.Equal(t, []string{"a", "b"}, plain.Values())
+	assert.True(t, plain.Rule()("b"))
+}
@@ -0,0 +1,188 @@
+package is
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// FaultInjectionEnv is the environment variable that enables fault injection when set to a
+// true value, such as "1" or "true"
+const FaultInjectionEnv = "DATACOP_FAULT_INJECTION"
+
+// ErrInjectedFault is the error returned by the services wrapped with FaultyModerator and
+// FaultyResolver when a fault is injected
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultConfig configures the faults injected by FaultyModerator, FaultyResolver, and
+// WithFaultInjection. Rates are fractions of calls, from 0 to 1.
+type FaultConfig struct {
+	// LatencyRate is the fraction of calls delayed by Latency before they run
+	LatencyRate float64
+	Latency     time.Duration
+
+	// ErrorRate is the fraction of calls that fail without running, as a service does when it
+	// is down
+	ErrorRate float64
+
+	// Context, when set, cuts injected latency short once it is done and fails the call, as a
+	// deadline would cut short a slow call to the service. Use the context the validator's own
+	// calls use, so its timeout is exercised. FaultyModerator and FaultyResolver use the
+	// context of each call when it is nil.
+	Context context.Context
+
+	// Rand returns the random numbers in [0, 1) that decide which calls get faults. Defaults to
+	// math/rand/v2.Float64.
+	Rand func() float64
+}
+
+// FaultInjectionEnabled reports whether faults are injected: in builds with the
+// faultinjection tag, or when the FaultInjectionEnv environment variable is true
+func FaultInjectionEnabled() bool {
+	if faultInjectionTag {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(FaultInjectionEnv))
+	return enabled
+}
+
+// FaultyModerator wraps m so some of its calls are delayed or fail with ErrInjectedFault, as
+// configured by cfg. Use it in staging to check that timeouts and fallbacks actually protect
+// request latency when the moderation service is slow or down. Faults are injected at the
+// service, so PassesModeration handles them like real failures and records its "moderation
+// failed" warning.
+//
+// Faults are only injected when FaultInjectionEnabled reports true at the time of the call to
+// FaultyModerator; otherwise m is returned unchanged, so the wrapper costs nothing in production.
+//
+// Example usage:
+//
+//	// DATACOP_FAULT_INJECTION=1 in staging
+//	moderator = is.FaultyModerator(moderator, is.FaultConfig{
+//		LatencyRate: 0.2,
+//		Latency:     2 * time.Second,
+//		ErrorRate:   0.05,
+//	})
+//	ctx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+//	defer cancel()
+//	v.Field("comment", comment).Validate(is.PassesModeration(ctx, moderator))
+func FaultyModerator(m Moderator, cfg FaultConfig) Moderator {
+	if !FaultInjectionEnabled() {
+		return m
+	}
+	return faultyModerator{m: m, cfg: cfg}
+}
+
+type faultyModerator struct {
+	m   Moderator
+	cfg FaultConfig
+}
+
+func (f faultyModerator) Moderate(ctx context.Context, text string) (ModerationResult, error) {
+	if err := f.cfg.inject(ctx); err != nil {
+		return ModerationResult{}, err
+	}
+	return f.m.Moderate(ctx, text)
+}
+
+// FaultyResolver wraps r so some of its lookups are delayed or fail with ErrInjectedFault, as
+// configured by cfg, like FaultyModerator does for a Moderator. Set it as EmailOptions.Resolver.
+//
+// Example usage:
+//
+//	opts.Resolver = is.FaultyResolver(net.DefaultResolver, is.FaultConfig{ErrorRate: 0.05})
+func FaultyResolver(r MXResolver, cfg FaultConfig) MXResolver {
+	if !FaultInjectionEnabled() {
+		return r
+	}
+	return faultyResolver{r: r, cfg: cfg}
+}
+
+type faultyResolver struct {
+	r   MXResolver
+	cfg FaultConfig
+}
+
+func (f faultyResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if err := f.cfg.inject(ctx); err != nil {
+		return nil, err
+	}
+	return f.r.LookupMX(ctx, name)
+}
+
+func (f faultyResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := f.cfg.inject(ctx); err != nil {
+		return nil, err
+	}
+	return f.r.LookupHost(ctx, host)
+}
+
+// WithFaultInjection wraps a validation function so some of its calls are delayed or fail, as
+// configured by cfg. A failed call returns false without running fn, so fn's own error handling
+// is skipped; prefer FaultyModerator and FaultyResolver for the validators that take a service.
+// The rule's name, params, and annotations are kept.
+//
+// Faults are only injected when FaultInjectionEnabled reports true at the time of the call to
+// WithFaultInjection; otherwise fn is returned unchanged.
+//
+// Example usage:
+//
+//	rule := is.WithFaultInjection(usernameFree(ctx, users), is.FaultConfig{
+//		LatencyRate: 0.2,
+//		Latency:     2 * time.Second,
+//		Context:     ctx,
+//	})
+//	v.Field("username", username).Validate(rule)
+func WithFaultInjection(fn datacop.ValidationFunc, cfg FaultConfig) datacop.ValidationFunc {
+	if !FaultInjectionEnabled() {
+		return fn
+	}
+	return datacop.Intercept(fn, func(call func() bool) bool {
+		if cfg.inject(cfg.Context) != nil {
+			return false
+		}
+		return call()
+	})
+}
+
+// inject delays or fails a call as configured by cfg. It returns ErrInjectedFault for an
+// injected error, and the error of cfg.Context, or else of ctx, when injected latency is cut
+// short.
+func (cfg FaultConfig) inject(ctx context.Context) error {
+	if cfg.Context != nil {
+		ctx = cfg.Context
+	}
+	random := cfg.Rand
+	if random == nil {
+		random = rand.Float64
+	}
+	if cfg.LatencyRate > 0 && random() < cfg.LatencyRate && !injectLatency(ctx, cfg.Latency) {
+		return context.Cause(ctx)
+	}
+	if cfg.ErrorRate > 0 && random() < cfg.ErrorRate {
+		return ErrInjectedFault
+	}
+	return nil
+}
+
+// injectLatency waits for d, or until ctx is done. It reports false if ctx was done first.
+func injectLatency(ctx context.Context, d time.Duration) bool {
+	if ctx == nil {
+		time.Sleep(d)
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !faultinjection
+
+package is
+
+// faultInjectionTag enables fault injection in builds with the faultinjection tag
+const faultInjectionTag = false
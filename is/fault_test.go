@@ -0,0 +1,121 @@
+package is_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// sequence returns a Rand function that yields values in order
+func sequence(values ...float64) func() float64 {
+	return func() float64 {
+		v := values[0]
+		values = values[1:]
+		return v
+	}
+}
+
+func TestWithFaultInjection_Disabled(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "0")
+	if is.FaultInjectionEnabled() {
+		t.Skip("built with the faultinjection tag")
+	}
+
+	rule := is.WithFaultInjection(is.MinLength(3), is.FaultConfig{ErrorRate: 1})
+	assert.True(t, rule("abc"))
+}
+
+func TestWithFaultInjection(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "true")
+	assert.True(t, is.FaultInjectionEnabled())
+
+	tests := []struct {
+		name    string
+		cfg     is.FaultConfig
+		value   string
+		want    bool
+		minTime time.Duration
+	}{
+		{"no faults", is.FaultConfig{LatencyRate: 0.5, ErrorRate: 0.5, Latency: time.Hour, Rand: sequence(0.9, 0.9)}, "abc", true, 0},
+		{"rule still fails", is.FaultConfig{}, "ab", false, 0},
+		{"injected error", is.FaultConfig{ErrorRate: 0.5, Rand: sequence(0.1)}, "abc", false, 0},
+		{"injected latency", is.FaultConfig{LatencyRate: 0.5, Latency: 20 * time.Millisecond, Rand: sequence(0.1)}, "abc", true, 20 * time.Millisecond},
+		{"always", is.FaultConfig{ErrorRate: 1}, "abc", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := is.WithFaultInjection(is.MinLength(3), tt.cfg)
+			start := time.Now()
+			assert.Equal(t, tt.want, rule(tt.value))
+			assert.GreaterOrEqual(t, time.Since(start), tt.minTime)
+		})
+	}
+}
+
+func TestWithFaultInjection_ContextCutsLatency(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	rule := is.WithFaultInjection(is.MinLength(3), is.FaultConfig{LatencyRate: 1, Latency: time.Hour, Context: ctx})
+	start := time.Now()
+	assert.False(t, rule("abc"), "a call cut short by its deadline fails")
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestWithFaultInjection_KeepsMetadata(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "1")
+	rule := is.WithFaultInjection(is.MinLength(3), is.FaultConfig{ErrorRate: 1})
+	name, _, _ := datacop.RuleInfo(rule)
+	assert.Equal(t, "min_length", name)
+
+	v := datacop.New()
+	v.Field("name", "abc").Validate(rule)
+	assert.Equal(t, "min_length", v.OrderedErrors()[0].Code)
+}
+
+func TestFaultyModerator(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "1")
+	m := &keywordModerator{}
+	moderator := is.FaultyModerator(m, is.FaultConfig{ErrorRate: 0.5, Rand: sequence(0.1, 0.9)})
+
+	v := datacop.New()
+	v.Field("comment", "hello").Validate(is.PassesModeration(context.Background(), moderator), "could not be checked")
+	assert.Equal(t, "could not be checked", v.ErrorFor("comment"))
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "comment", Kind: datacop.AnnotationWarning, Message: "moderation failed", Data: map[string]any{"error": is.ErrInjectedFault.Error()}},
+	}, v.Annotations(), "injected faults go through the validator's own error handling")
+	assert.Equal(t, 0, m.calls)
+
+	assert.True(t, is.PassesModeration(context.Background(), moderator)("hello"))
+	assert.Equal(t, 1, m.calls)
+}
+
+func TestFaultyResolver(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	resolver := is.FaultyResolver(fakeResolver{}, is.FaultConfig{LatencyRate: 1, Latency: time.Hour})
+	_, err := resolver.LookupMX(ctx, "example.com")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "injected latency is cut short by the call's context")
+
+	resolver = is.FaultyResolver(fakeResolver{}, is.FaultConfig{ErrorRate: 1})
+	_, err = resolver.LookupMX(context.Background(), "example.com")
+	assert.ErrorIs(t, err, is.ErrInjectedFault)
+}
+
+func TestFaultyModerator_Disabled(t *testing.T) {
+	t.Setenv(is.FaultInjectionEnv, "0")
+	if is.FaultInjectionEnabled() {
+		t.Skip("built with the faultinjection tag")
+	}
+	m := &keywordModerator{}
+	assert.Same(t, m, is.FaultyModerator(m, is.FaultConfig{ErrorRate: 1}))
+}
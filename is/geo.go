@@ -0,0 +1,102 @@
+package is
+
+import (
+	"strings"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+// Latitude returns a validation function for latitudes in decimal degrees, from -90 to 90, given
+// as a number or a numeric string.
+//
+// Example usage:
+// Latitude()(51.5072) // returns true
+// Latitude()("-33.8688") // returns true
+// Latitude()(91) // returns false
+func Latitude() datacop.NamedRule {
+	return pure("latitude", datacop.Params{}, func(value any) bool {
+		lat, ok := numeric.Float(value)
+		return ok && lat >= -90 && lat <= 90
+	})
+}
+
+// Longitude returns a validation function for longitudes in decimal degrees, from -180 to 180,
+// given as a number or a numeric string.
+//
+// Example usage:
+// Longitude()(-0.1276) // returns true
+// Longitude()("151.2093") // returns true
+// Longitude()(181) // returns false
+func Longitude() datacop.NamedRule {
+	return pure("longitude", datacop.Params{}, func(value any) bool {
+		lng, ok := numeric.Float(value)
+		return ok && lng >= -180 && lng <= 180
+	})
+}
+
+// WithinBoundingBox returns a validation function that checks that a coordinate lies within the
+// box from (minLat, minLng) to (maxLat, maxLng), edges included. A box whose minLng is greater
+// than its maxLng crosses the antimeridian, such as one around Fiji from 176 to -178.
+//
+// The coordinate is a latitude and longitude, given as a two-element []float64, [2]float64,
+// []string, or []any; as a "lat,lng" string; or as a map[string]any with "lat" and "lng" or
+// "lon" keys, as decoded from JSON. Each part may be a number or a numeric string.
+//
+// Example usage:
+// WithinBoundingBox(49.9, -8.6, 60.9, 1.8)([]float64{51.5072, -0.1276}) // returns true
+// WithinBoundingBox(49.9, -8.6, 60.9, 1.8)("48.8566,2.3522") // returns false
+func WithinBoundingBox(minLat, minLng, maxLat, maxLng float64) datacop.NamedRule {
+	params := datacop.Params{"min_lat": minLat, "min_lng": minLng, "max_lat": maxLat, "max_lng": maxLng}
+	return pure("within_bounding_box", params, func(value any) bool {
+		lat, lng, ok := coordinate(value)
+		if !ok || lat < -90 || lat > 90 || lng < -180 || lng > 180 || lat < minLat || lat > maxLat {
+			return false
+		}
+		if minLng > maxLng {
+			return lng >= minLng || lng <= maxLng
+		}
+		return lng >= minLng && lng <= maxLng
+	})
+}
+
+// coordinate returns the latitude and longitude of a coordinate value; see WithinBoundingBox
+func coordinate(value any) (lat, lng float64, ok bool) {
+	var latValue, lngValue any
+	switch v := value.(type) {
+	case []float64:
+		if len(v) != 2 {
+			return 0, 0, false
+		}
+		latValue, lngValue = v[0], v[1]
+	case [2]float64:
+		latValue, lngValue = v[0], v[1]
+	case []string:
+		if len(v) != 2 {
+			return 0, 0, false
+		}
+		latValue, lngValue = v[0], v[1]
+	case []any:
+		if len(v) != 2 {
+			return 0, 0, false
+		}
+		latValue, lngValue = v[0], v[1]
+	case string:
+		first, second, found := strings.Cut(v, ",")
+		if !found {
+			return 0, 0, false
+		}
+		latValue, lngValue = first, second
+	case map[string]any:
+		latValue = v["lat"]
+		if lngValue = v["lng"]; lngValue == nil {
+			lngValue = v["lon"]
+		}
+	default:
+		return 0, 0, false
+	}
+
+	lat, latOK := numeric.Float(latValue)
+	lng, lngOK := numeric.Float(lngValue)
+	return lat, lng, latOK && lngOK
+}
@@ -0,0 +1,62 @@
+package is_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestCoordinates(t *testing.T) {
+	uk := is.WithinBoundingBox(49.9, -8.6, 60.9, 1.8)
+	fiji := is.WithinBoundingBox(-21, 176, -12, -178)
+
+	tests := []struct {
+		name  string
+		rule  datacop.NamedRule
+		value any
+		want  bool
+	}{
+		{"latitude", is.Latitude(), 51.5072, true},
+		{"latitude string", is.Latitude(), "-33.8688", true},
+		{"latitude pole", is.Latitude(), -90, true},
+		{"latitude json number", is.Latitude(), json.Number("45"), true},
+		{"latitude too high", is.Latitude(), 91, false},
+		{"latitude not a number", is.Latitude(), "north", false},
+		{"latitude nil", is.Latitude(), nil, false},
+
+		{"longitude", is.Longitude(), -0.1276, true},
+		{"longitude string", is.Longitude(), " 151.2093 ", true},
+		{"longitude antimeridian", is.Longitude(), 180, true},
+		{"longitude too low", is.Longitude(), -180.5, false},
+
+		{"box float slice", uk, []float64{51.5072, -0.1276}, true},
+		{"box array", uk, [2]float64{55.9533, -3.1883}, true},
+		{"box string", uk, "51.5072, -0.1276", true},
+		{"box string slice", uk, []string{"51.5072", "-0.1276"}, true},
+		{"box any slice", uk, []any{51.5072, "-0.1276"}, true},
+		{"box map lng", uk, map[string]any{"lat": 51.5072, "lng": -0.1276}, true},
+		{"box map lon", uk, map[string]any{"lat": "51.5072", "lon": -0.1276}, true},
+		{"box edge", uk, []float64{49.9, 1.8}, true},
+		{"box outside", uk, "48.8566,2.3522", false},
+		{"box outside latitude", uk, []float64{61, 0}, false},
+		{"box map missing longitude", uk, map[string]any{"lat": 51.5072}, false},
+		{"box three elements", uk, []float64{51.5, -0.1, 0}, false},
+		{"box string without comma", uk, "51.5072 -0.1276", false},
+		{"box invalid latitude", is.WithinBoundingBox(-100, -180, 100, 180), []float64{95, 0}, false},
+		{"box single number", uk, 51.5, false},
+
+		{"antimeridian east", fiji, []float64{-17.7, 178.1}, true},
+		{"antimeridian west", fiji, []float64{-16.5, -179.9}, true},
+		{"antimeridian outside", fiji, []float64{-17.7, 0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule(tt.value))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package is
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+// Hostname returns a validation function for host names as defined by RFC 1123: dot-separated
+// labels of 1 to 63 letters, digits, and hyphens that do not start or end with a hyphen, at
+// most 253 characters in total. A single label, such as "localhost", and a trailing dot are
+// accepted.
+//
+// Example usage:
+// Hostname()("db-1.internal") // returns true
+// Hostname()("localhost") // returns true
+// Hostname()("-db.internal") // returns false
+func Hostname() datacop.NamedRule {
+	return pure("hostname", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && isHostname(strings.TrimSuffix(str, "."))
+	})
+}
+
+// FQDN returns a validation function for fully qualified domain names: host names, as accepted
+// by Hostname, with at least two labels and a top-level domain that is not all digits, so IPv4
+// addresses are rejected. A trailing dot is accepted.
+//
+// Example usage:
+// FQDN()("api.example.com") // returns true
+// FQDN()("api.example.com.") // returns true
+// FQDN()("localhost") // returns false
+func FQDN() datacop.NamedRule {
+	return pure("fqdn", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && isDomain(strings.TrimSuffix(str, "."))
+	})
+}
+
+// Domain returns a validation function for domain names as users write them, such as
+// "example.com": a fully qualified domain name, as accepted by FQDN, without a trailing dot.
+// Use DomainWith to reject public suffixes such as "co.uk".
+//
+// Example usage:
+// Domain()("example.com") // returns true
+// Domain()("example.com.") // returns false
+func Domain() datacop.NamedRule {
+	return pure("domain", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		return ok && isDomain(str)
+	})
+}
+
+// PublicSuffixList reports the public suffix of a domain, such as "co.uk" for "example.co.uk".
+// The List of golang.org/x/net/publicsuffix implements it, as does any
+// net/http/cookiejar.PublicSuffixList.
+type PublicSuffixList interface {
+	PublicSuffix(domain string) string
+}
+
+// DomainWith is like Domain, but also rejects domains that are themselves public suffixes
+// according to list, such as "co.uk" or "github.io", so only domains someone can register, and
+// their subdomains, are accepted. Domains are compared in lower case.
+//
+// Example usage:
+// DomainWith(publicsuffix.List)("example.co.uk") // returns true
+// DomainWith(publicsuffix.List)("co.uk") // returns false
+func DomainWith(list PublicSuffixList) datacop.NamedRule {
+	return datacop.Named("domain_with", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if !isDomain(str) {
+			return false
+		}
+		domain := strings.ToLower(str)
+		return list.PublicSuffix(domain) != domain
+	})
+}
+
+// Port returns a validation function for TCP and UDP port numbers from 1 to 65535, given as an
+// integer, a whole float such as a number decoded from JSON, or a string of digits.
+//
+// Example usage:
+// Port()(8080) // returns true
+// Port()("443") // returns true
+// Port()(0) // returns false
+// Port()("80/tcp") // returns false
+func Port() datacop.NamedRule {
+	return pure("port", datacop.Params{}, func(value any) bool {
+		var n float64
+		if str, ok := value.(string); ok {
+			if str == "" || len(str) > 5 || !isDigits(str) {
+				return false
+			}
+			p, _ := strconv.Atoi(str)
+			n = float64(p)
+		} else if f, ok := numeric.Float(value); ok {
+			n = f
+		} else {
+			return false
+		}
+		return n == math.Trunc(n) && n >= 1 && n <= 65535
+	})
+}
+
+// isDomain reports whether str is a fully qualified domain name without a trailing dot
+func isDomain(str string) bool {
+	if !isHostname(str) {
+		return false
+	}
+	tld := str[strings.LastIndexByte(str, '.')+1:]
+	return len(tld) < len(str) && !isDigits(tld)
+}
+
+// isHostname reports whether str is an RFC 1123 host name without a trailing dot
+func isHostname(str string) bool {
+	if str == "" || len(str) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(str, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !isDigit(c) && c != '-' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package is_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// suffixes is a PublicSuffixList for tests with a few multi-label suffixes
+type suffixes []string
+
+func (s suffixes) PublicSuffix(domain string) string {
+	for _, suffix := range s {
+		if domain == suffix || strings.HasSuffix(domain, "."+suffix) {
+			return suffix
+		}
+	}
+	return domain[strings.LastIndex(domain, ".")+1:]
+}
+
+func TestHostFormats(t *testing.T) {
+	list := suffixes{"co.uk", "github.io"}
+	longLabel := strings.Repeat("a", 64)
+	longName := strings.Repeat(strings.Repeat("a", 63)+".", 4) + "com"
+
+	tests := []struct {
+		name  string
+		rule  datacop.NamedRule
+		value any
+		want  bool
+	}{
+		{"hostname", is.Hostname(), "db-1.internal", true},
+		{"hostname single label", is.Hostname(), "localhost", true},
+		{"hostname leading digit", is.Hostname(), "1password.com", true},
+		{"hostname trailing dot", is.Hostname(), "example.com.", true},
+		{"hostname upper case", is.Hostname(), "DB.Internal", true},
+		{"hostname leading hyphen", is.Hostname(), "-db.internal", false},
+		{"hostname trailing hyphen", is.Hostname(), "db-.internal", false},
+		{"hostname underscore", is.Hostname(), "db_1.internal", false},
+		{"hostname empty label", is.Hostname(), "db..internal", false},
+		{"hostname label too long", is.Hostname(), longLabel + ".com", false},
+		{"hostname too long", is.Hostname(), longName, false},
+		{"hostname empty", is.Hostname(), "", false},
+		{"hostname non-string", is.Hostname(), 1, false},
+
+		{"fqdn", is.FQDN(), "api.example.com", true},
+		{"fqdn trailing dot", is.FQDN(), "api.example.com.", true},
+		{"fqdn punycode", is.FQDN(), "xn--e1afmkfd.xn--p1ai", true},
+		{"fqdn single label", is.FQDN(), "localhost", false},
+		{"fqdn ip address", is.FQDN(), "192.168.0.1", false},
+
+		{"domain", is.Domain(), "example.com", true},
+		{"domain public suffix without list", is.Domain(), "co.uk", true},
+		{"domain trailing dot", is.Domain(), "example.com.", false},
+		{"domain with scheme", is.Domain(), "https://example.com", false},
+
+		{"domain with list", is.DomainWith(list), "example.co.uk", true},
+		{"domain with list subdomain", is.DomainWith(list), "docs.example.github.io", true},
+		{"domain with list public suffix", is.DomainWith(list), "co.uk", false},
+		{"domain with list upper case suffix", is.DomainWith(list), "GitHub.io", false},
+		{"domain with list invalid", is.DomainWith(list), "example", false},
+
+		{"port int", is.Port(), 8080, true},
+		{"port string", is.Port(), "443", true},
+		{"port uint16", is.Port(), uint16(65535), true},
+		{"port json number", is.Port(), 22.0, true},
+		{"port zero", is.Port(), 0, false},
+		{"port too high", is.Port(), 65536, false},
+		{"port too high string", is.Port(), "65536", false},
+		{"port fraction", is.Port(), 80.5, false},
+		{"port sign", is.Port(), "+80", false},
+		{"port protocol", is.Port(), "80/tcp", false},
+		{"port empty", is.Port(), "", false},
+		{"port nil", is.Port(), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule(tt.value))
+		})
+	}
+}
+
+func TestDomainWith_Name(t *testing.T) {
+	assert.Equal(t, "domain", is.Domain().Name())
+	assert.Equal(t, "domain_with", is.DomainWith(suffixes{"co.uk"}).Name())
+
+	v := datacop.New(datacop.WithIntraRequestMemo())
+	v.Field("a", "co.uk").Validate(is.DomainWith(suffixes{"github.io"}), "is not registrable")
+	v.Field("b", "co.uk").Validate(is.DomainWith(suffixes{"co.uk"}), "is not registrable")
+	assert.Equal(t, map[string]string{"b": "is not registrable"}, v.Errors(), "results are not shared across lists")
+}
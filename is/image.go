@@ -0,0 +1,89 @@
+package is
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"  // register GIF for image.DecodeConfig
+	_ "image/jpeg" // register JPEG for image.DecodeConfig
+	_ "image/png"  // register PNG for image.DecodeConfig
+	"io"
+	"math"
+	"mime/multipart"
+
+	"github.com/patrickward/datacop"
+)
+
+// ImageMaxDimensions returns a validation function that checks that an image is at most w pixels
+// wide and h pixels high. Only the image header is decoded, so large uploads are not read into
+// memory. A limit of 0 leaves that dimension unchecked.
+//
+// The value may be a *multipart.FileHeader, a multipart.File or other io.ReadSeeker, which is
+// rewound after the header is read so the upload can still be saved, an io.Reader, which is
+// consumed, or a []byte. PNG, JPEG, and GIF are recognized; register other formats, such as
+// golang.org/x/image/webp, by importing their packages. Values that are not images fail.
+//
+// Example usage:
+//
+//	file, header, _ := r.FormFile("avatar")
+//	v.Field("avatar", header).Validate(is.ImageMaxDimensions(1024, 1024), "must be at most 1024x1024 pixels")
+//	v.Field("avatar", file).Validate(is.ImageMaxDimensions(1024, 1024)) // file can still be copied afterwards
+func ImageMaxDimensions(w, h int) datacop.NamedRule {
+	return datacop.Named("image_max_dimensions", datacop.Params{"width": w, "height": h}, func(value any) bool {
+		config, ok := imageConfig(value)
+		if !ok {
+			return false
+		}
+		return (w <= 0 || config.Width <= w) && (h <= 0 || config.Height <= h)
+	})
+}
+
+// ImageAspectRatio returns a validation function that checks that the width of an image divided
+// by its height is within tolerance of ratio, such as 16.0/9 with a tolerance of 0.01 for a
+// banner. It accepts the same values as ImageMaxDimensions.
+//
+// Example usage:
+// v.Field("banner", header).Validate(is.ImageAspectRatio(3, 0.05), "must be three times as wide as it is high")
+// v.Field("avatar", header).Validate(is.ImageAspectRatio(1, 0), "must be square")
+func ImageAspectRatio(ratio float64, tolerance float64) datacop.NamedRule {
+	params := datacop.Params{"ratio": ratio, "tolerance": tolerance}
+	return datacop.Named("image_aspect_ratio", params, func(value any) bool {
+		config, ok := imageConfig(value)
+		if !ok || config.Height == 0 {
+			return false
+		}
+		return math.Abs(float64(config.Width)/float64(config.Height)-ratio) <= tolerance
+	})
+}
+
+// imageConfig decodes the header of an image held in value
+func imageConfig(value any) (image.Config, bool) {
+	var r io.Reader
+	switch v := value.(type) {
+	case []byte:
+		r = bytes.NewReader(v)
+	case *multipart.FileHeader:
+		if v == nil {
+			return image.Config{}, false
+		}
+		f, err := v.Open()
+		if err != nil {
+			return image.Config{}, false
+		}
+		defer f.Close()
+		r = f
+	case io.ReadSeeker:
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return image.Config{}, false
+		}
+		defer v.Seek(offset, io.SeekStart)
+		r = v
+	case io.Reader:
+		r = v
+	default:
+		return image.Config{}, false
+	}
+
+	config, _, err := image.DecodeConfig(r)
+	return config, err == nil
+}
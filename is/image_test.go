@@ -0,0 +1,115 @@
+package is_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+// fileHeader returns the header of data uploaded as a multipart form file
+func fileHeader(t *testing.T, data []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("upload", "image.png")
+	require.NoError(t, err)
+	_, err = fw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	form, err := multipart.NewReader(&body, mw.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["upload"][0]
+}
+
+func TestImageMaxDimensions(t *testing.T) {
+	small := pngBytes(t, 100, 50)
+	large := pngBytes(t, 300, 200)
+
+	tests := []struct {
+		name  string
+		w, h  int
+		value any
+		want  bool
+	}{
+		{"bytes within limits", 200, 200, small, true},
+		{"bytes too wide", 200, 200, large, false},
+		{"exact limits", 100, 50, small, true},
+		{"too high", 0, 40, small, false},
+		{"unchecked dimension", 0, 50, large, false},
+		{"unchecked width", 0, 200, large, true},
+		{"reader", 200, 200, io.MultiReader(bytes.NewReader(small)), true},
+		{"read seeker", 200, 200, bytes.NewReader(large), false},
+		{"file header", 200, 200, fileHeader(t, small), true},
+		{"large file header", 200, 200, fileHeader(t, large), false},
+		{"not an image", 200, 200, []byte("hello"), false},
+		{"nil file header", 200, 200, (*multipart.FileHeader)(nil), false},
+		{"unsupported type", 200, 200, "image.png", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.ImageMaxDimensions(tt.w, tt.h)(tt.value))
+		})
+	}
+}
+
+func TestImageMaxDimensions_Rewinds(t *testing.T) {
+	data := pngBytes(t, 10, 10)
+	r := bytes.NewReader(data)
+
+	assert.True(t, is.ImageMaxDimensions(10, 10)(r))
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, rest, "the reader is rewound so the upload can be saved")
+}
+
+func TestImageAspectRatio(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratio     float64
+		tolerance float64
+		value     any
+		want      bool
+	}{
+		{"square", 1, 0, pngBytes(t, 64, 64), true},
+		{"not square", 1, 0, pngBytes(t, 64, 63), false},
+		{"within tolerance", 1, 0.02, pngBytes(t, 64, 63), true},
+		{"banner", 16.0 / 9, 0.01, pngBytes(t, 1600, 900), true},
+		{"portrait banner", 16.0 / 9, 0.01, pngBytes(t, 900, 1600), false},
+		{"file header", 3, 0.05, fileHeader(t, pngBytes(t, 300, 100)), true},
+		{"not an image", 1, 0.5, strings.NewReader("GIF89a"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.ImageAspectRatio(tt.ratio, tt.tolerance)(tt.value))
+		})
+	}
+}
+
+func TestImage_RuleInfo(t *testing.T) {
+	name, params, _ := datacop.RuleInfo(is.ImageMaxDimensions(1024, 768))
+	assert.Equal(t, "image_max_dimensions", name)
+	assert.Equal(t, datacop.Params{"width": 1024, "height": 768}, params)
+
+	name, params, _ = datacop.RuleInfo(is.ImageAspectRatio(1.5, 0.1))
+	assert.Equal(t, "image_aspect_ratio", name)
+	assert.Equal(t, datacop.Params{"ratio": 1.5, "tolerance": 0.1}, params)
+}
@@ -0,0 +1,66 @@
+package is
+
+import (
+	"context"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// Moderator classifies user-generated text, typically by calling a content moderation service.
+// Adapters for specific services live outside this package.
+type Moderator interface {
+	Moderate(ctx context.Context, text string) (ModerationResult, error)
+}
+
+// ModerationResult is the verdict of a Moderator
+type ModerationResult struct {
+	// Flagged reports whether the text should be rejected
+	Flagged bool
+	// Categories lists the categories the text was flagged for, such as "harassment"
+	Categories []string
+	// Scores optionally holds the service's confidence for each category, from 0 to 1
+	Scores map[string]float64
+}
+
+// PassesModeration returns a validation function that checks text with m, using ctx for the
+// request. Text the moderator flags fails. Blank text passes without calling the moderator.
+//
+// When it runs in Validate, each flagged category is recorded as a datacop.AnnotationModeration
+// annotation whose message is the category and whose data holds its "category" and, if the
+// moderator scored it, its "score". Errors from the moderator fail the check and are recorded as
+// a datacop.AnnotationWarning annotation; wrap the moderator to let text through on errors
+// instead.
+//
+// Example usage:
+//
+//	v.Field("comment", comment).Validate(is.PassesModeration(r.Context(), moderator), "violates our community guidelines")
+//	for _, a := range v.AnnotationsOfKind(datacop.AnnotationModeration) {
+//		metrics.Flagged(a.Message)
+//	}
+func PassesModeration(ctx context.Context, m Moderator) datacop.NamedRule {
+	return datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		if strings.TrimSpace(str) == "" {
+			return true
+		}
+
+		result, err := m.Moderate(ctx, str)
+		if err != nil {
+			annotate(datacop.AnnotationWarning, "moderation failed", map[string]any{"error": err.Error()})
+			return false
+		}
+
+		for _, category := range result.Categories {
+			data := map[string]any{"category": category}
+			if score, ok := result.Scores[category]; ok {
+				data["score"] = score
+			}
+			annotate(datacop.AnnotationModeration, category, data)
+		}
+		return !result.Flagged
+	}).Named("moderation", nil)
+}
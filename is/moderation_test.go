@@ -0,0 +1,77 @@
+package is_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// keywordModerator flags text containing "spam" and scores text containing "darn"
+type keywordModerator struct {
+	calls int
+	err   error
+}
+
+func (m *keywordModerator) Moderate(_ context.Context, text string) (is.ModerationResult, error) {
+	m.calls++
+	if m.err != nil {
+		return is.ModerationResult{}, m.err
+	}
+	var result is.ModerationResult
+	if strings.Contains(text, "spam") {
+		result.Flagged = true
+		result.Categories = append(result.Categories, "spam")
+	}
+	if strings.Contains(text, "darn") {
+		result.Categories = append(result.Categories, "profanity")
+		result.Scores = map[string]float64{"profanity": 0.3}
+	}
+	return result, nil
+}
+
+func TestPassesModeration(t *testing.T) {
+	m := &keywordModerator{}
+	check := is.PassesModeration(context.Background(), m)
+
+	assert.True(t, check("a friendly comment"))
+	assert.False(t, check("buy spam now"))
+	assert.True(t, check("   "))
+	assert.False(t, check(42))
+	assert.Equal(t, 2, m.calls, "blank and non-string values are not sent to the moderator")
+
+	m.err = errors.New("service unavailable")
+	assert.False(t, check("a friendly comment"))
+}
+
+func TestPassesModeration_Annotations(t *testing.T) {
+	m := &keywordModerator{}
+	v := datacop.New()
+
+	v.Field("comment", "darn spam").Validate(is.PassesModeration(context.Background(), m))
+	v.Field("bio", "darn").Validate(is.PassesModeration(context.Background(), m))
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "comment", errs[0].Field)
+	assert.Equal(t, "moderation", errs[0].Code)
+	assert.Equal(t, "contains content that is not allowed", errs[0].Message)
+
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "comment", Kind: datacop.AnnotationModeration, Message: "spam", Data: map[string]any{"category": "spam"}},
+		{Field: "comment", Kind: datacop.AnnotationModeration, Message: "profanity", Data: map[string]any{"category": "profanity", "score": 0.3}},
+		{Field: "bio", Kind: datacop.AnnotationModeration, Message: "profanity", Data: map[string]any{"category": "profanity", "score": 0.3}},
+	}, v.Annotations())
+
+	m.err = errors.New("service unavailable")
+	v = datacop.New()
+	v.Field("comment", "hello").Validate(is.PassesModeration(context.Background(), m), "could not be checked")
+	assert.Equal(t, "could not be checked", v.ErrorFor("comment"))
+	assert.Equal(t, "moderation failed", v.AnnotationsOfKind(datacop.AnnotationWarning)[0].Message)
+}
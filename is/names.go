@@ -0,0 +1,41 @@
+package is
+
+import "github.com/patrickward/datacop"
+
+// The predicates of this package are plain functions, so they can be called directly. Their rule
+// names are registered here, so Validate and RuleSet.Apply record them as error codes and use
+// their default messages.
+func init() {
+	datacop.RegisterRule("required", nil, Required)
+	datacop.RegisterRule("accepted", nil, Accepted)
+	datacop.RegisterRule("email", nil, Email)
+	datacop.RegisterRule("phone", nil, Phone)
+	datacop.RegisterRule("password", nil, Password)
+	datacop.RegisterRule("username", nil, Username)
+	datacop.RegisterRule("int_string", nil, IntString)
+	datacop.RegisterRule("float_string", nil, FloatString)
+	datacop.RegisterRule("alpha", nil, Alpha)
+	datacop.RegisterRule("alpha_unicode", nil, AlphaUnicode)
+	datacop.RegisterRule("alphanumeric", nil, Alphanumeric)
+	datacop.RegisterRule("alphanumeric_unicode", nil, AlphanumericUnicode)
+	datacop.RegisterRule("numeric", nil, Numeric)
+	datacop.RegisterRule("numeric_unicode", nil, NumericUnicode)
+	datacop.RegisterRule("ascii", nil, ASCII)
+	datacop.RegisterRule("printable_ascii", nil, PrintableASCII)
+	datacop.RegisterRule("slug", nil, Slug)
+	datacop.RegisterRule("slug_unicode", nil, SlugUnicode)
+	datacop.RegisterRule("no_bidi_control", nil, NoBidiControl)
+	datacop.RegisterRule("no_leading_trailing_space", nil, NoLeadingTrailingSpace)
+	datacop.RegisterRule("single_line", nil, SingleLine)
+	datacop.RegisterRule("no_control_chars", nil, NoControlChars)
+	datacop.RegisterRule("totp_code", nil, TOTPCode)
+	datacop.RegisterRule("backup_code_format", nil, BackupCodeFormat)
+	datacop.RegisterRule("timezone", nil, Timezone)
+	datacop.RegisterRule("uuid", nil, UUID)
+}
+
+// pure names a rule whose result depends only on its params and the value, so validators
+// created with datacop.WithIntraRequestMemo can reuse its results wherever it appears
+func pure(name string, params datacop.Params, fn datacop.ValidationFunc) datacop.NamedRule {
+	return datacop.Named(name, params, fn).Memoized()
+}
@@ -0,0 +1,76 @@
+package is
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+// IntString checks if a value is a string holding a base-10 integer, such as "42" or "-7".
+// Surrounding whitespace is ignored.
+//
+// Example usage:
+// IntString("42") // returns true
+// IntString("4.2") // returns false
+func IntString(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+	return err == nil
+}
+
+// FloatString checks if a value is a string holding a finite decimal number, such as "4.2" or "1e3".
+// Surrounding whitespace is ignored.
+//
+// Example usage:
+// FloatString("4.2") // returns true
+// FloatString("NaN") // returns false
+func FloatString(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	_, ok = numeric.Float(str)
+	return ok
+}
+
+// MinNumeric checks if a number is at least min. Unlike Min, it accepts any integer or float
+// type as well as numeric strings, and compares them after converting to float64.
+//
+// Example usage:
+// MinNumeric(5)(int64(7)) // returns true
+// MinNumeric(5)("3.5") // returns false
+func MinNumeric(min float64) datacop.NamedRule {
+	return pure("min_numeric", datacop.Params{"min": min}, func(value any) bool {
+		n, ok := numeric.Float(value)
+		return ok && n >= min
+	})
+}
+
+// MaxNumeric checks if a number is at most max, accepting the same values as MinNumeric
+//
+// Example usage:
+// MaxNumeric(10)(uint8(7)) // returns true
+// MaxNumeric(10)("12") // returns false
+func MaxNumeric(max float64) datacop.NamedRule {
+	return pure("max_numeric", datacop.Params{"max": max}, func(value any) bool {
+		n, ok := numeric.Float(value)
+		return ok && n <= max
+	})
+}
+
+// BetweenNumeric checks if a number is between min and max inclusive, accepting the same values
+// as MinNumeric
+//
+// Example usage:
+// BetweenNumeric(1, 65535)(int64(8080)) // returns true
+func BetweenNumeric(min, max float64) datacop.NamedRule {
+	return pure("between_numeric", datacop.Params{"min": min, "max": max}, func(value any) bool {
+		n, ok := numeric.Float(value)
+		return ok && n >= min && n <= max
+	})
+}
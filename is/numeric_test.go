@@ -0,0 +1,87 @@
+package is_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestIntString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"positive integer", "42", true},
+		{"negative integer", "-7", true},
+		{"surrounding whitespace", " 12 ", true},
+		{"decimal", "4.2", false},
+		{"empty", "", false},
+		{"letters", "12a", false},
+		{"integer type", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.IntString(tt.value))
+		})
+	}
+}
+
+func TestFloatString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"decimal", "4.2", true},
+		{"integer", "42", true},
+		{"exponent", "1e3", true},
+		{"negative", "-0.5", true},
+		{"NaN", "NaN", false},
+		{"infinity", "Inf", false},
+		{"letters", "four", false},
+		{"float type", 4.2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.FloatString(tt.value))
+		})
+	}
+}
+
+func TestMinMaxNumeric(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantMin bool
+		wantMax bool
+	}{
+		{"int", 7, true, true},
+		{"int64", int64(7), true, true},
+		{"uint8", uint8(7), true, true},
+		{"float32", float32(7.5), true, true},
+		{"json number", json.Number("7"), true, true},
+		{"numeric string", "7", true, true},
+		{"at min", 5, true, true},
+		{"at max", int64(10), true, true},
+		{"below min", int32(4), false, true},
+		{"above max", "10.5", true, false},
+		{"NaN", math.NaN(), false, false},
+		{"non-numeric string", "seven", false, false},
+		{"nil", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMin, is.MinNumeric(5)(tt.value), "MinNumeric")
+			assert.Equal(t, tt.wantMax, is.MaxNumeric(10)(tt.value), "MaxNumeric")
+			assert.Equal(t, tt.wantMin && tt.wantMax, is.BetweenNumeric(5, 10)(tt.value), "BetweenNumeric")
+		})
+	}
+}
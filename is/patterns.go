@@ -1,19 +1,116 @@
 package is
 
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/patrickward/datacop"
+)
+
+// PasswordPolicy describes the requirements checked by StrongPassword
+type PasswordPolicy struct {
+	MinLength        int      // minimum number of characters; 0 means no minimum
+	MaxLength        int      // maximum number of characters; 0 means no maximum
+	RequireUpper     bool     // at least one uppercase letter
+	RequireLower     bool     // at least one lowercase letter
+	RequireDigit     bool     // at least one digit
+	RequireSymbol    bool     // at least one character that is not a letter, digit, or space
+	BannedSubstrings []string // case-insensitive substrings that may not appear, e.g. "password" or the product name
+}
+
+// params describes the policy as the params of the strong_password rule, with the normalized
+// banned substrings
+func (p PasswordPolicy) params(banned []string) datacop.Params {
+	return datacop.Params{
+		"min_length":     p.MinLength,
+		"max_length":     p.MaxLength,
+		"require_upper":  p.RequireUpper,
+		"require_lower":  p.RequireLower,
+		"require_digit":  p.RequireDigit,
+		"require_symbol": p.RequireSymbol,
+		"banned":         banned,
+	}
+}
+
+// DefaultPasswordPolicy is the policy used by Password
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:    8,
+	RequireUpper: true,
+	RequireLower: true,
+	RequireDigit: true,
+}
+
+// StrongPassword returns a validation function that checks a password against policy.
+// Lengths are counted in characters, ignoring leading and trailing whitespace.
+//
+// Example usage:
+//
+//	policy := is.PasswordPolicy{
+//		MinLength:        12,
+//		MaxLength:        128,
+//		RequireUpper:     true,
+//		RequireDigit:     true,
+//		RequireSymbol:    true,
+//		BannedSubstrings: []string{"password", "acme"},
+//	}
+//	StrongPassword(policy)("Tr0ub4dor&3x!") // returns true
+//	StrongPassword(policy)("AcmeRocks123!") // returns false
+func StrongPassword(policy PasswordPolicy) datacop.NamedRule {
+	banned := make([]string, 0, len(policy.BannedSubstrings))
+	for _, b := range policy.BannedSubstrings {
+		if b = strings.ToLower(strings.TrimSpace(b)); b != "" {
+			banned = append(banned, b)
+		}
+	}
+
+	return pure("strong_password", policy.params(banned), func(value any) bool {
+		str, ok := value.(string)
+		if !ok || !Required(str) {
+			return false
+		}
+
+		length := utf8.RuneCountInString(strings.TrimSpace(str))
+		if length < policy.MinLength || (policy.MaxLength > 0 && length > policy.MaxLength) {
+			return false
+		}
+
+		var hasUpper, hasLower, hasDigit, hasSymbol bool
+		for _, r := range str {
+			switch {
+			case unicode.IsUpper(r):
+				hasUpper = true
+			case unicode.IsLower(r):
+				hasLower = true
+			case unicode.IsDigit(r):
+				hasDigit = true
+			case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+				hasSymbol = true
+			}
+		}
+		if (policy.RequireUpper && !hasUpper) ||
+			(policy.RequireLower && !hasLower) ||
+			(policy.RequireDigit && !hasDigit) ||
+			(policy.RequireSymbol && !hasSymbol) {
+			return false
+		}
+
+		lower := strings.ToLower(str)
+		for _, b := range banned {
+			if strings.Contains(lower, b) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 // Password returns common password validation rules.
 // This is an example of a function that could be used in a project's own validation library,
-// combining common validation rules into a single function.
+// combining common validation rules into a single function. It checks DefaultPasswordPolicy;
+// use StrongPassword for a configurable policy.
 func Password(value any) bool {
-	str, ok := value.(string)
-	if !ok {
-		return false
-	}
-
-	return Required(str) &&
-		MinLength(8)(str) &&
-		Match(`[A-Z]`)(str) &&
-		Match(`[a-z]`)(str) &&
-		Match(`[0-9]`)(str)
+	return StrongPassword(DefaultPasswordPolicy)(value)
 }
 
 // Username returns common username validation rules.
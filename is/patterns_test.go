@@ -29,6 +29,45 @@ func TestPassword(t *testing.T) {
 	}
 }
 
+func TestStrongPassword(t *testing.T) {
+	policy := is.PasswordPolicy{
+		MinLength:        10,
+		MaxLength:        20,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BannedSubstrings: []string{"password", " Acme "},
+	}
+
+	tests := []struct {
+		name   string
+		policy is.PasswordPolicy
+		value  any
+		want   bool
+	}{
+		{"meets policy", policy, "Tr0ub4dor&3", true},
+		{"unicode letters", policy, "Ünïcødé-P4ss", true},
+		{"too short", policy, "Tr0ub4&x", false},
+		{"too long", policy, "Tr0ub4dor&3-and-then-some", false},
+		{"missing symbol", policy, "Tr0ub4dor33", false},
+		{"missing digit", policy, "Troubador&x", false},
+		{"missing uppercase", policy, "tr0ub4dor&3", false},
+		{"missing lowercase", policy, "TR0UB4DOR&3", false},
+		{"banned substring", policy, "MyPassword&1", false},
+		{"banned substring is trimmed and case-insensitive", policy, "ACMErocks&12", false},
+		{"blank", policy, "          ", false},
+		{"non-string value", policy, 1234567890, false},
+		{"empty policy accepts any non-blank value", is.PasswordPolicy{}, "x", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.StrongPassword(tt.policy)(tt.value))
+		})
+	}
+}
+
 func TestUsername(t *testing.T) {
 	tests := []struct {
 		name  string
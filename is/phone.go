@@ -0,0 +1,177 @@
+package is
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+var (
+	rgxE164         = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	rgxPhoneExt     = regexp.MustCompile(`(?i)\s*(?:ext\.?|extension|x|#)\s*([0-9]{1,6})$`)
+	rgxPhoneAllowed = regexp.MustCompile(`^\+?[0-9 ().\-/]+$`)
+	phoneSeparators = strings.NewReplacer(" ", "", "(", "", ")", "", ".", "", "-", "", "/", "")
+)
+
+// phoneRegion describes the numbering plan of a region
+type phoneRegion struct {
+	callingCode string
+	trunkPrefix string // dialled before national numbers inside the region
+	minLength   int    // national significant number length range
+	maxLength   int
+}
+
+var phoneRegions = map[string]phoneRegion{
+	"AU": {"61", "0", 9, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CN": {"86", "0", 10, 11},
+	"DE": {"49", "0", 6, 13},
+	"ES": {"34", "", 9, 9},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"IE": {"353", "0", 7, 9},
+	"IN": {"91", "0", 10, 10},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"MX": {"52", "", 10, 10},
+	"NL": {"31", "0", 9, 9},
+	"NZ": {"64", "0", 8, 10},
+	"US": {"1", "1", 10, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+type phoneConfig struct {
+	e164       bool
+	extensions bool
+	regions    []phoneRegion
+	codes      []string // the regions' ISO 3166-1 codes, for the rule's params
+}
+
+// params describes the configuration as the params of the phone_number rule
+func (c *phoneConfig) params() datacop.Params {
+	return datacop.Params{"e164": c.e164, "extensions": c.extensions, "regions": c.codes}
+}
+
+// PhoneOption configures PhoneNumber
+type PhoneOption func(*phoneConfig)
+
+// PhoneE164 requires numbers to be written in strict E.164 format: a plus sign followed by
+// 8 to 15 digits with no spaces, punctuation, or extension, e.g. "+14155552671".
+func PhoneE164() PhoneOption {
+	return func(c *phoneConfig) {
+		c.e164 = true
+	}
+}
+
+// PhoneAllowExtension accepts a trailing extension such as "ext. 123", "x123", or "#123"
+func PhoneAllowExtension() PhoneOption {
+	return func(c *phoneConfig) {
+		c.extensions = true
+	}
+}
+
+// PhoneForRegion restricts numbers to the given regions, identified by ISO 3166-1 alpha-2 codes.
+// International numbers must carry one of the regions' calling codes, and national numbers
+// (written without a leading plus sign) are accepted and checked against the regions'
+// numbering plans. Using an unsupported region panics.
+//
+// Supported regions: AU, BR, CA, CN, DE, ES, FR, GB, IE, IN, IT, JP, MX, NL, NZ, US, ZA.
+func PhoneForRegion(regions ...string) PhoneOption {
+	resolved := make([]phoneRegion, 0, len(regions))
+	codes := make([]string, 0, len(regions))
+	for _, code := range regions {
+		region, ok := phoneRegions[strings.ToUpper(code)]
+		if !ok {
+			supported := slices.Sorted(maps.Keys(phoneRegions))
+			panic(fmt.Sprintf("is: unsupported phone region %q (supported: %s)", code, strings.Join(supported, ", ")))
+		}
+		resolved = append(resolved, region)
+		codes = append(codes, strings.ToUpper(code))
+	}
+
+	return func(c *phoneConfig) {
+		c.regions = append(c.regions, resolved...)
+		c.codes = append(c.codes, codes...)
+	}
+}
+
+// PhoneNumber returns a validation function for international phone numbers. By default it
+// accepts numbers in international format, a plus sign followed by a country calling code and
+// number, with optional spaces, dots, dashes, slashes, and parentheses, e.g. "+44 20 7946 0958".
+// The total number of digits must be between 8 and 15, as E.164 requires.
+//
+// Options enforce strict E.164 formatting, restrict numbers to specific regions (which also
+// enables national formats), and allow extensions.
+//
+// Example usage:
+// PhoneNumber()("+44 20 7946 0958") // returns true
+// PhoneNumber()("020 7946 0958") // returns false
+// PhoneNumber(PhoneForRegion("GB"))("020 7946 0958") // returns true
+// PhoneNumber(PhoneForRegion("US"))("+44 20 7946 0958") // returns false
+// PhoneNumber(PhoneE164())("+442079460958") // returns true
+// PhoneNumber(PhoneAllowExtension())("+1 415-555-2671 ext. 12") // returns true
+func PhoneNumber(opts ...PhoneOption) datacop.NamedRule {
+	cfg := &phoneConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return pure("phone_number", cfg.params(), func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+
+		if cfg.extensions && !cfg.e164 {
+			str = rgxPhoneExt.ReplaceAllString(str, "")
+		}
+
+		if cfg.e164 {
+			if !rgxE164.MatchString(str) {
+				return false
+			}
+		} else if !rgxPhoneAllowed.MatchString(str) {
+			return false
+		}
+
+		digits := phoneSeparators.Replace(str)
+		international := strings.HasPrefix(digits, "+")
+		digits = strings.TrimPrefix(digits, "+")
+
+		if len(cfg.regions) == 0 {
+			return international && len(digits) >= 8 && len(digits) <= 15 && digits[0] != '0'
+		}
+
+		for _, region := range cfg.regions {
+			if region.matches(digits, international) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// matches reports whether digits form a valid number for the region
+func (r phoneRegion) matches(digits string, international bool) bool {
+	national := digits
+	if international {
+		var ok bool
+		if national, ok = strings.CutPrefix(digits, r.callingCode); !ok {
+			return false
+		}
+	} else if r.trunkPrefix != "" {
+		national = strings.TrimPrefix(digits, r.trunkPrefix)
+	}
+
+	// In regions with a trunk prefix, national significant numbers never start with zero
+	if national == "" || (r.trunkPrefix != "" && national[0] == '0') {
+		return false
+	}
+	return len(national) >= r.minLength && len(national) <= r.maxLength
+}
@@ -0,0 +1,61 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestPhoneNumber(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []is.PhoneOption
+		value any
+		want  bool
+	}{
+		{"international with spaces", nil, "+44 20 7946 0958", true},
+		{"international with punctuation", nil, "+1 (415) 555-2671", true},
+		{"international compact", nil, "+919876543210", true},
+		{"national without region", nil, "020 7946 0958", false},
+		{"nanp without region", nil, "415-555-2671", false},
+		{"too short", nil, "+1 555", false},
+		{"too long", nil, "+1234567890123456", false},
+		{"letters", nil, "+1 415 CALL NOW", false},
+		{"extension not allowed", nil, "+1 415 555 2671 x12", false},
+		{"non-string value", nil, 14155552671, false},
+
+		{"e164", []is.PhoneOption{is.PhoneE164()}, "+442079460958", true},
+		{"e164 with spaces", []is.PhoneOption{is.PhoneE164()}, "+44 20 7946 0958", false},
+		{"e164 without plus", []is.PhoneOption{is.PhoneE164()}, "442079460958", false},
+		{"e164 ignores extension option", []is.PhoneOption{is.PhoneE164(), is.PhoneAllowExtension()}, "+442079460958 x1", false},
+
+		{"extension ext.", []is.PhoneOption{is.PhoneAllowExtension()}, "+1 415-555-2671 ext. 12", true},
+		{"extension x", []is.PhoneOption{is.PhoneAllowExtension()}, "+1 415-555-2671x12", true},
+		{"extension hash", []is.PhoneOption{is.PhoneAllowExtension()}, "+1 415-555-2671 #12", true},
+		{"extension too long", []is.PhoneOption{is.PhoneAllowExtension()}, "+1 415-555-2671 x1234567", false},
+
+		{"region international", []is.PhoneOption{is.PhoneForRegion("US")}, "+1 415 555 2671", true},
+		{"region national", []is.PhoneOption{is.PhoneForRegion("US")}, "(415) 555-2671", true},
+		{"region national with trunk prefix", []is.PhoneOption{is.PhoneForRegion("US")}, "1-415-555-2671", true},
+		{"region wrong country", []is.PhoneOption{is.PhoneForRegion("US")}, "+44 20 7946 0958", false},
+		{"region wrong length", []is.PhoneOption{is.PhoneForRegion("US")}, "+1 415 555 267", false},
+		{"gb national with trunk prefix", []is.PhoneOption{is.PhoneForRegion("gb")}, "020 7946 0958", true},
+		{"gb international", []is.PhoneOption{is.PhoneForRegion("GB")}, "+44 20 7946 0958", true},
+		{"gb international with trunk prefix", []is.PhoneOption{is.PhoneForRegion("GB")}, "+44 020 7946 0958", false},
+		{"gb nine digit number", []is.PhoneOption{is.PhoneForRegion("GB")}, "016977 2345", true},
+		{"it landline keeps leading zero", []is.PhoneOption{is.PhoneForRegion("IT")}, "+39 06 6982 1234", true},
+		{"multiple regions", []is.PhoneOption{is.PhoneForRegion("US", "GB")}, "+44 20 7946 0958", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.PhoneNumber(tt.opts...)(tt.value))
+		})
+	}
+}
+
+func TestPhoneForRegion_Unsupported(t *testing.T) {
+	assert.Panics(t, func() { is.PhoneForRegion("XX") })
+}
@@ -0,0 +1,87 @@
+package is
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/patrickward/datacop"
+)
+
+// MaxReadingLevel returns a validation function that checks that the Flesch-Kincaid grade level
+// of a string, as computed by ReadingLevel, is at most grade. Blank strings pass.
+//
+// Example usage:
+// MaxReadingLevel(8)("The cat sat on the mat. It was warm.") // returns true
+// MaxReadingLevel(8)("Notwithstanding the aforementioned considerations, applicants must substantiate eligibility.") // returns false
+func MaxReadingLevel(grade float64) datacop.NamedRule {
+	return pure("max_reading_level", datacop.Params{"grade": grade}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return ReadingLevel(str) <= grade
+	})
+}
+
+// ReadingLevel returns the Flesch-Kincaid grade level of English text, roughly the number of
+// years of schooling needed to understand it. Syllables are estimated from groups of vowels, so
+// the result is an approximation. Text without words has a level of 0.
+//
+// Example usage:
+// ReadingLevel("The cat sat on the mat.") // returns about -1.4
+func ReadingLevel(text string) float64 {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	if len(words) == 0 {
+		return 0
+	}
+
+	syllables := 0
+	for _, w := range words {
+		syllables += countSyllables(w)
+	}
+
+	return 0.39*float64(len(words))/float64(countSentences(text)) +
+		11.8*float64(syllables)/float64(len(words)) - 15.59
+}
+
+// countSentences counts runs of sentence-ending punctuation, treating text without any as one
+// sentence
+func countSentences(text string) int {
+	sentences, inRun := 0, false
+	lastWord := false
+	for _, r := range text {
+		switch {
+		case r == '.' || r == '!' || r == '?':
+			if !inRun && lastWord {
+				sentences++
+			}
+			inRun = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			inRun, lastWord = false, true
+		}
+	}
+	if !inRun && lastWord {
+		sentences++ // a final sentence without punctuation
+	}
+	return max(sentences, 1)
+}
+
+// countSyllables estimates the syllables of an English word by counting groups of vowels,
+// ignoring a silent final "e"
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count, prevVowel := 0, false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") {
+		count--
+	}
+	return max(count, 1)
+}
@@ -0,0 +1,63 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+const (
+	plainText   = "The cat sat on the mat. It was warm. We went home."
+	complexText = "Notwithstanding the aforementioned considerations, applicants must substantiate eligibility through comprehensive documentation."
+)
+
+func TestMaxReadingLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		grade float64
+		value any
+		want  bool
+	}{
+		{"plain text", 6, plainText, true},
+		{"complex text", 12, complexText, false},
+		{"complex text with a high limit", 40, complexText, true},
+		{"blank", 6, "  ", true},
+		{"non-string", 6, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.MaxReadingLevel(tt.grade)(tt.value))
+		})
+	}
+
+	name, params, _ := datacop.RuleInfo(is.MaxReadingLevel(8))
+	assert.Equal(t, "max_reading_level", name)
+	assert.Equal(t, datacop.Params{"grade": 8.0}, params)
+}
+
+func TestReadingLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want float64
+	}{
+		// 6 words, 1 sentence, 6 syllables: 0.39*6 + 11.8*1 - 15.59
+		{"one sentence", "The cat sat on the mat.", -1.45},
+		// 4 words, 3 sentences, 5 syllables (the estimate counts 2 for "really")
+		{"repeated punctuation", "Wait... what?! Really now.", 0.39*4/3 + 11.8*5/4 - 15.59},
+		// 2 syllables each for "table" and "little", 1 for "make"
+		{"silent e", "Make a little table", 0.39*4 + 11.8*6/4 - 15.59},
+		{"no punctuation", "Hello", 0.39 + 11.8*2 - 15.59},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, is.ReadingLevel(tt.text), 0.001)
+		})
+	}
+}
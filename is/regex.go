@@ -1,14 +1,34 @@
 package is
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/patrickward/datacop"
+)
+
+// EmailPattern is the regular expression Email matches addresses against. Its syntax is shared
+// by Go and JavaScript, so client-side code can use it to check addresses the same way.
+const EmailPattern = rgxEmail
 
 const (
 	rgxEmail = "^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$"
 	rgxPhone = `^\(?([0-9]{3})\)?[-.\s]?([0-9]{3})[-.\s]?([0-9]{4})$`
 )
 
-// Email is a very simple email validation function. For a more comprehensive
-// email validation, consider using a package like github.com/patrickward/mailcop.
+var (
+	emailRegex = regexp.MustCompile(rgxEmail)
+	phoneRegex = regexp.MustCompile(rgxPhone)
+)
+
+var (
+	patternsMu sync.RWMutex
+	patterns   = make(map[string]*regexp.Regexp)
+)
+
+// Email is a very simple email validation function. Use EmailWith for MX lookups and
+// domain allow, deny, and disposable-provider lists.
 //
 // Example usage:
 // Email("foo@example.com") // returns true
@@ -18,15 +38,52 @@ func Email(value any) bool {
 	if !ok {
 		return false
 	}
-	return regexp.MustCompile(rgxEmail).MatchString(str)
+	return emailRegex.MatchString(str)
 }
 
 // Phone is a simple phone number validation function. It expects a string
-// with a format of 123-456-7890.
+// with a format of 123-456-7890. Use PhoneNumber for international numbers.
 func Phone(value any) bool {
 	str, ok := value.(string)
 	if !ok {
 		return false
 	}
-	return regexp.MustCompile(rgxPhone).MatchString(str)
+	return phoneRegex.MatchString(str)
+}
+
+// RegisterPattern registers a compiled regular expression under name so that it can be
+// referenced with Pattern anywhere in an application. Registering a name again replaces
+// the previous pattern. It is safe for concurrent use, but patterns are typically
+// registered once from an init function.
+//
+// Example usage:
+// is.RegisterPattern("sku", regexp.MustCompile(`^[A-Z]{3}-[0-9]{4}$`))
+func RegisterPattern(name string, re *regexp.Regexp) {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	patterns[name] = re
+}
+
+// Pattern returns a validation function that checks if a string matches the pattern
+// registered under name. Using a name that has not been registered is a programming
+// error and panics when the validation runs.
+//
+// Example usage:
+// Pattern("sku")("ABC-1234") // returns true
+// Pattern("sku")("abc-12") // returns false
+func Pattern(name string) datacop.NamedRule {
+	return datacop.Named("pattern", datacop.Params{"name": name}, func(value any) bool {
+		patternsMu.RLock()
+		re, ok := patterns[name]
+		patternsMu.RUnlock()
+		if !ok {
+			panic(fmt.Sprintf("is: pattern %q is not registered", name))
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return re.MatchString(str)
+	})
 }
@@ -1,6 +1,7 @@
 package is_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,43 @@ func TestPhone(t *testing.T) {
 		})
 	}
 }
+
+func TestPattern(t *testing.T) {
+	is.RegisterPattern("sku", regexp.MustCompile(`^[A-Z]{3}-[0-9]{4}$`))
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"matching value", "ABC-1234", true},
+		{"non-matching value", "abc-12", false},
+		{"non-string value", 1234, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.Pattern("sku")(tt.value))
+		})
+	}
+}
+
+func TestPattern_Replace(t *testing.T) {
+	is.RegisterPattern("code", regexp.MustCompile(`^a+$`))
+	assert.True(t, is.Pattern("code")("aaa"))
+
+	is.RegisterPattern("code", regexp.MustCompile(`^b+$`))
+	assert.False(t, is.Pattern("code")("aaa"))
+	assert.True(t, is.Pattern("code")("bbb"))
+}
+
+func TestPattern_Unregistered(t *testing.T) {
+	fn := is.Pattern("does-not-exist")
+	assert.Panics(t, func() { fn("value") })
+}
+
+func BenchmarkEmail(b *testing.B) {
+	for range b.N {
+		is.Email("foo@example.com")
+	}
+}
@@ -0,0 +1,95 @@
+package is
+
+import (
+	"reflect"
+
+	"github.com/patrickward/datacop"
+)
+
+// RequiredIf returns a validation function that checks that a value is present, as Required
+// does, when other equals value, such as a company name when the account type is "business".
+// Otherwise any value passes. Values of named types are equal to values of other types with the
+// same kind, so a typed string enum matches a string literal, but an int64 does not match an int.
+// Values that cannot be compared, such as slices, are never equal.
+//
+// Like the other dependent rules, its params record whether the value was "required".
+//
+// Example usage:
+// v.Field("company", company).Validate(is.RequiredIf(accountType, "business"), "is required for business accounts")
+// RequiredIf("business", "business")("") // returns false
+// RequiredIf("personal", "business")("") // returns true
+func RequiredIf(other, value any) datacop.NamedRule {
+	required := equal(other, value)
+	return datacop.Named("required_if", datacop.Params{"value": value, "required": required}, func(v any) bool {
+		return !required || Required(v)
+	})
+}
+
+// RequiredUnless returns a validation function that checks that a value is present unless other
+// equals value, the inverse of RequiredIf
+//
+// Example usage:
+// v.Field("tax_id", taxID).Validate(is.RequiredUnless(country, "US"), "is required outside the US")
+func RequiredUnless(other, value any) datacop.NamedRule {
+	required := !equal(other, value)
+	return datacop.Named("required_unless", datacop.Params{"value": value, "required": required}, func(v any) bool {
+		return !required || Required(v)
+	})
+}
+
+// RequiredWith returns a validation function that checks that a value is present when other,
+// the value of the field named field, is present, as judged by Required. The field name is
+// available to messages as {other}.
+//
+// Example usage:
+// v.Field("shipping_method", method).Validate(is.RequiredWith("shipping_address", addr)) // "is required when shipping_address is present"
+func RequiredWith(field string, other any) datacop.NamedRule {
+	required := Required(other)
+	return datacop.Named("required_with", datacop.Params{"other": field, "required": required}, func(v any) bool {
+		return !required || Required(v)
+	})
+}
+
+// RequiredWithout returns a validation function that checks that a value is present when other,
+// the value of the field named field, is missing, such as a phone number when no email address
+// was given
+//
+// Example usage:
+// v.Field("phone", phone).Validate(is.RequiredWithout("email", email), "is required without an email")
+func RequiredWithout(field string, other any) datacop.NamedRule {
+	required := !Required(other)
+	return datacop.Named("required_without", datacop.Params{"other": field, "required": required}, func(v any) bool {
+		return !required || Required(v)
+	})
+}
+
+// equal reports whether a and b are equal, comparing strings, bools, and numbers of the same
+// kind by their underlying values and treating values that cannot be compared as unequal
+func equal(a, b any) (eq bool) {
+	defer func() {
+		if recover() != nil {
+			eq = false
+		}
+	}()
+	if a == b {
+		return true
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != vb.Kind() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.String:
+		return va.String() == vb.String()
+	case reflect.Bool:
+		return va.Bool() == vb.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() == vb.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() == vb.Uint()
+	case reflect.Float32, reflect.Float64:
+		return va.Float() == vb.Float()
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+//go:build tinygo || playground
+
+package is
+
+import (
+	"reflect"
+	"strings"
+)
+
+// requiredReflect implements Required for types not covered by its type switch.
+// TinyGo's reflect support is limited, so this version only inspects the value's kind, length,
+// and nil-ness, and its numeric value, which gives the same results as the standard build.
+func requiredReflect(value any) bool {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		return !v.IsNil() && Required(v.Elem().Interface())
+	}
+	if z, ok := value.(Zeroer); ok {
+		return !z.IsZero()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) != ""
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() > 0
+	case reflect.Chan, reflect.Func, reflect.Interface:
+		return !v.IsNil()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() != 0
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+//go:build !tinygo && !playground
+
+package is
+
+import (
+	"reflect"
+	"strings"
+)
+
+// requiredReflect implements Required for types not covered by its type switch
+func requiredReflect(value any) bool {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		return !v.IsNil() && Required(v.Elem().Interface())
+	}
+	if z, ok := value.(Zeroer); ok {
+		return !z.IsZero()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) != ""
+	case reflect.Slice, reflect.Array:
+		return v.Len() > 0
+	case reflect.Map:
+		return v.Len() > 0
+	case reflect.Struct:
+		// For other structs, we could either:
+		// 1. Consider them always required (return true)
+		// 2. Check if they're zero value (return !reflect.DeepEqual(value, reflect.Zero(v.Type()).Interface()))
+		return true
+	default:
+		// For basic types (int, float, etc), check if they're zero value
+		return value != reflect.Zero(v.Type()).Interface()
+	}
+}
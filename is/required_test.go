@@ -0,0 +1,60 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// accountType is a typed string enum, compared with untyped literals by RequiredIf
+type accountType string
+
+func TestRequiredDependent(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    datacop.ValidationFunc
+		value any
+		want  bool
+	}{
+		{"if matches and missing", is.RequiredIf("business", "business"), "", false},
+		{"if matches and present", is.RequiredIf("business", "business"), "Acme", true},
+		{"if does not match", is.RequiredIf("personal", "business"), "", true},
+		{"if with numbers", is.RequiredIf(2, 2), nil, false},
+		{"if with different types", is.RequiredIf(int64(2), 2), nil, true},
+		{"if with a typed string", is.RequiredIf(accountType("business"), "business"), "", false},
+		{"if with uncomparable values", is.RequiredIf([]string{"a"}, []string{"a"}), "", true},
+		{"unless matches", is.RequiredUnless("US", "US"), "", true},
+		{"unless does not match", is.RequiredUnless("DE", "US"), "", false},
+		{"unless does not match and present", is.RequiredUnless("DE", "US"), "DE123", true},
+		{"with other present", is.RequiredWith("shipping_address", "1 Main St"), "", false},
+		{"with other present and value present", is.RequiredWith("shipping_address", "1 Main St"), "express", true},
+		{"with other blank", is.RequiredWith("shipping_address", "  "), "", true},
+		{"with other empty slice", is.RequiredWith("items", []string{}), nil, true},
+		{"without other missing", is.RequiredWithout("email", ""), "", false},
+		{"without other missing and present", is.RequiredWithout("email", ""), "555-0100", true},
+		{"without other present", is.RequiredWithout("email", "a@example.com"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fn(tt.value))
+		})
+	}
+}
+
+func TestRequiredDependent_Messages(t *testing.T) {
+	v := datacop.New()
+	v.Field("company", "").Validate(is.RequiredIf("business", "business"))
+	v.Field("shipping_method", "").Validate(is.RequiredWith("shipping_address", "1 Main St"))
+	v.Field("phone", "").Validate(is.RequiredWithout("email", nil))
+
+	assert.Equal(t, map[string]string{
+		"company":         "is required",
+		"shipping_method": "is required when shipping_address is present",
+		"phone":           "is required when email is missing",
+	}, v.Errors())
+	assert.Equal(t, "required_if", v.OrderedErrors()[0].Code)
+}
@@ -0,0 +1,431 @@
+package is
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// semver is a parsed semantic version. Build metadata is dropped, as it does not affect
+// precedence.
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+}
+
+// SemVer returns a validation function for semantic versions as defined by Semantic Versioning
+// 2.0.0: MAJOR.MINOR.PATCH with optional pre-release and build metadata, e.g. "1.0.0-alpha.1" or
+// "1.0.0+20130313144700". Numbers must not have leading zeros, and neither may numeric pre-release
+// identifiers. A "v" prefix is not part of a semantic version and is rejected.
+//
+// Example usage:
+// SemVer()("1.2.3") // returns true
+// SemVer()("1.0.0-rc.1+build.5") // returns true
+// SemVer()("1.2") // returns false
+// SemVer()("v1.2.3") // returns false
+func SemVer() datacop.NamedRule {
+	return pure("semver", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		_, ok = parseSemVer(str)
+		return ok
+	})
+}
+
+// SemVerInRange returns a validation function for semantic versions that satisfy a range, using
+// the range syntax of npm:
+//
+//   - comparators: "1.2.3" or "=1.2.3", ">1.2.3", ">=1.2.3", "<1.2.3", "<=1.2.3"
+//   - space-separated comparators must all be satisfied: ">=1.2.0 <2.0.0"
+//   - "||" separates alternatives: "^1.2.0 || ^2.0.0"
+//   - partial versions and wildcards: "1.2", "1.2.x", "1.x", "*"
+//   - tilde ranges allow patch updates: "~1.2.3" is ">=1.2.3 <1.3.0"
+//   - caret ranges allow updates that keep the left-most non-zero number: "^1.2.3" is
+//     ">=1.2.3 <2.0.0" and "^0.2.3" is ">=0.2.3 <0.3.0"
+//   - hyphen ranges are inclusive: "1.2.3 - 2.3.4" is ">=1.2.3 <=2.3.4"
+//
+// As in npm, a pre-release version only satisfies a range if one of the comparators it is matched
+// against has a pre-release on the same MAJOR.MINOR.PATCH, so "2.0.0-rc.1" does not satisfy
+// "<2.0.0", but "1.3.0-beta.2" satisfies ">=1.3.0-beta.1". It panics if the range is invalid.
+//
+// Example usage:
+// SemVerInRange(">=1.2.0 <2.0.0")("1.4.7") // returns true
+// SemVerInRange(">=1.2.0 <2.0.0")("2.0.0") // returns false
+// SemVerInRange("^0.3.1")("0.4.0") // returns false
+// SemVerInRange("~1.2 || >=3")("3.1.0") // returns true
+func SemVerInRange(constraint string) datacop.NamedRule {
+	sets, err := parseSemVerRange(constraint)
+	if err != nil {
+		panic(fmt.Sprintf("is: invalid version range %q: %v", constraint, err))
+	}
+
+	return pure("semver_range", datacop.Params{"range": constraint}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		v, ok := parseSemVer(str)
+		if !ok {
+			return false
+		}
+		for _, set := range sets {
+			if set.matches(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// parseSemVer parses a semantic version, returning false if it is not valid
+func parseSemVer(str string) (semver, bool) {
+	var v semver
+	str, build, hasBuild := strings.Cut(str, "+")
+	if hasBuild && !validIdentifiers(build, false) {
+		return v, false
+	}
+	str, pre, hasPre := strings.Cut(str, "-")
+	if hasPre {
+		if !validIdentifiers(pre, true) {
+			return v, false
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	numbers := [3]*uint64{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		n, ok := parseVersionNumber(part)
+		if !ok {
+			return v, false
+		}
+		*numbers[i] = n
+	}
+	return v, true
+}
+
+// validIdentifiers reports whether str is a dot-separated list of non-empty identifiers of ASCII
+// letters, digits, and hyphens. Numeric pre-release identifiers must not have leading zeros.
+func validIdentifiers(str string, pre bool) bool {
+	for _, id := range strings.Split(str, ".") {
+		if id == "" {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !isDigit(c) && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != '-' {
+				return false
+			}
+		}
+		if pre && isDigits(id) && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseVersionNumber parses a version number without leading zeros
+func parseVersionNumber(str string) (uint64, bool) {
+	if str == "" || !isDigits(str) || (len(str) > 1 && str[0] == '0') {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(str, 10, 64)
+	return n, err == nil
+}
+
+// compare returns -1, 0, or 1 as v has lower, equal, or higher precedence than other
+func (v semver) compare(other semver) int {
+	for _, pair := range [3][2]uint64{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without a pre-release has higher precedence than one with
+	switch {
+	case len(v.pre) == 0 && len(other.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(other.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(other.pre); i++ {
+		if c := compareIdentifier(v.pre[i], other.pre[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.pre) < len(other.pre):
+		return -1
+	case len(v.pre) > len(other.pre):
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares pre-release identifiers: numeric identifiers numerically and lower
+// than alphanumeric ones, which compare in ASCII order
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isDigits(a), isDigits(b)
+	switch {
+	case aNum && bNum:
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// sameRelease reports whether v and other share MAJOR.MINOR.PATCH
+func (v semver) sameRelease(other semver) bool {
+	return v.major == other.major && v.minor == other.minor && v.patch == other.patch
+}
+
+// versionComparator is a single comparison against a version, such as ">=1.2.0"
+type versionComparator struct {
+	op string // one of "=", "<", "<=", ">", ">="
+	v  semver
+}
+
+func (c versionComparator) matches(v semver) bool {
+	cmp := v.compare(c.v)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+// comparatorSet is satisfied by versions that satisfy all of its comparators
+type comparatorSet []versionComparator
+
+func (s comparatorSet) matches(v semver) bool {
+	for _, c := range s {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if len(v.pre) == 0 {
+		return true
+	}
+	for _, c := range s {
+		if len(c.v.pre) > 0 && c.v.sameRelease(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// partialVersion is a version in a range, whose trailing numbers may be missing or wildcards
+type partialVersion struct {
+	numbers []uint64 // the numbers given, up to the first wildcard
+	pre     []string
+}
+
+// version returns the partial version with missing numbers set to zero
+func (p partialVersion) version() semver {
+	v := semver{pre: p.pre}
+	numbers := [3]*uint64{&v.major, &v.minor, &v.patch}
+	for i, n := range p.numbers {
+		*numbers[i] = n
+	}
+	return v
+}
+
+// next returns the lowest version above every version matching p, such as 1.3.0 for "1.2"
+func (p partialVersion) next() semver {
+	v := semver{major: p.numbers[0]}
+	switch len(p.numbers) {
+	case 1:
+		v.major++
+	case 2:
+		v.minor = p.numbers[1] + 1
+	default:
+		v.minor, v.patch = p.numbers[1], p.numbers[2]+1
+	}
+	return v
+}
+
+// parseSemVerRange parses a range into comparator sets, any of which may be satisfied
+func parseSemVerRange(constraint string) ([]comparatorSet, error) {
+	var sets []comparatorSet
+	for _, alternative := range strings.Split(constraint, "||") {
+		set, err := parseComparatorSet(strings.TrimSpace(alternative))
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+func parseComparatorSet(str string) (comparatorSet, error) {
+	if from, to, ok := strings.Cut(str, " - "); ok {
+		lower, err := parsePartialVersion(strings.TrimSpace(from))
+		if err != nil {
+			return nil, err
+		}
+		upper, err := parsePartialVersion(strings.TrimSpace(to))
+		if err != nil {
+			return nil, err
+		}
+		set := comparatorSet{{">=", lower.version()}}
+		switch {
+		case len(upper.numbers) == 0:
+		case len(upper.numbers) < 3:
+			set = append(set, versionComparator{"<", upper.next()})
+		default:
+			set = append(set, versionComparator{"<=", upper.version()})
+		}
+		return set, nil
+	}
+
+	// Operators may be separated from their versions by spaces, as in ">= 1.2.0"
+	var tokens []string
+	pending := ""
+	for _, field := range strings.Fields(str) {
+		if strings.Trim(field, "<>=~^") == "" {
+			pending += field
+			continue
+		}
+		tokens = append(tokens, pending+field)
+		pending = ""
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("operator %q without a version", pending)
+	}
+
+	set := comparatorSet{}
+	for _, token := range tokens {
+		comparators, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+// parseComparator expands a single comparator, which may be a tilde, caret, or partial version,
+// into primitive comparisons
+func parseComparator(token string) ([]versionComparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(token, prefix) {
+			op, token = prefix, token[len(prefix):]
+			break
+		}
+	}
+	p, err := parsePartialVersion(token)
+	if err != nil {
+		return nil, err
+	}
+	n := len(p.numbers)
+	if n == 0 {
+		// A wildcard matches every version, except that nothing is below or above all of them
+		if op == "<" || op == ">" {
+			return []versionComparator{{"<", semver{}}}, nil
+		}
+		return nil, nil
+	}
+
+	switch op {
+	case ">":
+		if n < 3 {
+			return []versionComparator{{">=", p.next()}}, nil
+		}
+		return []versionComparator{{">", p.version()}}, nil
+	case ">=":
+		return []versionComparator{{">=", p.version()}}, nil
+	case "<":
+		return []versionComparator{{"<", p.version()}}, nil
+	case "<=":
+		if n < 3 {
+			return []versionComparator{{"<", p.next()}}, nil
+		}
+		return []versionComparator{{"<=", p.version()}}, nil
+	case "~":
+		upper := partialVersion{numbers: p.numbers[:min(n, 2)]}
+		return []versionComparator{{">=", p.version()}, {"<", upper.next()}}, nil
+	case "^":
+		// Allow changes that keep the left-most non-zero number given
+		keep := n
+		for i, number := range p.numbers {
+			if number != 0 {
+				keep = i + 1
+				break
+			}
+		}
+		upper := partialVersion{numbers: p.numbers[:keep]}
+		return []versionComparator{{">=", p.version()}, {"<", upper.next()}}, nil
+	}
+
+	if n < 3 {
+		return []versionComparator{{">=", p.version()}, {"<", p.next()}}, nil
+	}
+	return []versionComparator{{"=", p.version()}}, nil
+}
+
+// parsePartialVersion parses a version in a range, such as "1.2.3-beta.1", "1.2", "1.x", or "*".
+// A leading "v" is allowed.
+func parsePartialVersion(str string) (partialVersion, error) {
+	var p partialVersion
+	str = strings.TrimPrefix(str, "v")
+	if str == "" {
+		return p, fmt.Errorf("missing version")
+	}
+	core, pre, hasPre := strings.Cut(strings.SplitN(str, "+", 2)[0], "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("invalid version %q", str)
+	}
+	wildcard := false
+	for _, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return p, fmt.Errorf("invalid version %q: numbers after a wildcard", str)
+		}
+		n, ok := parseVersionNumber(part)
+		if !ok {
+			return p, fmt.Errorf("invalid version %q", str)
+		}
+		p.numbers = append(p.numbers, n)
+	}
+
+	if hasPre {
+		if len(p.numbers) != 3 || !validIdentifiers(pre, true) {
+			return p, fmt.Errorf("invalid version %q", str)
+		}
+		p.pre = strings.Split(pre, ".")
+	}
+	return p, nil
+}
@@ -0,0 +1,166 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestSemVer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"release", "1.2.3", true},
+		{"zeros", "0.0.0", true},
+		{"pre-release", "1.0.0-alpha", true},
+		{"dotted pre-release", "1.0.0-alpha.1", true},
+		{"pre-release with hyphens", "1.0.0-x-y-z.--", true},
+		{"numeric pre-release", "1.0.0-0.3.7", true},
+		{"alphanumeric identifier with leading zero", "1.0.0-0alpha", true},
+		{"build metadata", "1.0.0+20130313144700", true},
+		{"pre-release and build", "1.0.0-beta+exp.sha.5114f85", true},
+		{"build with leading zeros", "1.0.0+001", true},
+		{"large numbers", "99999999999999999.0.0", true},
+		{"missing patch", "1.2", false},
+		{"extra number", "1.2.3.4", false},
+		{"v prefix", "v1.2.3", false},
+		{"leading zero", "01.2.3", false},
+		{"numeric pre-release with leading zero", "1.0.0-01", false},
+		{"empty pre-release identifier", "1.0.0-alpha..1", false},
+		{"empty pre-release", "1.0.0-", false},
+		{"empty build", "1.0.0+", false},
+		{"invalid character", "1.0.0-alpha_1", false},
+		{"overflow", "99999999999999999999.0.0", false},
+		{"surrounding spaces", " 1.2.3", false},
+		{"empty", "", false},
+		{"non-string", 1.2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.SemVer()(tt.value))
+		})
+	}
+}
+
+func TestSemVerInRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		value      string
+		want       bool
+	}{
+		{">=1.2.0 <2.0.0", "1.2.0", true},
+		{">=1.2.0 <2.0.0", "1.9.99", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.9", false},
+		{">= 1.2.0 < 2.0.0", "1.5.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{">1.2.3", "1.2.4", true},
+		{"<=1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.3+build.7", true},
+
+		// Partial versions and wildcards
+		{"1.2", "1.2.9", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.x", "1.9.0", true},
+		{"1", "2.0.0", false},
+		{"*", "0.0.1", true},
+		{"", "5.0.0", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"<1.2", "1.1.9", true},
+		{"v1.2.3", "1.2.3", true},
+
+		// Tilde ranges
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+
+		// Caret ranges
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0", "0.9.0", true},
+		{"^1.x", "1.5.0", true},
+
+		// Hyphen ranges
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2.3 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3", "2.3.9", true},
+		{"1.2 - 2.3", "1.1.9", false},
+		{"1.2.3 - 2", "2.9.0", true},
+
+		// Alternatives
+		{"^1.2.0 || ^2.0.0", "2.4.0", true},
+		{"~1.2 || >=3", "3.1.0", true},
+		{"~1.2 || >=3", "2.0.0", false},
+
+		// Pre-releases
+		{"<2.0.0", "2.0.0-rc.1", false},
+		{">=1.2.0 <2.0.0", "1.3.0-beta", false},
+		{">=1.3.0-beta.1", "1.3.0-beta.2", true},
+		{">=1.3.0-beta.1", "1.3.0-beta.0", false},
+		{">=1.3.0-beta.1", "1.3.0-beta.11", true},
+		{">=1.3.0-beta.1", "1.4.0-alpha", false},
+		{">=1.3.0-beta.1", "1.3.0", true},
+		{">=1.0.0-alpha", "1.0.0-alpha.1", true},
+		{">=1.0.0-alpha.beta", "1.0.0-beta", true},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"*", "1.0.0-alpha", false},
+
+		// Invalid versions never satisfy a range
+		{"*", "1.2", false},
+		{"*", "v1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.SemVerInRange(tt.constraint)(tt.value))
+		})
+	}
+}
+
+func TestSemVerInRange_InvalidRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{">=", `is: invalid version range ">=": operator ">=" without a version`},
+		{"1.2.3.4", `is: invalid version range "1.2.3.4": invalid version "1.2.3.4"`},
+		{"1.x.3", `is: invalid version range "1.x.3": invalid version "1.x.3": numbers after a wildcard`},
+		{"^1.2-beta", `is: invalid version range "^1.2-beta": invalid version "1.2-beta"`},
+		{"~a.b.c", `is: invalid version range "~a.b.c": invalid version "a.b.c"`},
+		{"1.2.3 - ", `is: invalid version range "1.2.3 - ": invalid version "-"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			assert.PanicsWithValue(t, tt.want, func() { is.SemVerInRange(tt.constraint) })
+		})
+	}
+}
+
+func TestSemVer_DefaultMessages(t *testing.T) {
+	v := datacop.New()
+	v.Field("version", "1.2").Validate(is.SemVer())
+	v.Field("engine", "3.0.0").Validate(is.SemVerInRange("^2.1.0"))
+
+	assert.Equal(t, "must be a valid semantic version", v.Errors()["version"])
+	assert.Equal(t, "must be a version in ^2.1.0", v.Errors()["engine"])
+}
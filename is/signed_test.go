@@ -0,0 +1,68 @@
+package is_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestSignedValue(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	restore := is.SetClock(func() time.Time { return fixed })
+	defer restore()
+
+	secret := []byte("s3cret")
+	valid := is.Sign(secret, "user-42", fixed.Add(-time.Hour))
+
+	tests := []struct {
+		name   string
+		maxAge time.Duration
+		value  any
+		want   bool
+	}{
+		{"valid", 24 * time.Hour, valid, true},
+		{"payload with dots", 24 * time.Hour, is.Sign(secret, "a.b.c", fixed), true},
+		{"empty payload", 24 * time.Hour, is.Sign(secret, "", fixed.Add(-time.Minute)), true},
+		{"at max age", time.Hour, valid, true},
+		{"expired", 30 * time.Minute, valid, false},
+		{"no age limit", 0, is.Sign(secret, "x", fixed.AddDate(-1, 0, 0)), true},
+		{"from the future", 24 * time.Hour, is.Sign(secret, "x", fixed.Add(time.Minute)), false},
+		{"within the clock skew", 24 * time.Hour, is.Sign(secret, "x", fixed.Add(10*time.Second)), true},
+		{"wrong secret", 24 * time.Hour, is.Sign([]byte("other"), "user-42", fixed), false},
+		{"tampered payload", 24 * time.Hour, "user-43" + valid[len("user-42"):], false},
+		{"tampered signature", 24 * time.Hour, valid[:len(valid)-1] + "A", false},
+		{"missing signature", 24 * time.Hour, "user-42.1717243200", false},
+		{"not signed", 24 * time.Hour, "user-42", false},
+		{"empty", 24 * time.Hour, "", false},
+		{"non-string", 24 * time.Hour, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.SignedValue(secret, tt.maxAge)(tt.value))
+		})
+	}
+}
+
+func TestSignedValueWithSkew(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	restore := is.SetClock(func() time.Time { return fixed })
+	defer restore()
+
+	secret := []byte("s3cret")
+	ahead := is.Sign(secret, "x", fixed.Add(time.Minute))
+
+	assert.True(t, is.SignedValueWithSkew(secret, time.Hour, 2*time.Minute)(ahead))
+	assert.False(t, is.SignedValueWithSkew(secret, time.Hour, 0)(is.Sign(secret, "x", fixed.Add(time.Second))))
+}
+
+func TestSignedPayload(t *testing.T) {
+	secret := []byte("s3cret")
+	assert.Equal(t, "user-42", is.SignedPayload(is.Sign(secret, "user-42", time.Now())))
+	assert.Equal(t, "a.b", is.SignedPayload(is.Sign(secret, "a.b", time.Now())))
+	assert.Equal(t, "", is.SignedPayload(is.Sign(secret, "", time.Now())))
+	assert.Equal(t, "", is.SignedPayload("garbage"))
+}
@@ -0,0 +1,153 @@
+package is
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/patrickward/datacop"
+)
+
+// Dictionary decides which words are spelled correctly and suggests corrections for the rest
+type Dictionary interface {
+	// Known reports whether word, in lower case, is spelled correctly
+	Known(word string) bool
+	// Suggest returns likely corrections for an unknown word, best first
+	Suggest(word string) []string
+}
+
+// WordList is a Dictionary backed by a set of lower-case words. It suggests the known words one
+// edit away from an unknown word.
+type WordList map[string]struct{}
+
+// NewWordList creates a word list from words, which are stored in lower case
+func NewWordList(words ...string) WordList {
+	list := make(WordList, len(words))
+	for _, w := range words {
+		list[strings.ToLower(w)] = struct{}{}
+	}
+	return list
+}
+
+// Known reports whether word is in the list
+func (l WordList) Known(word string) bool {
+	_, ok := l[word]
+	return ok
+}
+
+// Suggest returns the words in the list that are one insertion, deletion, substitution, or
+// transposition away from word, in alphabetical order
+func (l WordList) Suggest(word string) []string {
+	var suggestions []string
+	for known := range l {
+		if oneEditApart(word, known) {
+			suggestions = append(suggestions, known)
+		}
+	}
+	sort.Strings(suggestions)
+	return suggestions
+}
+
+// SpellCheckWarn returns a validation function that never fails but, when it runs in Validate,
+// records a datacop.AnnotationWarning annotation for each word of a string that dict does not
+// know. The annotation's data holds the "word", its byte "offset" in the string, and the
+// dictionary's "suggestions". Words are runs of letters, optionally joined by apostrophes, and
+// are checked in lower case with typographic apostrophes replaced by straight ones; single
+// letters and words containing digits are skipped.
+//
+// Example usage:
+//
+//	v.Field("body", body).Validate(is.SpellCheckWarn(dict))
+//	for _, a := range v.AnnotationsFor("body") {
+//		fmt.Println(a.Message, a.Data["suggestions"]) // possible misspelling: "teh" [ten the]
+//	}
+func SpellCheckWarn(dict Dictionary) datacop.NamedRule {
+	return datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		str, ok := value.(string)
+		if !ok {
+			return true
+		}
+		for _, w := range words(str) {
+			lower := strings.ReplaceAll(strings.ToLower(w.text), "\u2019", "'")
+			if dict.Known(lower) {
+				continue
+			}
+			annotate(datacop.AnnotationWarning, `possible misspelling: "`+w.text+`"`, map[string]any{
+				"word":        w.text,
+				"offset":      w.offset,
+				"suggestions": dict.Suggest(lower),
+			})
+		}
+		return true
+	}).Named("spell_check", nil)
+}
+
+type word struct {
+	text   string
+	offset int
+}
+
+// words splits s into the words checked by SpellCheckWarn
+func words(s string) []word {
+	var found []word
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			i += size
+			continue
+		}
+
+		start, letters, digits := i, 0, false
+	scan:
+		for i < len(s) {
+			r, size = utf8.DecodeRuneInString(s[i:])
+			switch {
+			case unicode.IsLetter(r):
+				letters++
+			case unicode.IsDigit(r):
+				digits = true
+			case r == '\'' || r == '\u2019':
+				// An apostrophe joins two runs of letters, as in "don't"
+				next, _ := utf8.DecodeRuneInString(s[i+size:])
+				if !unicode.IsLetter(next) {
+					break scan
+				}
+			default:
+				break scan
+			}
+			i += size
+		}
+		if letters > 1 && !digits {
+			found = append(found, word{text: s[start:i], offset: start})
+		}
+	}
+	return found
+}
+
+// oneEditApart reports whether a and b differ by exactly one insertion, deletion, substitution,
+// or transposition of adjacent letters
+func oneEditApart(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb)-len(ra) > 1 {
+		return false
+	}
+
+	i := 0
+	for i < len(ra) && ra[i] == rb[i] {
+		i++
+	}
+	if len(ra) == len(rb) {
+		if i == len(ra) {
+			return false // equal
+		}
+		if string(ra[i+1:]) == string(rb[i+1:]) {
+			return true // substitution
+		}
+		return i+1 < len(ra) && ra[i] == rb[i+1] && ra[i+1] == rb[i] && string(ra[i+2:]) == string(rb[i+2:])
+	}
+	return string(ra[i:]) == string(rb[i+1:]) // insertion
+}
@@ -0,0 +1,63 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestSpellCheckWarn(t *testing.T) {
+	dict := is.NewWordList("the", "ten", "quick", "brown", "fox", "don't", "jumps", "over", "dog")
+	v := datacop.New()
+
+	v.Field("body", "Teh quick brwn fox don't jump over the dog 42 times, x.").Validate(is.SpellCheckWarn(dict))
+	assert.False(t, v.HasErrors(), "spelling never fails validation")
+
+	annotations := v.AnnotationsFor("body")
+	require.Len(t, annotations, 4)
+	assert.Equal(t, datacop.Annotation{
+		Field:   "body",
+		Kind:    datacop.AnnotationWarning,
+		Message: `possible misspelling: "Teh"`,
+		Data:    map[string]any{"word": "Teh", "offset": 0, "suggestions": []string{"ten", "the"}},
+	}, annotations[0])
+	assert.Equal(t, "brwn", annotations[1].Data["word"])
+	assert.Equal(t, 10, annotations[1].Data["offset"])
+	assert.Equal(t, []string{"brown"}, annotations[1].Data["suggestions"])
+	assert.Equal(t, "jump", annotations[2].Data["word"])
+	assert.Equal(t, []string{"jumps"}, annotations[2].Data["suggestions"])
+	assert.Equal(t, "times", annotations[3].Data["word"])
+	assert.Empty(t, annotations[3].Data["suggestions"])
+
+	v = datacop.New()
+	v.Field("body", "I don\u2019t know").Validate(is.SpellCheckWarn(is.NewWordList("know", "don't")))
+	assert.Empty(t, v.Annotations(), "typographic apostrophes match straight ones")
+
+	assert.True(t, is.SpellCheckWarn(dict)("whatever"))
+	assert.True(t, is.SpellCheckWarn(dict)(42))
+}
+
+func TestWordList_Suggest(t *testing.T) {
+	dict := is.NewWordList("cat", "cart", "act", "cast", "coat")
+
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"cta", []string{"cat"}},                         // transposition
+		{"cat", []string{"act", "cart", "cast", "coat"}}, // the word itself is excluded
+		{"bat", []string{"cat"}},                         // substitution
+		{"carts", []string{"cart"}},                      // deletion
+		{"dog", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.want, dict.Suggest(tt.word))
+		})
+	}
+}
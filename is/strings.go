@@ -0,0 +1,104 @@
+package is
+
+import (
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// StartsWith checks if a string starts with prefix
+//
+// Example usage:
+// StartsWith("https://")("https://example.com") // returns true
+// StartsWith("https://")("HTTPS://example.com") // returns false
+func StartsWith(prefix string) datacop.NamedRule {
+	return pure("starts_with", datacop.Params{"prefix": prefix}, stringCheck(func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}))
+}
+
+// StartsWithFold checks if a string starts with prefix, ignoring case
+//
+// Example usage:
+// StartsWithFold("https://")("HTTPS://example.com") // returns true
+func StartsWithFold(prefix string) datacop.NamedRule {
+	prefix = strings.ToLower(prefix)
+	return pure("starts_with_fold", datacop.Params{"prefix": prefix}, stringCheck(func(s string) bool {
+		return strings.HasPrefix(strings.ToLower(s), prefix)
+	}))
+}
+
+// EndsWith checks if a string ends with suffix
+//
+// Example usage:
+// EndsWith("@example.com")("jane@example.com") // returns true
+func EndsWith(suffix string) datacop.NamedRule {
+	return pure("ends_with", datacop.Params{"suffix": suffix}, stringCheck(func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	}))
+}
+
+// EndsWithFold checks if a string ends with suffix, ignoring case
+//
+// Example usage:
+// EndsWithFold("@example.com")("Jane@Example.COM") // returns true
+func EndsWithFold(suffix string) datacop.NamedRule {
+	suffix = strings.ToLower(suffix)
+	return pure("ends_with_fold", datacop.Params{"suffix": suffix}, stringCheck(func(s string) bool {
+		return strings.HasSuffix(strings.ToLower(s), suffix)
+	}))
+}
+
+// Contains checks if a string contains substr
+//
+// Example usage:
+// Contains("@")("jane@example.com") // returns true
+func Contains(substr string) datacop.NamedRule {
+	return pure("contains", datacop.Params{"substr": substr}, stringCheck(func(s string) bool {
+		return strings.Contains(s, substr)
+	}))
+}
+
+// ContainsFold checks if a string contains substr, ignoring case
+//
+// Example usage:
+// ContainsFold("acme")("ACME Corporation") // returns true
+func ContainsFold(substr string) datacop.NamedRule {
+	substr = strings.ToLower(substr)
+	return pure("contains_fold", datacop.Params{"substr": substr}, stringCheck(func(s string) bool {
+		return strings.Contains(strings.ToLower(s), substr)
+	}))
+}
+
+// NotContains checks if a string does not contain substr. Non-string values fail.
+//
+// Example usage:
+// NotContains("password")("my-secret") // returns true
+// NotContains("password")("password123") // returns false
+func NotContains(substr string) datacop.NamedRule {
+	return pure("not_contains", datacop.Params{"substr": substr}, stringCheck(func(s string) bool {
+		return !strings.Contains(s, substr)
+	}))
+}
+
+// NotContainsFold checks if a string does not contain substr, ignoring case. Non-string values fail.
+//
+// Example usage:
+// NotContainsFold("admin")("Administrator") // returns false
+func NotContainsFold(substr string) datacop.NamedRule {
+	substr = strings.ToLower(substr)
+	return pure("not_contains_fold", datacop.Params{"substr": substr}, stringCheck(func(s string) bool {
+		return !strings.Contains(strings.ToLower(s), substr)
+	}))
+}
+
+// stringCheck adapts a string predicate to a ValidationFunc that fails for non-string values
+func stringCheck(check func(string) bool) datacop.ValidationFunc {
+	return func(value any) bool {
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return check(s)
+	}
+}
@@ -0,0 +1,51 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestSubstringValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    datacop.ValidationFunc
+		value any
+		want  bool
+	}{
+		{"StartsWith match", is.StartsWith("https://"), "https://example.com", true},
+		{"StartsWith case mismatch", is.StartsWith("https://"), "HTTPS://example.com", false},
+		{"StartsWith no match", is.StartsWith("https://"), "http://example.com", false},
+		{"StartsWithFold case mismatch", is.StartsWithFold("https://"), "HTTPS://example.com", true},
+		{"StartsWithFold no match", is.StartsWithFold("https://"), "ftp://example.com", false},
+
+		{"EndsWith match", is.EndsWith("@example.com"), "jane@example.com", true},
+		{"EndsWith case mismatch", is.EndsWith("@example.com"), "jane@EXAMPLE.com", false},
+		{"EndsWithFold case mismatch", is.EndsWithFold("@Example.com"), "jane@EXAMPLE.COM", true},
+		{"EndsWithFold no match", is.EndsWithFold("@example.com"), "jane@example.org", false},
+
+		{"Contains match", is.Contains("acme"), "the acme corp", true},
+		{"Contains case mismatch", is.Contains("acme"), "ACME Corp", false},
+		{"ContainsFold match", is.ContainsFold("acme"), "ACME Corp", true},
+		{"ContainsFold unicode", is.ContainsFold("ÉCOLE"), "une école", true},
+
+		{"NotContains absent", is.NotContains("password"), "my-secret", true},
+		{"NotContains present", is.NotContains("password"), "password123", false},
+		{"NotContains case mismatch", is.NotContains("password"), "PASSWORD123", true},
+		{"NotContainsFold present", is.NotContainsFold("admin"), "Administrator", false},
+		{"NotContainsFold absent", is.NotContainsFold("admin"), "operator", true},
+
+		{"non-string StartsWith", is.StartsWith("1"), 123, false},
+		{"non-string NotContains", is.NotContains("x"), 123, false},
+		{"nil ContainsFold", is.ContainsFold("x"), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.fn(tt.value))
+		})
+	}
+}
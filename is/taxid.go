@@ -0,0 +1,331 @@
+package is
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// SSN returns a validation function for US Social Security numbers, written as 9 digits with or
+// without hyphens, e.g. "123-45-6789" or "123456789". Numbers the Social Security Administration
+// never issues are rejected: area numbers 000, 666, and 900-999, group number 00, serial number
+// 0000, and numbers that were published in advertising.
+//
+// Example usage:
+// SSN()("123-45-6789") // returns true
+// SSN()("666-45-6789") // returns false
+// SSN()("123-00-6789") // returns false
+func SSN() datacop.NamedRule {
+	return pure("ssn", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		digits, ok := usTaxDigits(str, 3, 5)
+		if !ok {
+			return false
+		}
+
+		area, group, serial := digits[:3], digits[3:5], digits[5:]
+		switch {
+		case area == "000" || area == "666" || area[0] == '9':
+			return false
+		case group == "00" || serial == "0000":
+			return false
+		case digits == "078051120" || digits == "219099999":
+			return false
+		}
+		return true
+	})
+}
+
+// einPrefixes holds the first two digits the IRS assigns to Employer Identification Numbers
+var einPrefixes = []string{
+	"01", "02", "03", "04", "05", "06", "10", "11", "12", "13", "14", "15", "16", "20", "21", "22",
+	"23", "24", "25", "26", "27", "30", "31", "32", "33", "34", "35", "36", "37", "38", "39", "40",
+	"41", "42", "43", "44", "45", "46", "47", "48", "50", "51", "52", "53", "54", "55", "56", "57",
+	"58", "59", "60", "61", "62", "63", "64", "65", "66", "67", "68", "71", "72", "73", "74", "75",
+	"76", "77", "80", "81", "82", "83", "84", "85", "86", "87", "88", "90", "91", "92", "93", "94",
+	"95", "98", "99",
+}
+
+// EIN returns a validation function for US Employer Identification Numbers, written as 9 digits
+// with or without a hyphen after the first two, e.g. "12-3456789". The first two digits must be
+// a prefix the IRS assigns.
+//
+// Example usage:
+// EIN()("12-3456789") // returns true
+// EIN()("07-3456789") // returns false
+func EIN() datacop.NamedRule {
+	return pure("ein", datacop.Params{}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		digits, ok := usTaxDigits(str, 2)
+		return ok && slices.Contains(einPrefixes, digits[:2])
+	})
+}
+
+// usTaxDigits returns the 9 digits of a US tax number written either without separators or with
+// hyphens at each of the given positions
+func usTaxDigits(str string, hyphens ...int) (string, bool) {
+	str = strings.TrimSpace(str)
+	if len(str) == 9+len(hyphens) {
+		for i, pos := range hyphens {
+			if str[pos+i] != '-' {
+				return "", false
+			}
+		}
+		str = strings.ReplaceAll(str, "-", "")
+	}
+	if len(str) != 9 || !isDigits(str) {
+		return "", false
+	}
+	return str, true
+}
+
+// vatFormat describes the VAT numbers of a country, without the country prefix
+type vatFormat struct {
+	pattern *regexp.Regexp
+	check   func(number string) bool // validates the check digits, if the country has any
+}
+
+var vatFormats = map[string]vatFormat{
+	"AT": {regexp.MustCompile(`^U\d{8}$`), func(n string) bool { return vatCheckAT(n[1:]) }},
+	"BE": {regexp.MustCompile(`^[01]\d{9}$`), vatCheckBE},
+	"BG": {regexp.MustCompile(`^\d{9,10}$`), nil},
+	"CY": {regexp.MustCompile(`^\d{8}[A-Z]$`), nil},
+	"CZ": {regexp.MustCompile(`^\d{8,10}$`), nil},
+	"DE": {regexp.MustCompile(`^[1-9]\d{8}$`), iso7064},
+	"DK": {regexp.MustCompile(`^[1-9]\d{7}$`), vatCheckDK},
+	"EE": {regexp.MustCompile(`^10\d{7}$`), nil},
+	"EL": {regexp.MustCompile(`^\d{9}$`), nil},
+	"ES": {regexp.MustCompile(`^(?:[A-Z]\d{7}[A-Z0-9]|\d{8}[A-Z])$`), nil},
+	"FI": {regexp.MustCompile(`^\d{8}$`), vatCheckFI},
+	"FR": {regexp.MustCompile(`^[0-9A-HJ-NP-Z]{2}\d{9}$`), vatCheckFR},
+	"HR": {regexp.MustCompile(`^\d{11}$`), iso7064},
+	"HU": {regexp.MustCompile(`^\d{8}$`), nil},
+	"IE": {regexp.MustCompile(`^(?:\d{7}[A-W][A-IW]?|\d[A-Z+*]\d{5}[A-W])$`), nil},
+	"IT": {regexp.MustCompile(`^\d{11}$`), luhn},
+	"LT": {regexp.MustCompile(`^(?:\d{9}|\d{12})$`), nil},
+	"LU": {regexp.MustCompile(`^\d{8}$`), vatCheckLU},
+	"LV": {regexp.MustCompile(`^\d{11}$`), nil},
+	"MT": {regexp.MustCompile(`^[1-9]\d{7}$`), nil},
+	"NL": {regexp.MustCompile(`^\d{9}B\d{2}$`), vatCheckNL},
+	"PL": {regexp.MustCompile(`^\d{10}$`), vatCheckPL},
+	"PT": {regexp.MustCompile(`^[1-9]\d{8}$`), vatCheckPT},
+	"RO": {regexp.MustCompile(`^[1-9]\d{1,9}$`), nil},
+	"SE": {regexp.MustCompile(`^\d{10}01$`), func(n string) bool { return luhn(n[:10]) }},
+	"SI": {regexp.MustCompile(`^[1-9]\d{7}$`), vatCheckSI},
+	"SK": {regexp.MustCompile(`^[1-9]\d{9}$`), func(n string) bool { return mod(n, 11) == 0 }},
+	"GB": {regexp.MustCompile(`^(?:\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`), vatCheckGB},
+	"XI": {regexp.MustCompile(`^(?:\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`), vatCheckGB},
+}
+
+// vatSeparators are removed from VAT numbers before validation
+var vatSeparators = strings.NewReplacer(" ", "", "-", "", ".", "")
+
+// VATNumber returns a validation function for value-added tax identification numbers of the
+// given country, identified by its ISO 3166-1 alpha-2 code, or "EL" for Greece as VIES uses.
+// The number may be written with or without its country prefix, in either case, with spaces,
+// dots, or hyphens between digits. Each country's format is checked, as are its check digits
+// where the number has them (AT, BE, DE, DK, FI, FR, GB, HR, IT, LU, NL, PL, PT, SE, SI, SK, XI).
+//
+// With an empty country, numbers from any supported country are accepted, but must carry their
+// country prefix. Using an unsupported country panics.
+//
+// The check only covers the number's structure. Use the VIES or HMRC services to verify that a
+// number is registered.
+//
+// Supported countries: the EU member states, GB, and XI (Northern Ireland).
+//
+// Example usage:
+// VATNumber("DE")("DE136695976") // returns true
+// VATNumber("DE")("136 695 976") // returns true
+// VATNumber("DE")("DE136695977") // returns false
+// VATNumber("")("FR40303265045") // returns true
+func VATNumber(country string) datacop.NamedRule {
+	country = strings.ToUpper(country)
+	if country == "GR" {
+		country = "EL"
+	}
+	if _, ok := vatFormats[country]; !ok && country != "" {
+		supported := slices.Sorted(maps.Keys(vatFormats))
+		panic(fmt.Sprintf("is: unsupported VAT country %q (supported: %s)", country, strings.Join(supported, ", ")))
+	}
+
+	return pure("vat_number", datacop.Params{"country": country}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		number := strings.ToUpper(vatSeparators.Replace(strings.TrimSpace(str)))
+
+		code := country
+		if code == "" {
+			if len(number) < 2 {
+				return false
+			}
+			code, number = number[:2], number[2:]
+		} else {
+			number = strings.TrimPrefix(number, code)
+		}
+
+		format, ok := vatFormats[code]
+		if !ok || !format.pattern.MatchString(number) {
+			return false
+		}
+		return format.check == nil || format.check(number)
+	})
+}
+
+// vatCheckAT validates an Austrian UID number without its "U" prefix
+func vatCheckAT(n string) bool {
+	sum := 0
+	for i := 0; i < 7; i++ {
+		d := int(n[i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return (10-(sum+4)%10)%10 == int(n[7]-'0')
+}
+
+// vatCheckBE validates a Belgian enterprise number: the last two digits are 97 minus the first
+// eight modulo 97
+func vatCheckBE(n string) bool {
+	return 97-mod(n[:8], 97) == mod(n[8:], 100)
+}
+
+// vatCheckDK validates a Danish CVR number with weights 2, 7, 6, 5, 4, 3, 2, 1 modulo 11
+func vatCheckDK(n string) bool {
+	return weightedSum(n, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+// vatCheckFI validates a Finnish business ID with weights 7, 9, 10, 5, 8, 4, 2 modulo 11
+func vatCheckFI(n string) bool {
+	r := weightedSum(n[:7], 7, 9, 10, 5, 8, 4, 2) % 11
+	if r == 1 {
+		return false
+	}
+	return (11-r)%11 == int(n[7]-'0')
+}
+
+// vatCheckFR validates a French VAT number's numeric key against its SIREN. Newer alphanumeric
+// keys have no published check.
+func vatCheckFR(n string) bool {
+	siren := n[2:]
+	if !luhn(siren) {
+		return false
+	}
+	key, err := strconv.Atoi(n[:2])
+	if err != nil {
+		return true
+	}
+	return key == (12+3*mod(siren, 97))%97
+}
+
+// vatCheckGB validates the check digits of a UK VAT number with the mod 97 or 9755 algorithm.
+// Government departments (GD) and health authorities (HA) have no check digits.
+func vatCheckGB(n string) bool {
+	if !isDigits(n) {
+		return true
+	}
+	sum := weightedSum(n[:7], 8, 7, 6, 5, 4, 3, 2) + mod(n[7:9], 100)
+	return sum%97 == 0 || (sum+55)%97 == 0
+}
+
+// vatCheckLU validates a Luxembourg VAT number: the last two digits are the first six modulo 89
+func vatCheckLU(n string) bool {
+	return mod(n[:6], 89) == mod(n[6:], 100)
+}
+
+// vatCheckNL validates a Dutch VAT number with the mod 11 check of the original format, or the
+// mod 97 check of the sole proprietor format introduced in 2020
+func vatCheckNL(n string) bool {
+	if (weightedSum(n[:8], 9, 8, 7, 6, 5, 4, 3, 2)-int(n[8]-'0'))%11 == 0 {
+		return true
+	}
+	return mod97("NL"+n) == 1
+}
+
+// vatCheckPL validates a Polish NIP with weights 6, 5, 7, 2, 3, 4, 5, 6, 7 modulo 11
+func vatCheckPL(n string) bool {
+	return weightedSum(n[:9], 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == int(n[9]-'0')
+}
+
+// vatCheckPT validates a Portuguese NIF with weights 9 through 2 modulo 11
+func vatCheckPT(n string) bool {
+	check := 11 - weightedSum(n[:8], 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == int(n[8]-'0')
+}
+
+// vatCheckSI validates a Slovenian tax number with weights 8 through 2 modulo 11
+func vatCheckSI(n string) bool {
+	check := 11 - weightedSum(n[:7], 8, 7, 6, 5, 4, 3, 2)%11
+	switch check {
+	case 11:
+		return false
+	case 10:
+		check = 0
+	}
+	return check == int(n[7]-'0')
+}
+
+// iso7064 validates the last digit of n with the ISO 7064 MOD 11,10 algorithm
+func iso7064(n string) bool {
+	p := 10
+	for i := 0; i < len(n)-1; i++ {
+		s := (int(n[i]-'0') + p) % 10
+		if s == 0 {
+			s = 10
+		}
+		p = (2 * s) % 11
+	}
+	return (11-p)%10 == int(n[len(n)-1]-'0')
+}
+
+// luhn validates the last digit of n with the Luhn algorithm
+func luhn(n string) bool {
+	sum := 0
+	for i := 0; i < len(n); i++ {
+		d := int(n[len(n)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// weightedSum returns the sum of the digits of n multiplied by the weights
+func weightedSum(n string, weights ...int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(n[i]-'0') * w
+	}
+	return sum
+}
+
+// mod returns the number formed by the digits of n modulo m
+func mod(n string, m int) int {
+	r := 0
+	for i := 0; i < len(n); i++ {
+		r = (r*10 + int(n[i]-'0')) % m
+	}
+	return r
+}
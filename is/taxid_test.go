@@ -0,0 +1,151 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestSSN(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"hyphenated", "123-45-6789", true},
+		{"digits only", "123456789", true},
+		{"surrounding spaces", " 123-45-6789 ", true},
+		{"area 000", "000-45-6789", false},
+		{"area 666", "666-45-6789", false},
+		{"area 9xx", "900-45-6789", false},
+		{"group 00", "123-00-6789", false},
+		{"serial 0000", "123-45-0000", false},
+		{"advertised", "078-05-1120", false},
+		{"misplaced hyphens", "12-345-6789", false},
+		{"spaces", "123 45 6789", false},
+		{"too short", "123-45-678", false},
+		{"letters", "123-45-678X", false},
+		{"empty", "", false},
+		{"non-string", 123456789, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.SSN()(tt.value))
+		})
+	}
+}
+
+func TestEIN(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"hyphenated", "12-3456789", true},
+		{"digits only", "123456789", true},
+		{"internet prefix", "27-3456789", true},
+		{"unassigned prefix", "07-3456789", false},
+		{"unassigned prefix 89", "89-3456789", false},
+		{"misplaced hyphen", "123-456789", false},
+		{"too long", "12-34567890", false},
+		{"letters", "AB-3456789", false},
+		{"empty", "", false},
+		{"non-string", 123456789, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.EIN()(tt.value))
+		})
+	}
+}
+
+func TestVATNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		value   any
+		want    bool
+	}{
+		{"AT", "AT", "ATU13585627", true},
+		{"AT bad check", "AT", "ATU13585628", false},
+		{"BE", "BE", "BE0403019261", true},
+		{"BE bad check", "BE", "BE0403019262", false},
+		{"DE", "DE", "DE136695976", true},
+		{"DE without prefix", "DE", "136695976", true},
+		{"DE with separators", "de", "de 136.695-976", true},
+		{"DE bad check", "DE", "DE136695977", false},
+		{"DE leading zero", "DE", "DE036695976", false},
+		{"DK", "DK", "DK13585628", true},
+		{"DK bad check", "DK", "DK13585629", false},
+		{"FI", "FI", "FI20774740", true},
+		{"FI bad check", "FI", "FI20774741", false},
+		{"FR", "FR", "FR40303265045", true},
+		{"FR bad key", "FR", "FR41303265045", false},
+		{"FR alphanumeric key", "FR", "FRK7303265045", true},
+		{"FR bad SIREN", "FR", "FRK7303265046", false},
+		{"HR", "HR", "HR33392005961", true},
+		{"HR bad check", "HR", "HR33392005962", false},
+		{"IT", "IT", "IT00743110157", true},
+		{"IT bad check", "IT", "IT00743110158", false},
+		{"LU", "LU", "LU15027442", true},
+		{"LU bad check", "LU", "LU15027443", false},
+		{"NL", "NL", "NL004495445B01", true},
+		{"NL bad check", "NL", "NL004495446B01", false},
+		{"NL missing B", "NL", "NL00449544501", false},
+		{"PL", "PL", "PL8567346215", true},
+		{"PL bad check", "PL", "PL8567346216", false},
+		{"PT", "PT", "PT501964843", true},
+		{"PT bad check", "PT", "PT501964844", false},
+		{"SE", "SE", "SE123456789701", true},
+		{"SE bad check", "SE", "SE123456789801", false},
+		{"SI", "SI", "SI50223054", true},
+		{"SI bad check", "SI", "SI50223055", false},
+		{"SK", "SK", "SK2022749619", true},
+		{"SK bad check", "SK", "SK2022749618", false},
+		{"GB", "GB", "GB980780684", true},
+		{"GB with branch", "GB", "GB 980 7806 84 001", true},
+		{"GB bad check", "GB", "GB980780685", false},
+		{"GB government department", "GB", "GBGD001", true},
+		{"GB health authority", "GB", "GBHA599", true},
+		{"XI", "XI", "XI980780684", true},
+		{"ES format", "ES", "ESA12345674", true},
+		{"ES all digits", "ES", "ES123456789", false},
+		{"IE format", "IE", "IE6388047V", true},
+		{"GR alias", "GR", "EL123456789", true},
+		{"other country's prefix", "DE", "FR40303265045", false},
+		{"any country", "", "FR40303265045", true},
+		{"any country without prefix", "", "40303265045", false},
+		{"any country unknown prefix", "", "US123456789", false},
+		{"empty", "DE", "", false},
+		{"non-string", "DE", 136695976, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.VATNumber(tt.country)(tt.value))
+		})
+	}
+}
+
+func TestVATNumber_UnsupportedCountry(t *testing.T) {
+	assert.PanicsWithValue(t, `is: unsupported VAT country "US" (supported: AT, BE, BG, CY, CZ, DE, DK, EE, EL, ES, FI, FR, GB, HR, HU, IE, IT, LT, LU, LV, MT, NL, PL, PT, RO, SE, SI, SK, XI)`, func() {
+		is.VATNumber("us")
+	})
+}
+
+func TestTaxID_DefaultMessages(t *testing.T) {
+	v := datacop.New()
+	v.Field("ssn", "000-00-0000").Validate(is.SSN())
+	v.Field("ein", "00").Validate(is.EIN())
+	v.Field("vat", "DE1").Validate(is.VATNumber("DE"))
+
+	assert.Equal(t, map[string][]string{"ssn": {"ssn"}, "ein": {"ein"}, "vat": {"vat_number"}}, v.FailedRules())
+	assert.Equal(t, "must be a valid Social Security number", v.Errors()["ssn"])
+	assert.Equal(t, "must be a valid Employer Identification Number", v.Errors()["ein"])
+	assert.Equal(t, "must be a valid VAT number", v.Errors()["vat"])
+}
@@ -0,0 +1,182 @@
+package is
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/patrickward/datacop"
+)
+
+// nameScripts maps locale language subtags to the scripts their names are written in
+var nameScripts = map[string][]*unicode.RangeTable{
+	"ar": {unicode.Arabic},
+	"el": {unicode.Greek},
+	"en": {unicode.Latin},
+	"es": {unicode.Latin},
+	"fa": {unicode.Arabic},
+	"fr": {unicode.Latin},
+	"de": {unicode.Latin},
+	"he": {unicode.Hebrew},
+	"hi": {unicode.Devanagari},
+	"it": {unicode.Latin},
+	"ja": {unicode.Han, unicode.Hiragana, unicode.Katakana},
+	"ko": {unicode.Hangul, unicode.Han},
+	"nl": {unicode.Latin},
+	"pl": {unicode.Latin},
+	"pt": {unicode.Latin},
+	"ru": {unicode.Cyrillic},
+	"sv": {unicode.Latin},
+	"th": {unicode.Thai},
+	"tr": {unicode.Latin},
+	"uk": {unicode.Cyrillic},
+	"vi": {unicode.Latin},
+	"zh": {unicode.Han},
+}
+
+// HumanName returns a validation function that checks if a string is a plausible personal name.
+// Letters from any script are accepted along with combining marks (diacritics), spaces, and the
+// apostrophes, hyphens, and periods found in names like "O'Brien", "Jean-Luc", and "J. R.".
+// Digits, symbols, and other punctuation are rejected, and a name must contain at least one letter.
+//
+// When locales are given (e.g. "ru", "ja", "pt-BR"), letters are further restricted to the scripts
+// used by those locales; unrecognised locales accept any script.
+//
+// Example usage:
+// HumanName()("Zoë O'Brien-Núñez") // returns true
+// HumanName()("Jürgen 2") // returns false
+// HumanName("ru")("Анна") // returns true
+// HumanName("ru")("Anna") // returns false
+func HumanName(locales ...string) datacop.NamedRule {
+	var scripts []*unicode.RangeTable
+	for _, locale := range locales {
+		lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+		lang, _, _ = strings.Cut(lang, "_")
+		tables, ok := nameScripts[lang]
+		if !ok {
+			scripts = nil
+			break
+		}
+		scripts = append(scripts, tables...)
+	}
+
+	return pure("human_name", datacop.Params{"locales": locales}, func(value any) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		str = strings.TrimSpace(str)
+		if str == "" || !utf8.ValidString(str) {
+			return false
+		}
+
+		hasLetter := false
+		for _, r := range str {
+			switch {
+			case unicode.IsLetter(r):
+				if len(scripts) > 0 && !unicode.In(r, scripts...) {
+					return false
+				}
+				hasLetter = true
+			case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Mc, r):
+				// combining diacritics
+			case r == ' ', r == '\'', r == '’', r == '-', r == '‐', r == '.':
+				// name punctuation
+			default:
+				return false
+			}
+		}
+		return hasLetter
+	})
+}
+
+// NoBidiControl checks that a string contains no Unicode bidirectional formatting characters,
+// such as RIGHT-TO-LEFT OVERRIDE (U+202E). These invisible characters reorder how text is
+// displayed and are used to disguise filenames ("invoice\u202Efdp.exe") and spoof display text.
+//
+// The rejected characters are the embeddings and overrides (U+202A–U+202E), the isolates
+// (U+2066–U+2069), and the implicit marks LRM (U+200E), RLM (U+200F), and ALM (U+061C).
+//
+// Example usage:
+// NoBidiControl("report.pdf") // returns true
+// NoBidiControl("report\u202Efdp.exe") // returns false
+func NoBidiControl(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return !strings.ContainsFunc(str, isBidiControl)
+}
+
+// isBidiControl reports whether r is a Unicode bidirectional formatting character
+func isBidiControl(r rune) bool {
+	switch {
+	case r >= '\u202A' && r <= '\u202E':
+		return true
+	case r >= '\u2066' && r <= '\u2069':
+		return true
+	case r == '\u200E', r == '\u200F', r == '\u061C':
+		return true
+	}
+	return false
+}
+
+// NoLeadingTrailingSpace checks that a string does not start or end with white space, including
+// Unicode spaces such as NO-BREAK SPACE (U+00A0)
+//
+// Example usage:
+// NoLeadingTrailingSpace("Jane Doe") // returns true
+// NoLeadingTrailingSpace(" Jane Doe\u00A0") // returns false
+func NoLeadingTrailingSpace(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return str == strings.TrimSpace(str)
+}
+
+// SingleLine checks that a string contains no line breaks: line feed, carriage return, vertical
+// tab, form feed, NEXT LINE (U+0085), LINE SEPARATOR (U+2028), or PARAGRAPH SEPARATOR (U+2029)
+//
+// Example usage:
+// SingleLine("Jane Doe") // returns true
+// SingleLine("Jane\nDoe") // returns false
+func SingleLine(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return !strings.ContainsAny(str, "\n\r\v\f\u0085\u2028\u2029")
+}
+
+// NoControlChars checks that a string contains no control characters (Unicode category Cc),
+// such as NUL or ESC, other than tab, line feed, and carriage return. Combine it with SingleLine
+// to reject line breaks as well, and with NoBidiControl to reject invisible formatting characters.
+//
+// Example usage:
+// NoControlChars("line one\nline two") // returns true
+// NoControlChars("bell\x07") // returns false
+func NoControlChars(value any) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	return !strings.ContainsFunc(str, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r'
+	})
+}
+
+// UTF8 checks that a string or byte slice is valid UTF-8
+//
+// Example usage:
+// UTF8("héllo") // returns true
+// UTF8(string([]byte{0xff, 0xfe})) // returns false
+var UTF8 datacop.ValidationFunc = func(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return utf8.ValidString(v)
+	case []byte:
+		return utf8.Valid(v)
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestHumanName(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		value   any
+		want    bool
+	}{
+		{"simple name", nil, "Jane Doe", true},
+		{"apostrophe", nil, "Conan O'Brien", true},
+		{"typographic apostrophe", nil, "D’Angelo", true},
+		{"hyphen", nil, "Jean-Luc Picard", true},
+		{"initials", nil, "J. R. R. Tolkien", true},
+		{"diacritics", nil, "Zoë Núñez", true},
+		{"combining diacritic", nil, "Zoë", true},
+		{"cyrillic", nil, "Анна Каренина", true},
+		{"japanese", nil, "山田 太郎", true},
+		{"arabic", nil, "محمد", true},
+		{"digits", nil, "Jürgen 2", false},
+		{"symbols", nil, "jane@doe", false},
+		{"only punctuation", nil, "-'.", false},
+		{"empty", nil, "  ", false},
+		{"non-string value", nil, 42, false},
+		{"matching locale", []string{"ru"}, "Анна", true},
+		{"wrong script for locale", []string{"ru"}, "Anna", false},
+		{"multiple locales", []string{"ru", "en-US"}, "Anna Петрова", true},
+		{"region subtag", []string{"pt_BR"}, "João", true},
+		{"region subtag wrong script", []string{"pt-BR"}, "Анна", false},
+		{"unknown locale accepts any script", []string{"xx"}, "Анна", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.HumanName(tt.locales...)(tt.value))
+		})
+	}
+}
+
+func TestNoBidiControl(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"plain filename", "report.pdf", true},
+		{"right-to-left text", "שלום", true},
+		{"right-to-left override", "invoice\u202Efdp.exe", false},
+		{"left-to-right embedding", "a\u202Ab", false},
+		{"first strong isolate", "a\u2068b\u2069", false},
+		{"right-to-left mark", "abc\u200F", false},
+		{"arabic letter mark", "\u061Cabc", false},
+		{"non-string value", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.NoBidiControl(tt.value))
+		})
+	}
+}
+
+func TestNoLeadingTrailingSpace(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"no surrounding space", "Jane Doe", true},
+		{"empty string", "", true},
+		{"leading space", " Jane", false},
+		{"trailing newline", "Jane\n", false},
+		{"trailing no-break space", "Jane\u00A0", false},
+		{"leading tab", "\tJane", false},
+		{"non-string value", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.NoLeadingTrailingSpace(tt.value))
+		})
+	}
+}
+
+func TestSingleLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"single line", "Jane Doe", true},
+		{"tab is allowed", "Jane\tDoe", true},
+		{"line feed", "Jane\nDoe", false},
+		{"carriage return", "Jane\rDoe", false},
+		{"next line", "Jane\u0085Doe", false},
+		{"line separator", "Jane\u2028Doe", false},
+		{"paragraph separator", "Jane\u2029Doe", false},
+		{"non-string value", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.SingleLine(tt.value))
+		})
+	}
+}
+
+func TestNoControlChars(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"plain text", "hello world", true},
+		{"tabs and line breaks", "line one\r\n\tline two", true},
+		{"null byte", "abc\x00", false},
+		{"escape", "\x1b[31mred", false},
+		{"delete", "abc\x7f", false},
+		{"C1 control", "abc\u0090", false},
+		{"non-string value", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.NoControlChars(tt.value))
+		})
+	}
+}
+
+func TestUTF8(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"ascii", "hello", true},
+		{"multi-byte", "héllo 世界", true},
+		{"valid bytes", []byte("héllo"), true},
+		{"invalid string", string([]byte{'a', 0xff, 'b'}), false},
+		{"truncated sequence", string([]byte{0xe4, 0xb8}), false},
+		{"invalid bytes", []byte{0xfe, 0xff}, false},
+		{"non-string value", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.UTF8(tt.value))
+		})
+	}
+}
@@ -1,44 +1,221 @@
 package is
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/patrickward/datacop"
 )
 
-// Before checks if a time is before another
-func Before(t time.Time) datacop.ValidationFunc {
-	return func(value any) bool {
-		v, ok := value.(time.Time)
+// now returns the current time for validators that compare against it
+var now = time.Now
+
+// SetClock replaces the clock used by validators that compare against the current time, such as
+// MinAge and NotOlderThan, and returns a function that restores the previous clock. It is intended
+// for tests and is not safe to call while validations are running concurrently.
+//
+// Example usage:
+//
+//	restore := is.SetClock(func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) })
+//	defer restore()
+func SetClock(clock func() time.Time) (restore func()) {
+	previous := now
+	now = clock
+	return func() {
+		now = previous
+	}
+}
+
+// toTime converts a time.Time, a non-nil *time.Time, or an RFC 3339 string to a time.Time
+func toTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// Before checks if a time is before another.
+// Like the other time validators, it accepts time.Time, *time.Time, and RFC 3339 strings.
+func Before(t time.Time) datacop.NamedRule {
+	return pure("before", datacop.Params{"time": t}, func(value any) bool {
+		v, ok := toTime(value)
 		if !ok {
 			return false
 		}
 		return v.Before(t)
-	}
+	})
 }
 
 // After checks if a time is after another
-func After(t time.Time) datacop.ValidationFunc {
-	return func(value any) bool {
-		v, ok := value.(time.Time)
+func After(t time.Time) datacop.NamedRule {
+	return pure("after", datacop.Params{"time": t}, func(value any) bool {
+		v, ok := toTime(value)
 		if !ok {
 			return false
 		}
 		return v.After(t)
-	}
+	})
+}
+
+// BeforeOrEqual checks if a time is before or equal to another
+func BeforeOrEqual(t time.Time) datacop.NamedRule {
+	return pure("before_or_equal", datacop.Params{"time": t}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		return !v.After(t)
+	})
 }
 
-// BetweenTime checks if a value is between two other values
+// AfterOrEqual checks if a time is after or equal to another
+func AfterOrEqual(t time.Time) datacop.NamedRule {
+	return pure("after_or_equal", datacop.Params{"time": t}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		return !v.Before(t)
+	})
+}
+
+// BetweenTime checks if a value is between two other values, excluding the boundaries.
+// Use BetweenTimeInclusive to accept the boundaries as well.
 //
 // Example usage:
 // BetweenTime(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour))(time.Now()) // returns true
 // BetweenTime(time.Now().Add(-1*time.Hour), time.Now().Add(-30*time.Minute))(time.Now()) // returns false
-func BetweenTime(start, end time.Time) datacop.ValidationFunc {
-	return func(value any) bool {
-		v, ok := value.(time.Time)
+func BetweenTime(start, end time.Time) datacop.NamedRule {
+	return pure("between_time", datacop.Params{"start": start, "end": end}, func(value any) bool {
+		v, ok := toTime(value)
 		if !ok {
 			return false
 		}
 		return v.After(start) && v.Before(end)
+	})
+}
+
+// BetweenTimeInclusive checks if a value is between two other values, including the boundaries
+//
+// Example usage:
+// BetweenTimeInclusive(start, end)(start) // returns true
+func BetweenTimeInclusive(start, end time.Time) datacop.NamedRule {
+	return pure("between_time_inclusive", datacop.Params{"start": start, "end": end}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		return !v.Before(start) && !v.After(end)
+	})
+}
+
+// WithinDuration checks if a time is no further than d from the current time, in either direction
+//
+// Example usage:
+// WithinDuration(5*time.Minute)(time.Now().Add(2*time.Minute)) // returns true
+// WithinDuration(5*time.Minute)(time.Now().Add(-time.Hour)) // returns false
+func WithinDuration(d time.Duration) datacop.NamedRule {
+	return datacop.Named("within_duration", datacop.Params{"duration": d}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		diff := now().Sub(v)
+		return diff >= -d && diff <= d
+	})
+}
+
+// NotOlderThan checks if a time is no more than d before the current time. Times in the future pass.
+//
+// Example usage:
+// NotOlderThan(24*time.Hour)(lastSeen)
+func NotOlderThan(d time.Duration) datacop.NamedRule {
+	return datacop.Named("not_older_than", datacop.Params{"duration": d}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		return !v.Before(now().Add(-d))
+	})
+}
+
+// Timezone checks if a value is a valid IANA time zone name, such as "Europe/Paris".
+// The empty string and "Local" are rejected even though time.LoadLocation accepts them.
+//
+// Example usage:
+// Timezone("America/New_York") // returns true
+// Timezone("Mars/Olympus_Mons") // returns false
+func Timezone(value any) bool {
+	v, ok := value.(string)
+	if !ok || v == "" || v == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(v)
+	return err == nil
+}
+
+// Weekday checks if a time falls on one of the given days of the week, in the time's own location
+//
+// Example usage:
+// Weekday(time.Saturday, time.Sunday)(appointment)
+func Weekday(days ...time.Weekday) datacop.NamedRule {
+	return pure("weekday", datacop.Params{"days": days}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		for _, d := range days {
+			if v.Weekday() == d {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WithinBusinessHours checks if the time of day of a time is at or after start and before end,
+// both given in "15:04" format. The time is converted to loc first; a nil loc uses the time's own
+// location. It panics if start or end is not a valid time of day.
+//
+// Example usage:
+// WithinBusinessHours("09:00", "17:30", newYork)(appointment)
+func WithinBusinessHours(start, end string, loc *time.Location) datacop.NamedRule {
+	from := mustClock(start)
+	to := mustClock(end)
+
+	location := ""
+	if loc != nil {
+		location = loc.String()
+	}
+
+	return pure("within_business_hours", datacop.Params{"start": start, "end": end, "location": location}, func(value any) bool {
+		v, ok := toTime(value)
+		if !ok {
+			return false
+		}
+		if loc != nil {
+			v = v.In(loc)
+		}
+		t := time.Duration(v.Hour())*time.Hour + time.Duration(v.Minute())*time.Minute +
+			time.Duration(v.Second())*time.Second + time.Duration(v.Nanosecond())
+		return t >= from && t < to
+	})
+}
+
+// mustClock parses a "15:04" time of day into the duration since midnight
+func mustClock(s string) time.Duration {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		panic("is: invalid time of day " + strconv.Quote(s))
 	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
 }
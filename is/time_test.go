@@ -71,3 +71,211 @@ func TestBetweenTime(t *testing.T) {
 		})
 	}
 }
+
+func TestBeforeOrEqual(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		value time.Time
+		want  bool
+	}{
+		{"before time", now.Add(-time.Hour), true},
+		{"equal time", now, true},
+		{"after time", now.Add(time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.BeforeOrEqual(now)(tt.value))
+		})
+	}
+}
+
+func TestAfterOrEqual(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		value time.Time
+		want  bool
+	}{
+		{"after time", now.Add(time.Hour), true},
+		{"equal time", now, true},
+		{"before time", now.Add(-time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.AfterOrEqual(now)(tt.value))
+		})
+	}
+}
+
+func TestBetweenTimeInclusive(t *testing.T) {
+	now := time.Now()
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name  string
+		value time.Time
+		want  bool
+	}{
+		{"within range", now, true},
+		{"at start boundary", start, true},
+		{"at end boundary", end, true},
+		{"before range", start.Add(-time.Nanosecond), false},
+		{"after range", end.Add(time.Nanosecond), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.BetweenTimeInclusive(start, end)(tt.value))
+		})
+	}
+}
+
+func TestTimeValueTypes(t *testing.T) {
+	ref := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	earlier := ref.Add(-time.Hour)
+	var nilTime *time.Time
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"time value", earlier, true},
+		{"time pointer", &earlier, true},
+		{"RFC 3339 string", "2024-06-01T11:00:00Z", true},
+		{"RFC 3339 string with offset", "2024-06-01T13:00:00+02:00", true},
+		{"nil time pointer", nilTime, false},
+		{"non RFC 3339 string", "2024-06-01 11:00", false},
+		{"unsupported type", 1717239600, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.Before(ref)(tt.value))
+		})
+	}
+}
+
+func TestWithinDuration(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	restore := is.SetClock(func() time.Time { return fixed })
+	defer restore()
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"now", fixed, true},
+		{"in the past within duration", fixed.Add(-4 * time.Minute), true},
+		{"in the future within duration", fixed.Add(5 * time.Minute), true},
+		{"too far in the past", fixed.Add(-6 * time.Minute), false},
+		{"too far in the future", fixed.Add(6 * time.Minute), false},
+		{"RFC 3339 string", "2024-06-01T12:03:00Z", true},
+		{"not a time", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.WithinDuration(5*time.Minute)(tt.value))
+		})
+	}
+}
+
+func TestNotOlderThan(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	restore := is.SetClock(func() time.Time { return fixed })
+	defer restore()
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"recent", fixed.Add(-time.Hour), true},
+		{"exactly at the limit", fixed.Add(-24 * time.Hour), true},
+		{"too old", fixed.Add(-25 * time.Hour), false},
+		{"in the future", fixed.Add(time.Hour), true},
+		{"not a time", "yesterday", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.NotOlderThan(24*time.Hour)(tt.value))
+		})
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"region zone", "America/New_York", true},
+		{"UTC", "UTC", true},
+		{"unknown zone", "Mars/Olympus_Mons", false},
+		{"empty", "", false},
+		{"local", "Local", false},
+		{"not a string", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.Timezone(tt.value))
+		})
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	saturday := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		days  []time.Weekday
+		value any
+		want  bool
+	}{
+		{"matching day", []time.Weekday{time.Saturday, time.Sunday}, saturday, true},
+		{"other day", []time.Weekday{time.Monday}, saturday, false},
+		{"no days", nil, saturday, false},
+		{"not a time", []time.Weekday{time.Saturday}, "2024-06-01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.Weekday(tt.days...)(tt.value))
+		})
+	}
+}
+
+func TestWithinBusinessHours(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	tests := []struct {
+		name  string
+		loc   *time.Location
+		value any
+		want  bool
+	}{
+		{"opening time", nil, time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC), true},
+		{"during hours", nil, time.Date(2024, 6, 3, 13, 15, 0, 0, time.UTC), true},
+		{"closing time", nil, time.Date(2024, 6, 3, 17, 30, 0, 0, time.UTC), false},
+		{"before opening", nil, time.Date(2024, 6, 3, 8, 59, 59, 0, time.UTC), false},
+		{"converted to location", newYork, time.Date(2024, 6, 3, 14, 0, 0, 0, time.UTC), true},
+		{"outside hours in location", newYork, time.Date(2024, 6, 3, 22, 0, 0, 0, time.UTC), false},
+		{"not a time", nil, "09:30", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.WithinBusinessHours("09:00", "17:30", tt.loc)(tt.value))
+		})
+	}
+
+	assert.Panics(t, func() { is.WithinBusinessHours("9am", "17:00", nil) })
+}
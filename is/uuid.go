@@ -0,0 +1,33 @@
+package is
+
+// UUID checks if a value is a UUID in its canonical textual form of 32 hexadecimal digits in
+// groups of 8-4-4-4-12, in either case. Any version is accepted.
+//
+// Example usage:
+// UUID("f47ac10b-58cc-4372-a567-0e02b2c3d479") // returns true
+// UUID("f47ac10b58cc4372a5670e02b2c3d479") // returns false
+// UUID("{f47ac10b-58cc-4372-a567-0e02b2c3d479}") // returns false
+func UUID(value any) bool {
+	str, ok := value.(string)
+	if !ok || len(str) != 36 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
@@ -0,0 +1,34 @@
+package is_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/is"
+)
+
+func TestUUID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"version 4", "f47ac10b-58cc-4372-a567-0e02b2c3d479", true},
+		{"upper case", "F47AC10B-58CC-4372-A567-0E02B2C3D479", true},
+		{"nil UUID", "00000000-0000-0000-0000-000000000000", true},
+		{"no hyphens", "f47ac10b58cc4372a5670e02b2c3d479", false},
+		{"braces", "{f47ac10b-58cc-4372-a567-0e02b2c3d479}", false},
+		{"misplaced hyphen", "f47ac10b5-8cc-4372-a567-0e02b2c3d479", false},
+		{"non-hex digit", "g47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"too short", "f47ac10b-58cc-4372-a567-0e02b2c3d47", false},
+		{"empty", "", false},
+		{"non-string", 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.UUID(tt.value))
+		})
+	}
+}
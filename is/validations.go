@@ -1,7 +1,6 @@
 package is
 
 import (
-	"reflect"
 	"regexp"
 	"strings"
 	"time"
@@ -9,7 +8,16 @@ import (
 	"github.com/patrickward/datacop"
 )
 
-// Required checks if a value is non-empty
+// Zeroer is implemented by types that report whether they hold their zero value, such as
+// time.Time and netip.Addr. Required treats values of other types implementing it as present
+// when IsZero returns false, without resorting to reflection.
+type Zeroer interface {
+	IsZero() bool
+}
+
+// Required checks if a value is non-empty. Types not handled directly are checked with Zeroer,
+// if they implement it, and otherwise by reflection, limited in TinyGo and playground builds to
+// what TinyGo supports, with the same results.
 //
 // Example usage:
 // Required("some value") // returns true
@@ -17,35 +25,38 @@ import (
 // Required([]int{1, 2, 3}) // returns true
 // Required([]int{}) // returns false
 func Required(value any) bool {
-	if value == nil {
+	// Common types are handled without reflection; see requiredReflect for the rest
+	switch v := value.(type) {
+	case nil:
 		return false
+	case string:
+		return strings.TrimSpace(v) != ""
+	case bool:
+		return v
+	case int:
+		return v != 0
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case time.Time:
+		return !v.IsZero()
+	case []string:
+		return len(v) > 0
+	case []int:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	case map[string]string:
+		return len(v) > 0
+	case *string:
+		return v != nil && Required(*v)
+	case *time.Time:
+		return v != nil && !v.IsZero()
 	}
-
-	v := reflect.ValueOf(value)
-	switch v.Kind() {
-	case reflect.String:
-		return strings.TrimSpace(v.String()) != ""
-	case reflect.Slice, reflect.Array:
-		return v.Len() > 0
-	case reflect.Map:
-		return v.Len() > 0
-	case reflect.Struct:
-		if t, ok := value.(time.Time); ok {
-			return !t.IsZero()
-		}
-		// For other structs, we could either:
-		// 1. Consider them always required (return true)
-		// 2. Check if they're zero value (return !reflect.DeepEqual(value, reflect.Zero(v.Type()).Interface()))
-		return true
-	case reflect.Ptr:
-		if v.IsNil() {
-			return false
-		}
-		return Required(v.Elem().Interface())
-	default:
-		// For basic types (int, float, etc), check if they're zero value
-		return value != reflect.Zero(v.Type()).Interface()
-	}
+	return requiredReflect(value)
 }
 
 // NotZero checks if a numeric value is not a zero value
@@ -58,13 +69,37 @@ func NotZero[T comparable](value T) bool {
 // Example usage:
 // Match(`^[a-zA-Z0-9]+$`)(username) // returns true if username is alphanumeric
 // Match(`^[a-zA-Z0-9]+$`)(email) // returns false if email is not alphanumeric
-func Match(pattern string) datacop.ValidationFunc {
+func Match(pattern string) datacop.NamedRule {
 	regex := regexp.MustCompile(pattern)
-	return func(value any) bool {
+	return pure("match", datacop.Params{"pattern": pattern}, func(value any) bool {
 		str, ok := value.(string)
 		if !ok {
 			return false
 		}
 		return regex.MatchString(str)
+	})
+}
+
+// Accepted checks if a value affirmatively accepts something, as a terms-of-service or consent
+// checkbox must. It accepts true, 1, and the strings "true", "on", "1", and "yes" (case-insensitive).
+// Missing, false, and any other values are not accepted.
+//
+// Example usage:
+// Accepted("on") // returns true
+// Accepted(true) // returns true
+// Accepted("") // returns false
+// Accepted("no") // returns false
+func Accepted(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int:
+		return v == 1
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "on", "1", "yes":
+			return true
+		}
 	}
+	return false
 }
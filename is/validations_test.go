@@ -22,6 +22,28 @@ func TestRequired(t *testing.T) {
 		{"non-zero time", time.Now(), true},
 		{"zero time", time.Time{}, false},
 		{"nil value", nil, false},
+		{"non-zero int", 5, true},
+		{"zero int", 0, false},
+		{"zero uint8", uint8(0), false},
+		{"true bool", true, true},
+		{"non-empty map", map[string]any{"a": 1}, true},
+		{"empty map", map[string]string{}, false},
+		{"pointer to string", ptr("test"), true},
+		{"pointer to blank string", ptr("  "), false},
+		{"nil pointer", (*string)(nil), false},
+		{"struct", struct{ Name string }{}, true},
+		{"non-zero Zeroer", money{cents: 100}, true},
+		{"zero Zeroer", money{}, false},
+		{"nil Zeroer pointer", (*money)(nil), false},
+		{"empty int64 slice", []int64{}, false},
+		{"non-empty int64 slice", []int64{1}, true},
+		{"empty int map", map[int]int{}, false},
+		{"nil int64 pointer", (*int64)(nil), false},
+		{"pointer to zero int64", new(int64), false},
+		{"non-zero int16", int16(3), true},
+		{"zero float32", float32(0), false},
+		{"blank named string", nickname("  "), false},
+		{"array", [2]int{}, true},
 	}
 
 	for _, tt := range tests {
@@ -65,3 +87,41 @@ func TestMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestAccepted(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"bool true", true, true},
+		{"bool false", false, false},
+		{"on", "on", true},
+		{"yes mixed case", "Yes", true},
+		{"true string", "true", true},
+		{"one string", "1", true},
+		{"one int", 1, true},
+		{"zero int", 0, false},
+		{"empty string", "", false},
+		{"no", "no", false},
+		{"off", "off", false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, is.Accepted(tt.value))
+		})
+	}
+}
+
+// money implements is.Zeroer
+type money struct{ cents int64 }
+
+func (m money) IsZero() bool { return m.cents == 0 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+type nickname string
@@ -0,0 +1,75 @@
+package datacop
+
+import "encoding/json"
+
+// ProblemContentType is the media type for RFC 7807 problem details responses
+const ProblemContentType = "application/problem+json"
+
+// DetailedError is the JSON representation of a single error in detailed output.
+// Standalone errors have no field.
+type DetailedError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Params  Params `json:"params,omitempty"`
+
+	Position *Position      `json:"position,omitempty"` // where the field appears in the submitted document
+	Context  map[string]any `json:"context,omitempty"`  // metadata set with SetContextValue
+}
+
+// ProblemDetails is an RFC 7807 problem details object carrying validation errors as an
+// "errors" extension member
+type ProblemDetails struct {
+	Type   string          `json:"type"`
+	Title  string          `json:"title"`
+	Status int             `json:"status,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Errors []DetailedError `json:"errors"`
+}
+
+// DetailedErrors returns every error in the order reported by OrderedErrors, with the
+// default code filled in for errors recorded without one
+func (v *Validator) DetailedErrors() []DetailedError {
+	ordered := v.OrderedErrors()
+	errs := make([]DetailedError, len(ordered))
+	for i, err := range ordered {
+		errs[i] = DetailedError{Field: err.Field, Code: err.Code, Message: err.Message, Params: err.Params, Position: err.Position, Context: err.Context}
+		if errs[i].Field == StandaloneErrorKey {
+			errs[i].Field = ""
+		}
+		if errs[i].Code == "" {
+			errs[i].Code = DefaultErrorCode
+		}
+	}
+	return errs
+}
+
+// MarshalJSONDetailed encodes every error individually, preserving multiple messages per
+// field with their codes and params:
+//
+//	{"errors":[{"field":"password","code":"min_length","message":"too short","params":{"min":8}}]}
+func (v *Validator) MarshalJSONDetailed() ([]byte, error) {
+	return json.Marshal(struct {
+		Errors []DetailedError `json:"errors"`
+	}{
+		Errors: v.DetailedErrors(),
+	})
+}
+
+// ToProblemDetails returns the errors as an RFC 7807 problem details object with the given
+// HTTP status. Encode it with encoding/json and serve it with ProblemContentType.
+//
+// Example usage:
+//
+//	w.Header().Set("Content-Type", datacop.ProblemContentType)
+//	w.WriteHeader(http.StatusUnprocessableEntity)
+//	json.NewEncoder(w).Encode(v.ToProblemDetails(http.StatusUnprocessableEntity))
+func (v *Validator) ToProblemDetails(status int) ProblemDetails {
+	return ProblemDetails{
+		Type:   "about:blank",
+		Title:  "Validation failed",
+		Status: status,
+		Detail: "The request contains invalid fields.",
+		Errors: v.DetailedErrors(),
+	}
+}
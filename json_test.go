@@ -0,0 +1,53 @@
+package datacop_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestValidator_MarshalJSONDetailed(t *testing.T) {
+	v := datacop.New()
+	v.Check(false, "email", "invalid email format")
+	v.CheckCode(false, "email", "taken", "email is already registered")
+	v.CheckStandalone(false, "request could not be processed")
+
+	data, err := v.MarshalJSONDetailed()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"errors":[
+		{"field":"email","code":"invalid","message":"invalid email format"},
+		{"field":"email","code":"taken","message":"email is already registered"},
+		{"code":"invalid","message":"request could not be processed"}
+	]}`, string(data))
+}
+
+func TestValidator_MarshalJSONDetailed_Empty(t *testing.T) {
+	data, err := datacop.New().MarshalJSONDetailed()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors":[]}`, string(data))
+}
+
+func TestValidator_ToProblemDetails(t *testing.T) {
+	v := datacop.New()
+	v.CheckCode(false, "age", "too_young", "must be 18 or older")
+
+	problem := v.ToProblemDetails(http.StatusUnprocessableEntity)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, "about:blank", problem.Type)
+
+	data, err := json.Marshal(problem)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type":"about:blank",
+		"title":"Validation failed",
+		"status":422,
+		"detail":"The request contains invalid fields.",
+		"errors":[{"field":"age","code":"too_young","message":"must be 18 or older"}]
+	}`, string(data))
+}
@@ -0,0 +1,151 @@
+package jwtcheck
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// Check parses token and validates its claims, recording failures on v.
+// It returns the decoded claims, or nil if the token could not be parsed.
+type Check func(v *datacop.Validator, token string) map[string]any
+
+// Claims returns a Check that parses tokens with p and applies the rule set for each claim name.
+// Failures are recorded as field errors keyed by claim name. Tokens that cannot be parsed or
+// verified are recorded as a standalone error.
+//
+// Example usage:
+//
+//	check := jwtcheck.Claims(jwtcheck.NewParser(jwtcheck.HS256(secret)), map[string]datacop.RuleSet{
+//		"exp": {{Func: jwtcheck.NotExpired(time.Now), Message: "token has expired"}},
+//		"iss": {{Func: jwtcheck.Issuer("https://auth.example.com"), Message: "unknown issuer"}},
+//		"sub": {{Func: is.Required, Message: "subject is required"}},
+//	})
+//
+//	v := datacop.New()
+//	claims := check(v, token)
+func Claims(p *Parser, rules map[string]datacop.RuleSet) Check {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return func(v *datacop.Validator, token string) map[string]any {
+		_, claims, err := p.Parse(token)
+		if err != nil {
+			v.AddStandaloneError(err.Error())
+			return nil
+		}
+
+		for _, name := range names {
+			rules[name].Apply(v, name, claims[name])
+		}
+		return claims
+	}
+}
+
+// NotExpired checks that a numeric date claim (such as exp) is after the current time.
+// The now function supplies the current time; nil uses time.Now.
+func NotExpired(now func() time.Time) datacop.NamedRule {
+	return datacop.Named("not_expired", nil, func(value any) bool {
+		t, ok := numericDate(value)
+		if !ok {
+			return false
+		}
+		return clock(now)().Before(t)
+	})
+}
+
+// NotBefore checks that a numeric date claim (such as nbf or iat) is not after the current time.
+// The now function supplies the current time; nil uses time.Now.
+func NotBefore(now func() time.Time) datacop.NamedRule {
+	return datacop.Named("not_before", nil, func(value any) bool {
+		t, ok := numericDate(value)
+		if !ok {
+			return false
+		}
+		return !clock(now)().Before(t)
+	})
+}
+
+// Audience checks that an aud claim, either a string or an array of strings, contains aud
+//
+// Example usage:
+// Audience("api")("api") // returns true
+// Audience("api")([]any{"web", "api"}) // returns true
+// Audience("api")("web") // returns false
+func Audience(aud string) datacop.NamedRule {
+	return datacop.Named("audience", datacop.Params{"audience": aud}, func(value any) bool {
+		switch v := value.(type) {
+		case string:
+			return v == aud
+		case []any:
+			for _, a := range v {
+				if s, ok := a.(string); ok && s == aud {
+					return true
+				}
+			}
+		case []string:
+			return slices.Contains(v, aud)
+		}
+		return false
+	})
+}
+
+// Issuer checks that an iss claim is one of the trusted issuers
+//
+// Example usage:
+// Issuer("https://auth.example.com")("https://auth.example.com") // returns true
+// Issuer("https://auth.example.com")("https://evil.example.com") // returns false
+func Issuer(issuers ...string) datacop.NamedRule {
+	return datacop.Named("issuer", datacop.Params{"issuers": issuers}, func(value any) bool {
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		return slices.Contains(issuers, s)
+	})
+}
+
+// maxNumericDate is the largest number of seconds accepted in a NumericDate claim, the point
+// past which float64 seconds are no longer exact
+const maxNumericDate = 1 << 53
+
+// numericDate converts a JWT NumericDate claim value into a time. Values that are not finite or
+// lie beyond maxNumericDate seconds from the epoch are rejected.
+func numericDate(value any) (time.Time, bool) {
+	var secs float64
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		secs = f
+	case float64:
+		secs = v
+	case int64:
+		secs = float64(v)
+	case int:
+		secs = float64(v)
+	default:
+		return time.Time{}, false
+	}
+	if math.IsNaN(secs) || math.Abs(secs) > maxNumericDate {
+		return time.Time{}, false
+	}
+
+	whole := math.Floor(secs)
+	return time.Unix(int64(whole), int64((secs-whole)*float64(time.Second))), true
+}
+
+func clock(now func() time.Time) func() time.Time {
+	if now == nil {
+		return time.Now
+	}
+	return now
+}
@@ -0,0 +1,155 @@
+package jwtcheck_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+	"github.com/patrickward/datacop/jwtcheck"
+)
+
+func TestClaims(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	check := jwtcheck.Claims(jwtcheck.NewParser(jwtcheck.HS256(secret)), map[string]datacop.RuleSet{
+		"exp": {{Func: jwtcheck.NotExpired(clock), Message: "token has expired"}},
+		"aud": {{Func: jwtcheck.Audience("api"), Message: "invalid audience"}},
+		"iss": {{Func: jwtcheck.Issuer("https://auth.example.com"), Message: "unknown issuer"}},
+		"sub": {{Func: is.Required, Message: "subject is required"}},
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid claims",
+			token: sign(t, secret, map[string]any{
+				"exp": now.Add(time.Hour).Unix(),
+				"aud": []string{"web", "api"},
+				"iss": "https://auth.example.com",
+				"sub": "user-1",
+			}),
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "invalid claims",
+			token: sign(t, secret, map[string]any{
+				"exp": now.Add(-time.Hour).Unix(),
+				"aud": "web",
+				"iss": "https://evil.example.com",
+			}),
+			wantErrors: map[string]string{
+				"exp": "token has expired",
+				"aud": "invalid audience",
+				"iss": "unknown issuer",
+				"sub": "subject is required",
+			},
+		},
+		{
+			name:  "bad signature",
+			token: sign(t, []byte("other"), map[string]any{"sub": "user-1"}),
+			wantErrors: map[string]string{
+				datacop.StandaloneErrorKey: jwtcheck.ErrSignature.Error(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			check(v, tt.token)
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestNotExpired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"future json number", json.Number("1700003600"), true},
+		{"past json number", json.Number("1699996400"), false},
+		{"future float", float64(1700003600), true},
+		{"fractional seconds", json.Number("1700000000.5"), true},
+		{"equal to now", int64(1700000000), false},
+		{"after 2262", json.Number("9999999999"), true},
+		{"not a number", math.NaN(), false},
+		{"infinite", math.Inf(1), false},
+		{"out of range", json.Number("1e300"), false},
+		{"non-numeric", "tomorrow", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jwtcheck.NotExpired(clock)(tt.value))
+		})
+	}
+}
+
+func TestNotBefore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"in the past", json.Number("1699996400"), true},
+		{"equal to now", json.Number("1700000000"), true},
+		{"in the future", json.Number("1700003600"), false},
+		{"missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jwtcheck.NotBefore(clock)(tt.value))
+		})
+	}
+}
+
+func TestAudience(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"matching string", "api", true},
+		{"non-matching string", "web", false},
+		{"array containing audience", []any{"web", "api"}, true},
+		{"array without audience", []any{"web"}, false},
+		{"non-string value", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jwtcheck.Audience("api")(tt.value))
+		})
+	}
+}
+
+func TestRuleNames(t *testing.T) {
+	assert.Equal(t, "not_expired", jwtcheck.NotExpired(nil).Name())
+	assert.Equal(t, "not_before", jwtcheck.NotBefore(nil).Name())
+	assert.Equal(t, datacop.Params{"audience": "api"}, jwtcheck.Audience("api").Params())
+	assert.Equal(t, datacop.Params{"issuers": []string{"a", "b"}}, jwtcheck.Issuer("a", "b").Params())
+}
+
+func TestIssuer(t *testing.T) {
+	assert.True(t, jwtcheck.Issuer("a", "b")("b"))
+	assert.False(t, jwtcheck.Issuer("a", "b")("c"))
+	assert.False(t, jwtcheck.Issuer("a")(nil))
+}
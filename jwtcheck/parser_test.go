@@ -0,0 +1,61 @@
+package jwtcheck_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/jwtcheck"
+)
+
+var secret = []byte("test-secret")
+
+// sign builds an HS256 compact token for the given claims
+func sign(t *testing.T, key []byte, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(jwtcheck.Header{Alg: "HS256", Typ: "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestParser_Parse(t *testing.T) {
+	token := sign(t, secret, map[string]any{"sub": "user-1"})
+
+	tests := []struct {
+		name    string
+		parser  *jwtcheck.Parser
+		token   string
+		wantErr error
+	}{
+		{"valid signature", jwtcheck.NewParser(jwtcheck.HS256(secret)), token, nil},
+		{"wrong secret", jwtcheck.NewParser(jwtcheck.HS256([]byte("other"))), token, jwtcheck.ErrSignature},
+		{"verification disabled", jwtcheck.NewParser(nil), sign(t, []byte("other"), map[string]any{"sub": "user-1"}), nil},
+		{"too few segments", jwtcheck.NewParser(nil), "abc.def", jwtcheck.ErrMalformed},
+		{"bad encoding", jwtcheck.NewParser(nil), "a!b.c!d.e!f", jwtcheck.ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, claims, err := tt.parser.Parse(tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "HS256", header.Alg)
+			assert.Equal(t, "user-1", claims["sub"])
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package datacop
+
+import "strings"
+
+// SetLabel sets the human-friendly name of a field, such as "Date of Birth" for "dob". The
+// {field} placeholder in the field's messages is replaced with the label instead of the field
+// name when the messages are read, such as by Errors, Error, and MarshalJSON, so internal names
+// do not surface to end users and the label may be set after the checks run. Errors are still
+// keyed by field name.
+//
+// Example usage:
+//
+//	v.SetLabel("dob", "Date of Birth")
+//	v.Check(dob != "", "dob", "{field} is required") // Errors()["dob"] is "Date of Birth is required"
+func (v *Validator) SetLabel(field, label string) {
+	if v.labels == nil {
+		v.labels = make(map[string]string)
+	}
+	v.labels[field] = label
+}
+
+// Label returns the label set for field with SetLabel, or the field name if it has none
+func (v *Validator) Label(field string) string {
+	if label, ok := v.labels[v.prefix+field]; ok {
+		return label
+	}
+	return v.prefix + field
+}
+
+// Labels returns a copy of the labels set with SetLabel, keyed by field name
+func (v *Validator) Labels() map[string]string {
+	labels := make(map[string]string, len(v.labels))
+	for field, label := range v.labels {
+		labels[field] = label
+	}
+	return labels
+}
+
+// WithLabels sets the labels of several fields, as SetLabel does
+//
+// Example usage:
+// v := datacop.New(datacop.WithLabels(map[string]string{"dob": "Date of Birth"}))
+func WithLabels(labels map[string]string) Option {
+	return func(v *Validator) {
+		for field, label := range labels {
+			v.SetLabel(field, label)
+		}
+	}
+}
+
+// UnlabeledErrors returns the errors in the order of OrderedErrors, with their messages as
+// recorded: the {field} placeholder is not yet replaced with the field's label. Message catalogs
+// use it to look up message templates.
+func (v *Validator) UnlabeledErrors() []ValidationError {
+	v.Wait()
+	var errs []ValidationError
+	for _, field := range v.order {
+		errs = append(errs, v.errors[field]...)
+	}
+	return errs
+}
+
+// labeled returns e with the {field} placeholder in its message replaced with the field's label
+func (v *Validator) labeled(e ValidationError) ValidationError {
+	if e.Field == StandaloneErrorKey || !strings.Contains(e.Message, "{field}") {
+		return e
+	}
+	e.Message = Interpolate(e.Message, Params{"field": v.Label(e.Field)})
+	return e
+}
@@ -0,0 +1,54 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestValidator_SetLabel(t *testing.T) {
+	v := datacop.New()
+	v.SetLabel("dob", "Date of Birth")
+	v.SetLabel("password", "Password")
+
+	v.Check(false, "dob", "{field} is required")
+	v.Field("password", "abc").Validate(is.MinLength(8), "{field} must be at least {min} characters")
+	v.Checkf(false, "terms", "{field} must be accepted", nil)
+	v.AddStandaloneError("{field} is not replaced in standalone errors")
+
+	assert.Equal(t, map[string]string{
+		"dob":                      "Date of Birth is required",
+		"password":                 "Password must be at least 8 characters",
+		"terms":                    "terms must be accepted",
+		datacop.StandaloneErrorKey: "{field} is not replaced in standalone errors",
+	}, v.Errors())
+	assert.Contains(t, v.Error(), "dob: [Date of Birth is required]")
+}
+
+func TestValidator_SetLabel_AfterChecks(t *testing.T) {
+	v := datacop.New()
+	v.Check(false, "dob", "{field} is required")
+	assert.Equal(t, "dob is required", v.ErrorFor("dob"))
+
+	v.SetLabel("dob", "Date of Birth")
+	assert.Equal(t, "Date of Birth is required", v.ErrorFor("dob"))
+	assert.Equal(t, "Date of Birth is required", v.OrderedErrors()[0].Message)
+	assert.Equal(t, "{field} is required", v.UnlabeledErrors()[0].Message)
+
+	data, err := v.MarshalJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"fields":{"dob":"Date of Birth is required"}}`, string(data))
+}
+
+func TestValidator_Label(t *testing.T) {
+	v := datacop.New(datacop.WithLabels(map[string]string{"dob": "Date of Birth"}))
+	assert.Equal(t, "Date of Birth", v.Label("dob"))
+	assert.Equal(t, "email", v.Label("email"))
+
+	labels := v.Labels()
+	labels["dob"] = "changed"
+	assert.Equal(t, "Date of Birth", v.Label("dob"), "Labels returns a copy")
+}
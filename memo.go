@@ -0,0 +1,75 @@
+package datacop
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// memoKey identifies the evaluation of a rule against a value
+type memoKey struct {
+	rule  string // the rule's name and params
+	value any
+}
+
+// WithIntraRequestMemo makes the validator remember the result of each memoized rule for each
+// value it was evaluated against, so validating the same value with the same rule again, for
+// example from a composite rule set and an explicit chain, reuses the result. Since a validator
+// typically lives for one request, results are never reused across requests.
+//
+// The built-in rules of the is package whose results depend only on their params and the value,
+// such as is.Required and is.MinLength(8), are memoized, as are rules registered with
+// RegisterRule and rules returned by Memoized. Rules are identified by their name and params, so
+// two calls to is.MinLength(8) share results. Rules created with Annotated always run. Only
+// string, boolean, and numeric values are memoized.
+//
+// Example usage:
+// v := datacop.New(datacop.WithIntraRequestMemo())
+func WithIntraRequestMemo() Option {
+	return func(v *Validator) {
+		v.memo = make(map[memoKey]bool)
+	}
+}
+
+// Memoized returns a copy of fn whose results a validator created with WithIntraRequestMemo
+// may reuse for equal values. fn must have a name, and its result must depend on nothing but
+// its params and the value, not on the time or a database, since every rule with the same name
+// and params shares the results.
+//
+// Example usage:
+//
+//	func MultipleOf(n int) datacop.NamedRule {
+//		return datacop.Named("multiple_of", datacop.Params{"n": n}, func(value any) bool {
+//			...
+//		}).Memoized()
+//	}
+func (fn ValidationFunc) Memoized() NamedRule {
+	m := metaOf(fn)
+	m.memoized = true
+	return m.attach(fn)
+}
+
+// memoKeyFor returns the memo key for evaluating the rule described by info against value, and
+// false if the evaluation cannot be memoized
+func (v *Validator) memoKeyFor(info *ruleMeta, value any) (memoKey, bool) {
+	if v.memo == nil || !info.memoized || info.name == "" || info.annotate != nil {
+		return memoKey{}, false
+	}
+	switch value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+	default:
+		return memoKey{}, false
+	}
+	return memoKey{rule: info.name + paramsKey(info.params), value: value}, true
+}
+
+// paramsKey describes params with their types, so params that print alike, such as the bounds
+// of is.Between[int] and is.Between[float64], give different keys
+func paramsKey(params Params) string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(params)) {
+		fmt.Fprintf(&b, " %s=%T:%#v", k, params[k], params[k])
+	}
+	return b.String()
+}
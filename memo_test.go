@@ -0,0 +1,117 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// countingRule returns a memoized rule that counts its evaluations in calls
+func countingRule(calls *int, params datacop.Params) datacop.NamedRule {
+	return datacop.Named("counted", params, func(value any) bool {
+		*calls++
+		s, _ := value.(string)
+		return len(s) >= 3
+	}).Memoized()
+}
+
+func TestWithIntraRequestMemo(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []datacop.Option
+		values    []any
+		wantCalls int
+	}{
+		{"same rule and value", []datacop.Option{datacop.WithIntraRequestMemo()}, []any{"ab", "ab", "ab"}, 1},
+		{"different values", []datacop.Option{datacop.WithIntraRequestMemo()}, []any{"ab", "abc", "ab"}, 2},
+		{"without the option", nil, []any{"ab", "ab"}, 2},
+		{"values that are not scalars", []datacop.Option{datacop.WithIntraRequestMemo()}, []any{[]string{"ab"}, []string{"ab"}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			rule := countingRule(&calls, datacop.Params{"min": 3})
+			v := datacop.New(tt.opts...)
+			for _, value := range tt.values {
+				v.Field("name", value).Validate(rule, "is too short")
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestWithIntraRequestMemo_RecordsErrors(t *testing.T) {
+	calls := 0
+	rule := countingRule(&calls, datacop.Params{"min": 3})
+	v := datacop.New(datacop.WithIntraRequestMemo())
+	v.Field("password", "ab").Validate(rule, "must be at least {min}")
+	v.Field("confirmation", "ab").Validate(rule)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "must be at least 3", v.ErrorFor("password"))
+	assert.Equal(t, "is invalid", v.ErrorFor("confirmation"), "a memoized failure is still recorded for each field")
+	assert.Equal(t, "counted", v.OrderedErrors()[1].Code)
+}
+
+func TestWithIntraRequestMemo_NameAndParams(t *testing.T) {
+	calls := 0
+	v := datacop.New(datacop.WithIntraRequestMemo())
+	v.Field("name", "ab").Validate(countingRule(&calls, datacop.Params{"min": 3}))
+	v.Field("name", "ab").Validate(countingRule(&calls, datacop.Params{"min": 3}))
+	assert.Equal(t, 1, calls, "rules with the same name and params share results")
+
+	v.Field("name", "ab").Validate(countingRule(&calls, datacop.Params{"min": 4}))
+	v.Field("name", "ab").Validate(countingRule(&calls, datacop.Params{"min": 3.0}))
+	assert.Equal(t, 3, calls, "params that differ in value or type are memoized separately")
+
+	unmemoized := datacop.Named("counted", datacop.Params{"min": 3}, func(value any) bool {
+		calls++
+		return true
+	})
+	v.Field("name", "ab").Validate(unmemoized)
+	v.Field("name", "ab").Validate(unmemoized)
+	assert.Equal(t, 5, calls, "rules that are not memoized always run")
+}
+
+func TestWithIntraRequestMemo_CompositeAndChain(t *testing.T) {
+	password := datacop.RuleSet{
+		{Func: is.Required, Message: "password is required"},
+		{Func: is.MinLength(8), Message: "password is too short"},
+	}
+
+	v := datacop.New(datacop.WithExplain(), datacop.WithIntraRequestMemo())
+	password.Apply(v, "password", "secret")
+	v.Field("password", "secret").
+		Validate(is.Required, "password is required").
+		Validate(is.MinLength(8), "password is too short")
+
+	trace := v.Explain()
+	require.Len(t, trace, 4)
+	assert.False(t, trace[0].Memoized)
+	assert.False(t, trace[1].Memoized)
+	assert.True(t, trace[2].Memoized, "the chain reuses the result of is.Required from the rule set")
+	assert.True(t, trace[3].Memoized, "the chain reuses the result of is.MinLength(8) from the rule set")
+	assert.Equal(t, []string{"password is too short", "password is too short"}, v.AllErrors()["password"])
+}
+
+func TestWithIntraRequestMemo_Annotated(t *testing.T) {
+	calls := 0
+	rule := datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		if value == "x" {
+			calls++
+		}
+		annotate(datacop.AnnotationWarning, "checked", nil)
+		return true
+	}).Named("annotated", datacop.Params{"n": 1}).Memoized()
+
+	v := datacop.New(datacop.WithIntraRequestMemo())
+	v.Field("a", "x").Validate(rule)
+	v.Field("b", "x").Validate(rule)
+	assert.Equal(t, 2, calls, "annotated rules always run so their annotations are recorded")
+	assert.Len(t, v.AnnotationsOfKind(datacop.AnnotationWarning), 2)
+}
@@ -0,0 +1,250 @@
+package datacop
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Params holds named values substituted into message templates, such as "min" for a minimum
+// length or "field" for the name of the field being validated
+type Params map[string]any
+
+// Interpolate replaces {name} placeholders in template with the matching values from params.
+// Placeholders without a matching param are left unchanged, so templates can be interpolated in
+// stages. Lists are joined with ", ", and other values are formatted with fmt's %v verb.
+//
+// Example usage:
+// Interpolate("must be at least {min} characters", Params{"min": 8}) // returns "must be at least 8 characters"
+// Interpolate("{field} must be one of {allowed}", Params{"field": "plan", "allowed": []string{"free", "pro"}})
+// // returns "plan must be one of free, pro"
+func Interpolate(template string, params Params) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		name := template[start+1 : end]
+		value, ok := params[name]
+		if !ok || !isPlaceholderName(name) {
+			// Not a placeholder we can fill; keep the brace and look for the next one
+			b.WriteString(template[:start+1])
+			template = template[start+1:]
+			continue
+		}
+		b.WriteString(template[:start])
+		b.WriteString(formatParam(value))
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+// DefaultMessage is used when a rule fails without a message and no default message is
+// registered for its name
+const DefaultMessage = "is invalid"
+
+// defaultMessages holds the built-in message templates for the rules in the is package
+var defaultMessages = map[string]string{
+	"min_length":                "must be at least {min} characters",
+	"max_length":                "must be at most {max} characters",
+	"equal_length":              "must be exactly {length} characters",
+	"min":                       "must be at least {min}",
+	"max":                       "must be at most {max}",
+	"between":                   "must be between {min} and {max}",
+	"min_numeric":               "must be at least {min}",
+	"max_numeric":               "must be at most {max}",
+	"between_numeric":           "must be between {min} and {max}",
+	"greater_than":              "must be greater than {limit}",
+	"less_than":                 "must be less than {limit}",
+	"greater_or_equal":          "must be at least {limit}",
+	"less_or_equal":             "must be at most {limit}",
+	"in":                        "must be one of {allowed}",
+	"in_fold":                   "must be one of {allowed}",
+	"enum":                      "must be one of {allowed}",
+	"all_in":                    "must only contain {allowed}",
+	"match":                     "has an invalid format",
+	"moderation":                "contains content that is not allowed",
+	"max_reading_level":         "must be readable at grade level {grade} or below",
+	"unique":                    "is already taken",
+	"exists":                    "does not exist",
+	"required":                  "is required",
+	"accepted":                  "must be accepted",
+	"required_if":               "is required",
+	"required_unless":           "is required",
+	"required_with":             "is required when {other} is present",
+	"required_without":          "is required when {other} is missing",
+	"display_name":              "is not an allowed display name",
+	"meaningful_alt_text":       "must describe the image",
+	"image_max_dimensions":      "must be at most {width}x{height} pixels",
+	"image_aspect_ratio":        "must have an aspect ratio of {ratio}",
+	"min_age":                   "must be at least {years} years old",
+	"max_age":                   "must be at most {years} years old",
+	"numeric_code":              "must be a {length}-digit code",
+	"code_matches":              "does not match",
+	"equal":                     "must be {value}",
+	"no_duplicates":             "must not contain duplicates",
+	"subset":                    "must only contain values from {set}",
+	"contains_all":              "must contain {required}",
+	"disjoint":                  "must not contain any of {other}",
+	"email":                     "must be a valid email address",
+	"phone":                     "must be a valid phone number",
+	"uuid":                      "must be a valid UUID",
+	"alpha":                     "must contain only letters",
+	"alpha_unicode":             "must contain only letters",
+	"alphanumeric":              "must contain only letters and numbers",
+	"alphanumeric_unicode":      "must contain only letters and numbers",
+	"numeric":                   "must contain only digits",
+	"numeric_unicode":           "must contain only digits",
+	"ascii":                     "must contain only ASCII characters",
+	"printable_ascii":           "must contain only printable ASCII characters",
+	"slug":                      "must be a valid slug",
+	"slug_unicode":              "must be a valid slug",
+	"totp_code":                 "must be a 6 to 8 digit code",
+	"backup_code_format":        "must be a valid backup code",
+	"int_string":                "must be a whole number",
+	"float_string":              "must be a number",
+	"password":                  "is not strong enough",
+	"username":                  "is not a valid username",
+	"no_bidi_control":           "must not contain bidirectional control characters",
+	"no_leading_trailing_space": "must not start or end with a space",
+	"single_line":               "must be a single line",
+	"no_control_chars":          "must not contain control characters",
+	"timezone":                  "must be a valid time zone",
+	"strong_password":           "is not strong enough",
+	"phone_number":              "must be a valid phone number",
+	"pattern":                   "has an invalid format",
+	"signed_value":              "is invalid or has expired",
+	"starts_with":               "must start with {prefix}",
+	"starts_with_fold":          "must start with {prefix}",
+	"ends_with":                 "must end with {suffix}",
+	"ends_with_fold":            "must end with {suffix}",
+	"contains":                  "must contain {substr}",
+	"contains_fold":             "must contain {substr}",
+	"not_contains":              "must not contain {substr}",
+	"not_contains_fold":         "must not contain {substr}",
+	"human_name":                "must be a valid name",
+	"before":                    "must be before {time}",
+	"after":                     "must be after {time}",
+	"before_or_equal":           "must not be after {time}",
+	"after_or_equal":            "must not be before {time}",
+	"between_time":              "must be between {start} and {end}",
+	"between_time_inclusive":    "must be between {start} and {end}",
+	"within_duration":           "must be within {duration} of now",
+	"not_older_than":            "must not be older than {duration}",
+	"weekday":                   "is not an allowed day of the week",
+	"within_business_hours":     "must be between {start} and {end}",
+	"iban":                      "must be a valid IBAN",
+	"bic":                       "must be a valid BIC",
+	"aba_routing_number":        "must be a valid routing number",
+	"ssn":                       "must be a valid Social Security number",
+	"ein":                       "must be a valid Employer Identification Number",
+	"vat_number":                "must be a valid VAT number",
+	"hexadecimal":               "must be a hexadecimal string",
+	"base64":                    "must be valid base64",
+	"base64url":                 "must be valid URL-safe base64",
+	"md5":                       "must be an MD5 hash",
+	"sha256":                    "must be a SHA-256 hash",
+	"hex_color":                 "must be a hex color such as #1e90ff",
+	"rgb_color":                 "must be an rgb() color",
+	"hsl_color":                 "must be an hsl() color",
+	"hostname":                  "must be a valid host name",
+	"fqdn":                      "must be a fully qualified domain name",
+	"domain":                    "must be a valid domain name",
+	"domain_with":               "must be a registrable domain name",
+	"port":                      "must be a port number from 1 to 65535",
+	"mac_address":               "must be a valid MAC address",
+	"imei":                      "must be a valid IMEI",
+	"latitude":                  "must be a latitude from -90 to 90",
+	"longitude":                 "must be a longitude from -180 to 180",
+	"within_bounding_box":       "must be within the allowed area",
+	"semver":                    "must be a valid semantic version",
+	"semver_range":              "must be a version in {range}",
+}
+
+// SetDefaultMessage registers the message template used when a rule with the given name fails
+// in Validate or a RuleSet without a message of its own. It replaces the built-in template for
+// rules in the is package, so it can also be used to reword or translate them.
+//
+// Example usage:
+//
+//	v.SetDefaultMessage("min_length", "needs {min} or more characters")
+//	v.Field("name", name).Validate(is.MinLength(3)) // "needs 3 or more characters"
+func (v *Validator) SetDefaultMessage(name, template string) {
+	if v.messages == nil {
+		v.messages = make(map[string]string)
+	}
+	v.messages[name] = template
+}
+
+// defaultMessage returns the template for a rule that failed without a message: the template
+// registered with SetDefaultMessage, the built-in template, or DefaultMessage
+func (v *Validator) defaultMessage(name string) string {
+	if template, ok := v.messages[name]; ok {
+		return template
+	}
+	if template, ok := defaultMessages[name]; ok {
+		return template
+	}
+	return DefaultMessage
+}
+
+// Checkf performs a validation and, if it fails, adds an error for field with a message built
+// from template. The field's label is available to the template as {field} unless params sets it;
+// see SetLabel. The params are recorded on the error.
+//
+// Example usage:
+// v.Checkf(len(password) >= 8, "password", "{field} must be at least {min} characters", datacop.Params{"min": 8})
+func (v *Validator) Checkf(valid bool, field, template string, params Params) bool {
+	if !valid {
+		v.AddValidationError(ValidationError{
+			Field:   field,
+			Message: Interpolate(template, params),
+			Params:  params,
+		})
+	}
+	return valid
+}
+
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatParam(item)
+		}
+		return strings.Join(parts, ", ")
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,115 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		params   datacop.Params
+		want     string
+	}{
+		{"single placeholder", "must be at least {min} characters", datacop.Params{"min": 8}, "must be at least 8 characters"},
+		{"several placeholders", "{field} must be between {min} and {max}", datacop.Params{"field": "age", "min": 18, "max": 120.0}, "age must be between 18 and 120"},
+		{"repeated placeholder", "{value} is not {value}", datacop.Params{"value": "x"}, "x is not x"},
+		{"string list", "must be one of {allowed}", datacop.Params{"allowed": []string{"free", "pro"}}, "must be one of free, pro"},
+		{"any list", "must be one of {allowed}", datacop.Params{"allowed": []any{1, "two"}}, "must be one of 1, two"},
+		{"nil value", "got {value}", datacop.Params{"value": nil}, "got "},
+		{"missing param is kept", "must be at least {min}", datacop.Params{"max": 1}, "must be at least {min}"},
+		{"no params", "must be at least {min}", nil, "must be at least {min}"},
+		{"unbalanced braces", "use {braces or {min}", datacop.Params{"min": 1}, "use {braces or 1"},
+		{"non-identifier is kept", "{ min } and {min}", datacop.Params{"min": 2, " min ": 3}, "{ min } and 2"},
+		{"no placeholders", "is required", datacop.Params{"min": 1}, "is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, datacop.Interpolate(tt.template, tt.params))
+		})
+	}
+}
+
+func TestValidator_Checkf(t *testing.T) {
+	v := datacop.New()
+
+	assert.True(t, v.Checkf(true, "name", "{field} is required", nil))
+	assert.False(t, v.HasErrors())
+
+	assert.False(t, v.Checkf(false, "password", "{field} must be at least {min} characters", datacop.Params{"min": 8}))
+	assert.False(t, v.Checkf(false, "plan", "{field} must be one of {allowed}, got {value}",
+		datacop.Params{"allowed": []string{"free", "pro"}, "value": "gold"}))
+	assert.False(t, v.Checkf(false, "nickname", "{field} is taken", datacop.Params{"field": "Nickname"}))
+
+	assert.Equal(t, map[string]string{
+		"password": "password must be at least 8 characters",
+		"plan":     "plan must be one of free, pro, got gold",
+		"nickname": "Nickname is taken",
+	}, v.Errors())
+}
+
+func TestValidator_Checkf_Params(t *testing.T) {
+	v := datacop.New()
+	v.Checkf(false, "password", "{field} must be at least {min} characters", datacop.Params{"min": 8})
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, datacop.Params{"min": 8}, errs[0].Params)
+}
+
+func TestValidator_DefaultMessages(t *testing.T) {
+	v := datacop.New()
+	v.Field("name", "Al").Validate(is.MinLength(3))
+	v.Field("plan", "gold").Validate(is.In("free", "pro"))
+	v.Field("email", "x").Validate(is.Email)
+	v.Field("age", 15).Validate(is.Min(18), "must be an adult")
+
+	assert.Equal(t, map[string]string{
+		"name":  "must be at least 3 characters",
+		"plan":  "must be one of free, pro",
+		"email": "must be a valid email address",
+		"age":   "must be an adult",
+	}, v.Errors())
+}
+
+func TestValidator_SetDefaultMessage(t *testing.T) {
+	v := datacop.New()
+	v.SetDefaultMessage("min_length", "{field} needs {min} or more characters")
+	v.SetDefaultMessage("multiple_of", "must be a multiple of {n}")
+
+	multipleOf := func(n int) datacop.NamedRule {
+		return datacop.Named("multiple_of", datacop.Params{"n": n}, func(value any) bool {
+			v, ok := value.(int)
+			return ok && v%n == 0
+		})
+	}
+
+	v.Field("name", "Al").Validate(is.MinLength(3))
+	v.Field("quantity", 7).When(true).Validate(multipleOf(5))
+	datacop.RuleSet{{Func: is.MaxLength(2)}}.Apply(v, "code", "ABC")
+
+	assert.Equal(t, map[string]string{
+		"name":     "name needs 3 or more characters",
+		"quantity": "must be a multiple of 5",
+		"code":     "must be at most 2 characters",
+	}, v.Errors())
+
+	// Registered messages survive Clear
+	v.Clear()
+	v.Field("name", "Al").Validate(is.MinLength(3))
+	assert.Equal(t, "name needs 3 or more characters", v.ErrorFor("name"))
+}
+
+func TestWithDefaultMessages(t *testing.T) {
+	messages := map[string]string{"max_length": "is too long (maximum is {max})"}
+	v := datacop.New(datacop.WithDefaultMessages(messages))
+	v.Field("bio", "hello").Validate(is.MaxLength(3))
+	assert.Equal(t, "is too long (maximum is 3)", v.ErrorFor("bio"))
+}
@@ -0,0 +1,102 @@
+package datacop
+
+// ValidateAll runs the Validate method of each item against v. Nil items are skipped.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	datacop.ValidateAll(v, &user, &settings)
+//	return v.ErrOrNil()
+func ValidateAll(v *Validator, items ...Validatable) {
+	for _, item := range items {
+		if item != nil {
+			item.Validate(v)
+		}
+	}
+}
+
+// Validate runs obj's Validate method with its fields nested in the group, so an object that
+// validates its own "zip" field records errors for "address.zip" when validated in the "address"
+// group. Standalone errors of obj are recorded for the group itself, as are annotations without
+// a field. Labels and sensitive fields are looked up by full path, so a label set for
+// "address.zip" applies inside obj, and {field} in messages is the full path by default.
+//
+// Example usage:
+//
+//	func (a Address) Validate(v *datacop.Validator) {
+//		v.Field("zip", a.Zip).Validate(is.Required, "is required")
+//	}
+//
+//	v.Group("shipping").Validate(order.Shipping) // "shipping.zip"
+//	for i, item := range order.Items {
+//		v.Group("items").Index(i).Validate(item) // "items[0].sku"
+//	}
+func (g *Group) Validate(obj Validatable) {
+	if obj == nil {
+		return
+	}
+
+	parent := g.v
+	child := parent.child(g.name + ".")
+	if len(parent.reporters) > 0 {
+		// Report failures as they are recorded, under their full path
+		child.reporters = []Reporter{ReporterFunc(func(f Failure) {
+			f.Field = g.nest(f.Field)
+			for _, r := range parent.reporters {
+				r.Report(f)
+			}
+		})}
+	}
+
+	obj.Validate(child)
+
+	for _, field := range child.order {
+		path := g.nest(field)
+		for _, e := range child.errors[field] {
+			e.Field = path
+			parent.store(e)
+		}
+	}
+	for _, a := range child.annotations {
+		a.Field = g.nest(a.Field)
+		parent.annotations = append(parent.annotations, a)
+	}
+	for _, s := range child.trace {
+		s.Field = g.nest(s.Field)
+		parent.trace = append(parent.trace, s)
+	}
+	parent.score.merge(child.score)
+	for _, c := range child.pending {
+		// Asynchronous checks keep running and are collected by the parent's Wait
+		c.field = g.nest(c.field)
+		parent.pending = append(parent.pending, c)
+	}
+}
+
+// nest returns the path of a field of a nested object. The object's standalone errors and
+// annotations without a field belong to the group itself.
+func (g *Group) nest(field string) string {
+	if field == "" || field == StandaloneErrorKey {
+		return g.name
+	}
+	return g.Path(field)
+}
+
+// ValidateSlice runs fn for each item of items with a group for the item's index under prefix,
+// so errors are recorded for paths such as "line_items[3].quantity". For slices nested in a
+// group, pass the group's path as the prefix.
+//
+// Example usage:
+//
+//	datacop.ValidateSlice(v, "line_items", order.LineItems, func(g *datacop.Group, item LineItem) {
+//		g.Field("sku", item.SKU).Validate(is.Required, "is required")
+//		g.Field("quantity", item.Quantity).Validate(is.Min(1), "must be positive")
+//	})
+//
+//	datacop.ValidateSlice(v, v.Group("order").Path("items"), items, validateItem) // "order.items[0].sku"
+func ValidateSlice[T any](v *Validator, prefix string, items []T, fn func(g *Group, item T)) {
+	group := v.Group(prefix)
+	for i, item := range items {
+		fn(group.Index(i), item)
+	}
+}
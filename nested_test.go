@@ -0,0 +1,177 @@
+package datacop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+type address struct {
+	Street string
+	Zip    string
+}
+
+func (a address) Validate(v *datacop.Validator) {
+	v.Field("street", a.Street).Validate(is.Required, "{field} is required")
+	v.Field("zip", a.Zip).Validate(is.MinLength(5))
+	if a.Street == "PO Box" {
+		v.AddStandaloneError("cannot ship to a PO box")
+		v.Annotate("", datacop.AnnotationWarning, "po box", nil)
+	}
+}
+
+type lineItem struct {
+	SKU string
+}
+
+func (l *lineItem) Validate(v *datacop.Validator) {
+	v.Field("sku", l.SKU).Validate(is.Required, "is required")
+}
+
+type order struct {
+	Shipping address
+	Items    []*lineItem
+}
+
+func (o order) Validate(v *datacop.Validator) {
+	v.Group("shipping").Validate(o.Shipping)
+	items := v.Group("items")
+	for i, item := range o.Items {
+		items.Index(i).Validate(item)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	v := datacop.New()
+	datacop.ValidateAll(v, address{Zip: "12345"}, &lineItem{}, nil)
+
+	assert.Equal(t, map[string]string{"street": "street is required", "sku": "is required"}, v.Errors())
+	assert.NotPanics(t, func() { datacop.ValidateAll(v) })
+}
+
+func TestGroup_Validate(t *testing.T) {
+	v := datacop.New()
+	order{
+		Shipping: address{Zip: "123"},
+		Items:    []*lineItem{{SKU: "A-1"}, {}},
+	}.Validate(v)
+
+	assert.Equal(t, map[string]string{
+		"shipping.street": "shipping.street is required",
+		"shipping.zip":    "must be at least 5 characters",
+		"items[1].sku":    "is required",
+	}, v.Errors())
+	assert.Equal(t, "min_length", v.OrderedErrors()[1].Code)
+	assert.True(t, v.Group("items").HasErrors())
+}
+
+func TestGroup_Validate_StandaloneAndAnnotations(t *testing.T) {
+	v := datacop.New()
+	v.Group("shipping").Validate(address{Street: "PO Box", Zip: "12345"})
+
+	assert.Equal(t, "cannot ship to a PO box", v.ErrorFor("shipping"))
+	assert.False(t, v.HasStandaloneErrors())
+	require.Len(t, v.Annotations(), 1)
+	assert.Equal(t, "shipping", v.Annotations()[0].Field)
+}
+
+func TestGroup_Validate_Config(t *testing.T) {
+	var failures []datacop.Failure
+	v := datacop.New(
+		datacop.WithLabels(map[string]string{"shipping.street": "Street", "street": "Top-level street"}),
+		datacop.WithDefaultMessages(map[string]string{"min_length": "needs {min} characters"}),
+		datacop.WithReporter(datacop.ReporterFunc(func(f datacop.Failure) { failures = append(failures, f) })),
+		datacop.WithExplain(),
+		datacop.WithSensitiveFields("shipping.zip"),
+	)
+	v.Group("shipping").Validate(address{Zip: "123"})
+
+	assert.Equal(t, "Street is required", v.ErrorFor("shipping.street"))
+	assert.Equal(t, "needs 5 characters", v.ErrorFor("shipping.zip"))
+
+	require.Len(t, failures, 2)
+	assert.Equal(t, datacop.Failure{Field: "shipping.zip", Rule: "min_length", Kind: "string", Message: "needs 5 characters"}, failures[1])
+
+	trace := v.Explain()
+	require.Len(t, trace, 2)
+	assert.Equal(t, "shipping.street", trace[0].Field)
+	assert.Equal(t, datacop.RedactedInput, trace[1].Input)
+}
+
+type asyncProfile struct {
+	Handle string
+}
+
+func (p asyncProfile) Validate(v *datacop.Validator) {
+	v.CheckAsync(func() bool { return p.Handle != "taken" }, "handle", "is taken")
+}
+
+func TestGroup_Validate_Async(t *testing.T) {
+	v := datacop.New()
+	v.Group("profile").Validate(asyncProfile{Handle: "taken"})
+	v.Wait()
+	assert.Equal(t, "is taken", v.ErrorFor("profile.handle"))
+}
+
+// validateFunc adapts a function to the Validatable interface
+type validateFunc func(v *datacop.Validator)
+
+func (fn validateFunc) Validate(v *datacop.Validator) { fn(v) }
+
+func TestGroup_Validate_SinkAndStart(t *testing.T) {
+	var written []datacop.ValidationError
+	v := datacop.New(datacop.WithErrorSink(collect(&written), 1))
+
+	var started time.Time
+	v.Group("shipping").Validate(validateFunc(func(child *datacop.Validator) {
+		started = child.Result().StartedAt
+		address{}.Validate(child)
+	}))
+
+	assert.Equal(t, v.Result().StartedAt, started, "the nested validator shares the start time")
+	require.Len(t, written, 2, "each error is written to the sink once")
+	assert.Equal(t, "shipping.street", written[0].Field)
+	assert.Equal(t, 2, v.ErrorCount())
+	assert.Len(t, v.OrderedErrors(), 1, "the sample applies across nested objects")
+}
+
+func TestGroup_Validate_Nil(t *testing.T) {
+	v := datacop.New()
+	assert.NotPanics(t, func() { v.Group("shipping").Validate(nil) })
+	assert.False(t, v.HasErrors())
+}
+
+func TestValidateSlice(t *testing.T) {
+	type item struct {
+		SKU      string
+		Quantity int
+	}
+	validateItem := func(g *datacop.Group, it item) {
+		g.Field("sku", it.SKU).Validate(is.Required, "is required")
+		g.Field("quantity", it.Quantity).Validate(is.Min(1), "must be positive")
+	}
+	items := []item{{"A-1", 2}, {"", 1}, {"C-3", 0}, {"D-4", 0}}
+
+	v := datacop.New()
+	datacop.ValidateSlice(v, "line_items", items, validateItem)
+	assert.Equal(t, map[string]string{
+		"line_items[1].sku":      "is required",
+		"line_items[2].quantity": "must be positive",
+		"line_items[3].quantity": "must be positive",
+	}, v.Errors())
+	assert.Equal(t, "line_items[1].sku: [is required] | line_items[2].quantity: [must be positive] | "+
+		"line_items[3].quantity: [must be positive]", v.Error())
+
+	nested := datacop.New()
+	datacop.ValidateSlice(nested, nested.Group("order").Path("items"), items[:2], validateItem)
+	assert.Equal(t, map[string]string{"order.items[1].sku": "is required"}, nested.Errors())
+
+	empty := datacop.New()
+	datacop.ValidateSlice(empty, "line_items", nil, validateItem)
+	assert.False(t, empty.HasErrors())
+}
@@ -0,0 +1,277 @@
+// Package openapi validates HTTP requests against the operations of an OpenAPI 3 document using
+// datacop's schema engine.
+//
+// A subset of OpenAPI is supported: path, query, and header parameters, and JSON request bodies
+// described by object schemas. Within schemas, the keywords type, properties, required, minLength,
+// maxLength, minimum, maximum, pattern, enum, and format are enforced, and local "$ref"s are
+// resolved. Unsupported keywords are ignored rather than rejected. Required properties of optional
+// nested objects are not enforced.
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+// ErrNoOperation is returned when a request does not match any operation in the document
+var ErrNoOperation = errors.New("openapi: no matching operation")
+
+// Spec is a loaded OpenAPI document, ready to validate requests
+type Spec struct {
+	root       map[string]any
+	operations []*Operation
+}
+
+// Operation is a single method and path of the document
+type Operation struct {
+	ID     string
+	Method string
+	Path   string
+
+	segments     []string
+	params       []*Parameter
+	body         *schema.Schema
+	bodyRequired bool
+}
+
+// Parameter is a path, query, or header parameter of an operation
+type Parameter struct {
+	Name  string
+	In    string
+	Field *schema.Field
+}
+
+// Load parses an OpenAPI 3 document in JSON or YAML format
+//
+// Example usage:
+//
+//	spec, err := openapi.Load(specBytes)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	http.ListenAndServe(":8080", spec.Middleware(mux))
+func Load(data []byte) (*Spec, error) {
+	var root map[string]any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("openapi: parsing document: %w", err)
+	}
+
+	version, _ := root["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("openapi: unsupported version %q", version)
+	}
+
+	s := &Spec{root: root}
+	paths, _ := root["paths"].(map[string]any)
+	for path, item := range paths {
+		item, err := s.resolve(item)
+		if err != nil {
+			return nil, err
+		}
+
+		pathParams, _ := item["parameters"].([]any)
+		for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op, err := s.operation(strings.ToUpper(method), path, raw, pathParams)
+			if err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			s.operations = append(s.operations, op)
+		}
+	}
+
+	// Prefer literal segments over templated ones when several paths match, e.g. /users/me over /users/{id}
+	sort.SliceStable(s.operations, func(i, j int) bool {
+		return templated(s.operations[i].segments) < templated(s.operations[j].segments)
+	})
+
+	return s, nil
+}
+
+// Operations returns the operations of the document
+func (s *Spec) Operations() []*Operation {
+	return s.operations
+}
+
+// Find returns the operation matching the request's method and path, along with the values of
+// its path parameters
+func (s *Spec) Find(r *http.Request) (*Operation, map[string]string, bool) {
+	segments := splitPath(r.URL.Path)
+	for _, op := range s.operations {
+		if op.Method != r.Method {
+			continue
+		}
+		if params, ok := op.match(segments); ok {
+			return op, params, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Params returns the operation's parameters
+func (op *Operation) Params() []*Parameter {
+	return op.params
+}
+
+// Body returns the schema of the operation's JSON request body, or nil if it has none
+func (op *Operation) Body() *schema.Schema {
+	return op.body
+}
+
+func (s *Spec) operation(method, path string, raw any, pathParams []any) (*Operation, error) {
+	obj, err := s.resolve(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operation{Method: method, Path: path, segments: splitPath(path)}
+	op.ID, _ = obj["operationId"].(string)
+
+	opParams, _ := obj["parameters"].([]any)
+	seen := make(map[string]int)
+	for _, raw := range append(append([]any{}, pathParams...), opParams...) {
+		p, err := s.parameter(raw)
+		if err != nil {
+			return nil, err
+		}
+		// Operation parameters override path item parameters with the same name and location
+		key := p.In + ":" + p.Name
+		if i, ok := seen[key]; ok {
+			op.params[i] = p
+			continue
+		}
+		seen[key] = len(op.params)
+		op.params = append(op.params, p)
+	}
+
+	if raw, ok := obj["requestBody"]; ok {
+		body, err := s.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		op.bodyRequired, _ = body["required"].(bool)
+
+		content, _ := body["content"].(map[string]any)
+		if media, ok := content["application/json"]; ok {
+			media, _ := media.(map[string]any)
+			op.body = schema.New()
+			if err := op.body.AddJSONSchema("", media["schema"], false, s.jsonSchemaOptions()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return op, nil
+}
+
+func (s *Spec) parameter(raw any) (*Parameter, error) {
+	obj, err := s.resolve(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Parameter{}
+	p.Name, _ = obj["name"].(string)
+	p.In, _ = obj["in"].(string)
+	if p.Name == "" || p.In == "" {
+		return nil, errors.New("parameter requires a name and location")
+	}
+
+	required, _ := obj["required"].(bool)
+	p.Field = &schema.Field{Name: p.Name, Required: required || p.In == "path"}
+	if raw, ok := obj["schema"]; ok {
+		sch, err := s.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Field.ApplyJSONSchema(sch, s.jsonSchemaOptions()); err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+	}
+	return p, nil
+}
+
+// jsonSchemaOptions returns the options for reading the document's schema objects with the
+// schema package: references are resolved, and keywords that cannot be enforced are ignored
+func (s *Spec) jsonSchemaOptions() schema.JSONSchemaOptions {
+	return schema.JSONSchemaOptions{Resolve: s.resolve, Lenient: true}
+}
+
+// resolve follows a local "$ref" and returns the referenced object
+func (s *Spec) resolve(raw any) (map[string]any, error) {
+	for range 32 {
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", raw)
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj, nil
+		}
+		if raw, ok = s.lookupRef(ref); !ok {
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+	return nil, errors.New("reference cycle")
+}
+
+func (s *Spec) lookupRef(ref string) (any, bool) {
+	path, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+
+	var current any = s.root
+	for _, key := range strings.Split(path, "/") {
+		key = strings.NewReplacer("~1", "/", "~0", "~").Replace(key)
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func (op *Operation) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(op.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range op.segments {
+		if name, ok := strings.CutPrefix(seg, "{"); ok && strings.HasSuffix(name, "}") {
+			params[strings.TrimSuffix(name, "}")] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func templated(segments []string) int {
+	n := 0
+	for _, seg := range segments {
+		if strings.HasPrefix(seg, "{") {
+			n++
+		}
+	}
+	return n
+}
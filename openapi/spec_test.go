@@ -0,0 +1,123 @@
+package openapi_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/openapi"
+)
+
+const petstore = `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /pets/mine:
+    get:
+      operationId: myPets
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      operationId: getPet
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      properties:
+        name:
+          type: string
+          minLength: 1
+          maxLength: 20
+        kind:
+          type: string
+          enum: [cat, dog]
+        owner:
+          type: object
+          required: [email]
+          properties:
+            email:
+              type: string
+              format: email
+`
+
+func TestLoad(t *testing.T) {
+	spec, err := openapi.Load([]byte(petstore))
+	require.NoError(t, err)
+	assert.Len(t, spec.Operations(), 4)
+
+	_, err = openapi.Load([]byte(`{"swagger": "2.0"}`))
+	assert.Error(t, err)
+
+	_, err = openapi.Load([]byte(`{"openapi": "3.0.0", "paths": {"/x": {"get": {"parameters": [{"$ref": "#/components/parameters/Missing"}]}}}}`))
+	assert.ErrorContains(t, err, "unresolved reference")
+
+	_, err = openapi.Load([]byte(`{"openapi": "3.0.0", "paths": {"/x": {"get": {"parameters": [{"name": "q", "in": "query", "schema": {"pattern": "(["}}]}}}}`))
+	assert.ErrorContains(t, err, `parameter "q"`)
+}
+
+func TestSpec_Find(t *testing.T) {
+	spec, err := openapi.Load([]byte(petstore))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantID     string
+		wantParams map[string]string
+	}{
+		{"literal path", "GET", "/pets", "listPets", map[string]string{}},
+		{"method selects operation", "POST", "/pets", "createPet", map[string]string{}},
+		{"templated path", "GET", "/pets/42", "getPet", map[string]string{"petId": "42"}},
+		{"literal preferred over template", "GET", "/pets/mine", "myPets", map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, params, ok := spec.Find(httptest.NewRequest(tt.method, tt.target, nil))
+			require.True(t, ok)
+			assert.Equal(t, tt.wantID, op.ID)
+			assert.Equal(t, tt.wantParams, params)
+		})
+	}
+
+	_, _, ok := spec.Find(httptest.NewRequest("DELETE", "/pets", nil))
+	assert.False(t, ok)
+}
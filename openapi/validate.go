@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+	"github.com/patrickward/datacop/web"
+)
+
+// MaxBodyBytes limits the size of request bodies read for validation
+const MaxBodyBytes = 1 << 20
+
+// ErrBodyTooLarge is returned when a request body is larger than MaxBodyBytes
+var ErrBodyTooLarge = errors.New("openapi: request body too large")
+
+// ValidateRequest validates the request against its matching operation. Parameter errors are
+// recorded under the parameter name and body errors under the dotted property path. The body is
+// restored afterwards so that handlers can read it again.
+//
+// It returns ErrNoOperation if no operation matches the request, and ErrBodyTooLarge if the body
+// is larger than MaxBodyBytes. Other errors reading the body are returned as is; a body that is
+// not valid JSON is recorded as a standalone validation error.
+func (s *Spec) ValidateRequest(r *http.Request) (*datacop.Validator, error) {
+	op, pathParams, ok := s.Find(r)
+	if !ok {
+		return nil, ErrNoOperation
+	}
+
+	v := datacop.New()
+	for _, p := range op.params {
+		raw, present := paramValue(r, p, pathParams)
+		var value any
+		if present {
+			value = coerce(p.Field.Type, raw)
+		}
+		p.Field.Validate(v, p.Name, value)
+	}
+
+	if op.body == nil && !op.bodyRequired {
+		return v, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxBodyBytes {
+		return nil, ErrBodyTooLarge
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if op.bodyRequired {
+			v.AddCodedError(datacop.StandaloneErrorKey, schema.CodeRequired, "request body is required")
+		}
+		return v, nil
+	}
+	if op.body == nil {
+		return v, nil
+	}
+
+	var body map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		v.AddCodedError(datacop.StandaloneErrorKey, schema.CodeType, "request body must be a JSON object")
+		return v, nil
+	}
+	op.body.Validate(v, body)
+
+	return v, nil
+}
+
+// Middleware validates requests before passing them to next. Invalid requests receive a
+// 422 Unprocessable Entity response with RFC 7807 problem details, translated into the request's
+// locale when web.Middleware is installed in front of it. Bodies larger than MaxBodyBytes receive
+// 413 Request Entity Too Large. Requests that match no operation are passed through unchanged.
+func (s *Spec) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := s.ValidateRequest(r)
+		if errors.Is(err, ErrNoOperation) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if errors.Is(err, ErrBodyTooLarge) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if web.WriteError(w, r, v.ErrOrNil()) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func paramValue(r *http.Request, p *Parameter, pathParams map[string]string) (string, bool) {
+	switch p.In {
+	case "path":
+		v, ok := pathParams[p.Name]
+		return v, ok
+	case "query":
+		values, ok := r.URL.Query()[p.Name]
+		if !ok || len(values) == 0 {
+			return "", false
+		}
+		return values[0], true
+	case "header":
+		values := r.Header.Values(p.Name)
+		if len(values) == 0 {
+			return "", false
+		}
+		return values[0], true
+	case "cookie":
+		c, err := r.Cookie(p.Name)
+		if err != nil {
+			return "", false
+		}
+		return c.Value, true
+	}
+	return "", false
+}
+
+// coerce converts a raw parameter string to the declared type, leaving it unchanged if it
+// cannot be converted so that the type check reports it
+func coerce(t schema.Type, raw string) any {
+	switch t {
+	case schema.TypeInteger:
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case schema.TypeNumber:
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	case schema.TypeBoolean:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
@@ -0,0 +1,185 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/i18n"
+	"github.com/patrickward/datacop/openapi"
+	"github.com/patrickward/datacop/web"
+)
+
+func TestSpec_ValidateRequest(t *testing.T) {
+	spec, err := openapi.Load([]byte(petstore))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		headers    map[string]string
+		body       string
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid query and header",
+			method:     "GET",
+			target:     "/pets?limit=10",
+			headers:    map[string]string{"X-Request-Id": "8c2f5b8e-7d3a-4b2e-9f1a-0c6d5e4b3a21"},
+			wantErrors: map[string]string{},
+		},
+		{
+			name:   "invalid query and missing header",
+			method: "GET",
+			target: "/pets?limit=500",
+			wantErrors: map[string]string{
+				"limit":        "must be at most 100",
+				"X-Request-Id": "is required",
+			},
+		},
+		{
+			name:    "non-numeric query",
+			method:  "GET",
+			target:  "/pets?limit=ten",
+			headers: map[string]string{"X-Request-Id": "8c2f5b8e-7d3a-4b2e-9f1a-0c6d5e4b3a21"},
+			wantErrors: map[string]string{
+				"limit": "must be an integer",
+			},
+		},
+		{
+			name:       "path parameter",
+			method:     "GET",
+			target:     "/pets/abc",
+			wantErrors: map[string]string{"petId": "must be an integer"},
+		},
+		{
+			name:       "valid body",
+			method:     "POST",
+			target:     "/pets",
+			body:       `{"name": "Rex", "kind": "dog", "owner": {"email": "jane@example.com"}}`,
+			wantErrors: map[string]string{},
+		},
+		{
+			name:   "invalid body",
+			method: "POST",
+			target: "/pets",
+			body:   `{"name": "", "kind": "bird", "owner": {"email": "nope"}}`,
+			wantErrors: map[string]string{
+				"name":        "is required",
+				"kind":        "is not an allowed value",
+				"owner.email": "must be a valid email",
+			},
+		},
+		{
+			name:       "missing body",
+			method:     "POST",
+			target:     "/pets",
+			wantErrors: map[string]string{datacop.StandaloneErrorKey: "request body is required"},
+		},
+		{
+			name:       "malformed body",
+			method:     "POST",
+			target:     "/pets",
+			body:       `[1, 2`,
+			wantErrors: map[string]string{datacop.StandaloneErrorKey: "request body must be a JSON object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			v, err := spec.ValidateRequest(r)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErrors, v.Errors())
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body), "body should be readable after validation")
+		})
+	}
+
+	_, err = spec.ValidateRequest(httptest.NewRequest("GET", "/unknown", nil))
+	assert.ErrorIs(t, err, openapi.ErrNoOperation)
+}
+
+func TestSpec_ValidateRequest_Concurrent(t *testing.T) {
+	spec, err := openapi.Load([]byte(`{"openapi": "3.0.0", "paths": {"/x": {"get": {"parameters": [{"name": "q", "in": "query", "schema": {"pattern": "^[a-z]+$"}}]}}}}`))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := spec.ValidateRequest(httptest.NewRequest("GET", "/x?q=ABC", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, "pattern", v.OrderedErrors()[0].Code)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSpec_Middleware(t *testing.T) {
+	spec, err := openapi.Load([]byte(petstore))
+	require.NoError(t, err)
+
+	handler := spec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/pets", strings.NewReader(`{"name": "Rex", "kind": "dog"}`)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/pets", strings.NewReader(`{"kind": "dog"}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, datacop.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var problem datacop.ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, []datacop.DetailedError{{Field: "name", Code: "required", Message: "is required"}}, problem.Errors)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/unknown", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	large := `{"name": "Rex", "kind": "dog", "notes": "` + strings.Repeat("a", openapi.MaxBodyBytes) + `"}`
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/pets", strings.NewReader(large)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestSpec_Middleware_Localized(t *testing.T) {
+	spec, err := openapi.Load([]byte(petstore))
+	require.NoError(t, err)
+
+	catalog := i18n.NewCatalog()
+	catalog.Add("de", map[string]string{"required": "ist erforderlich"})
+
+	handler := web.Middleware(catalog)(spec.Middleware(http.NotFoundHandler()))
+
+	r := httptest.NewRequest("POST", "/pets", strings.NewReader(`{"kind": "dog"}`))
+	r.Header.Set("Accept-Language", "de-DE, en;q=0.5")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem datacop.ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, []datacop.DetailedError{{Field: "name", Code: "required", Message: "ist erforderlich"}}, problem.Errors)
+}
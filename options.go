@@ -0,0 +1,31 @@
+package datacop
+
+// Option configures a Validator created with New
+type Option func(*Validator)
+
+// WithStableOutput makes MarshalJSON render the canonical form of the errors, so that the
+// output depends only on which errors were recorded and not on the order they were recorded in.
+// This keeps equality assertions and cached responses stable when checks run in varying order.
+//
+// Example usage:
+// v := datacop.New(datacop.WithStableOutput())
+func WithStableOutput() Option {
+	return func(v *Validator) {
+		v.stable = true
+	}
+}
+
+// WithDefaultMessages registers message templates keyed by rule name, as SetDefaultMessage does,
+// so one set of messages can be shared by every validator an application creates.
+//
+// Example usage:
+//
+//	var messages = map[string]string{"min_length": "needs {min} or more characters"}
+//	v := datacop.New(datacop.WithDefaultMessages(messages))
+func WithDefaultMessages(messages map[string]string) Option {
+	return func(v *Validator) {
+		for name, template := range messages {
+			v.SetDefaultMessage(name, template)
+		}
+	}
+}
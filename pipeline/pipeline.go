@@ -0,0 +1,225 @@
+// Package pipeline runs validation in ordered stages, stopping at the first stage that records an
+// error. It formalizes the ordering most services implement by hand: first check that the input
+// has the right shape, then validate individual fields, then relations between fields, and only
+// then run expensive external checks such as database or network lookups, which are pointless on
+// input already known to be invalid.
+//
+// Example usage:
+//
+//	var signup = pipeline.New[SignupRequest]().
+//		Shape(func(ctx context.Context, v *datacop.Validator, r SignupRequest) {
+//			v.Field("email", r.Email).Validate(is.Required, "is required")
+//		}).
+//		Fields(func(ctx context.Context, v *datacop.Validator, r SignupRequest) {
+//			v.Field("email", r.Email).Validate(is.Email, "is not a valid email address")
+//		}).
+//		CrossField(func(ctx context.Context, v *datacop.Validator, r SignupRequest) {
+//			v.Field("password_confirmation", r.Confirmation).Check(r.Confirmation == r.Password, "does not match")
+//		}).
+//		External(func(ctx context.Context, v *datacop.Validator, r SignupRequest) {
+//			v.Field("email", r.Email).Validate(datacopdb.Unique(ctx, db, "users", "email"), "is already registered")
+//		})
+//
+//	result := signup.Run(ctx, req)
+//	if err := result.Err(); err != nil {
+//		return err
+//	}
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// Stage names, in the order the stages run
+const (
+	StageShape      = "shape"       // presence and type of the input's fields
+	StageFields     = "fields"      // rules for individual fields
+	StageCrossField = "cross_field" // relations between fields
+	StageExternal   = "external"    // checks against databases, services, and other systems
+)
+
+var stageNames = [...]string{StageShape, StageFields, StageCrossField, StageExternal}
+
+// Check validates input, recording failures on v. Checks may start asynchronous checks with
+// v.CheckAsync; the pipeline waits for them before deciding whether the stage failed.
+type Check[T any] func(ctx context.Context, v *datacop.Validator, input T)
+
+// Pipeline is a staged validation of inputs of type T. Build it once, typically as a package
+// variable; it is safe to Run concurrently once built.
+type Pipeline[T any] struct {
+	stages [len(stageNames)][]Check[T]
+	skipIf [len(stageNames)][]string // fields whose errors skip the stage; nil means any error
+	opts   []datacop.Option
+}
+
+// New creates an empty pipeline. The options configure the validator created by each Run.
+//
+// Example usage:
+// p := pipeline.New[Order](datacop.WithStableOutput())
+func New[T any](opts ...datacop.Option) *Pipeline[T] {
+	return &Pipeline[T]{opts: slices.Clone(opts)}
+}
+
+// Shape adds checks to the shape stage, which runs first and verifies that required fields are
+// present and have the right type
+func (p *Pipeline[T]) Shape(checks ...Check[T]) *Pipeline[T] {
+	return p.add(0, checks)
+}
+
+// Fields adds checks to the field stage, which runs after the shape stage and applies the rules
+// of individual fields
+func (p *Pipeline[T]) Fields(checks ...Check[T]) *Pipeline[T] {
+	return p.add(1, checks)
+}
+
+// CrossField adds checks to the cross-field stage, which runs after the field stage and compares
+// fields with each other
+func (p *Pipeline[T]) CrossField(checks ...Check[T]) *Pipeline[T] {
+	return p.add(2, checks)
+}
+
+// External adds checks to the external stage, which runs last and consults databases and other
+// services
+func (p *Pipeline[T]) External(checks ...Check[T]) *Pipeline[T] {
+	return p.add(3, checks)
+}
+
+func (p *Pipeline[T]) add(stage int, checks []Check[T]) *Pipeline[T] {
+	p.stages[stage] = append(p.stages[stage], checks...)
+	return p
+}
+
+// Stage configures one stage of a pipeline
+type Stage[T any] struct {
+	p     *Pipeline[T]
+	index int
+}
+
+// Stage returns the stage with the given name, one of the Stage constants, for configuration. It
+// panics if the name is unknown.
+//
+// Example usage:
+// p.Stage(pipeline.StageExternal).SkipIfErrors("email")
+func (p *Pipeline[T]) Stage(name string) *Stage[T] {
+	for i, n := range stageNames {
+		if n == name {
+			return &Stage[T]{p: p, index: i}
+		}
+	}
+	panic(fmt.Sprintf("pipeline: unknown stage %q", name))
+}
+
+// SkipIfErrors makes the stage skip only when one of fields has errors, instead of when any
+// earlier stage recorded an error. A field also matches errors in its group, so "address" covers
+// "address.zip". Use it to run cheap later checks despite unrelated errors while still sparing
+// expensive ones, such as a uniqueness query, when the value they need is already invalid.
+// Without fields, the stage skips on any error, which is the default.
+//
+// Example usage:
+//
+//	p.Stage(pipeline.StageExternal).SkipIfErrors("email")    // the lookup only needs a valid email
+//	p.Stage(pipeline.StageCrossField).SkipIfErrors("start", "end")
+func (s *Stage[T]) SkipIfErrors(fields ...string) *Stage[T] {
+	if len(fields) == 0 {
+		s.p.skipIf[s.index] = nil
+	} else {
+		s.p.skipIf[s.index] = slices.Clone(fields)
+	}
+	return s
+}
+
+// skip reports whether stage i should be skipped given the errors recorded so far
+func (p *Pipeline[T]) skip(i int, v *datacop.Validator) bool {
+	if !v.HasErrors() {
+		return false
+	}
+	if p.skipIf[i] == nil {
+		return true
+	}
+	for _, field := range p.skipIf[i] {
+		if v.HasErrorFor(field) || v.HasErrorsInGroup(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// StageResult describes one stage of a run
+type StageResult struct {
+	Name     string        // one of the Stage constants
+	Ran      bool          // false if the stage was skipped because of earlier errors or the context was done
+	Failed   bool          // whether the stage recorded errors
+	Duration time.Duration // time spent running the stage's checks
+}
+
+// Result is the outcome of running a pipeline
+type Result struct {
+	// Validator holds the errors and annotations recorded by the stages that ran
+	Validator *datacop.Validator
+	// Stages describes every stage with checks, in the order they run
+	Stages []StageResult
+
+	ctxErr error
+}
+
+// Err returns the validator if a stage recorded errors, the context's error if the context was
+// done before every stage ran, or nil
+func (r *Result) Err() error {
+	if err := r.Validator.ErrOrNil(); err != nil {
+		return err
+	}
+	return r.ctxErr
+}
+
+// FailedStage returns the name of the first stage that recorded errors, or "" if none did
+func (r *Result) FailedStage() string {
+	for _, s := range r.Stages {
+		if s.Failed {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// Run validates input stage by stage. Every check of a stage runs, so a stage reports all of its
+// failures, but later stages are skipped once a stage records an error, unless SkipIfErrors
+// narrows the errors that skip them. Stages are also skipped once ctx is done.
+//
+// Example usage:
+//
+//	result := p.Run(ctx, input)
+//	for _, s := range result.Stages {
+//		metrics.ObserveStage(s.Name, s.Duration)
+//	}
+func (p *Pipeline[T]) Run(ctx context.Context, input T) *Result {
+	result := &Result{Validator: datacop.New(p.opts...)}
+	v := result.Validator
+
+	for i, checks := range p.stages {
+		if len(checks) == 0 {
+			continue
+		}
+		stage := StageResult{Name: stageNames[i]}
+		if result.ctxErr == nil {
+			result.ctxErr = ctx.Err()
+		}
+		if result.ctxErr == nil && !p.skip(i, v) {
+			before := len(v.OrderedErrors())
+			start := time.Now()
+			for _, check := range checks {
+				check(ctx, v, input)
+			}
+			v.Wait()
+			stage.Ran = true
+			stage.Duration = time.Since(start)
+			stage.Failed = len(v.OrderedErrors()) > before
+		}
+		result.Stages = append(result.Stages, stage)
+	}
+	return result
+}
@@ -0,0 +1,213 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+	"github.com/patrickward/datacop/pipeline"
+)
+
+type signup struct {
+	Email        string
+	Password     string
+	Confirmation string
+}
+
+// newSignup returns a pipeline that records the stages whose checks ran in ran
+func newSignup(ran *[]string, taken string) *pipeline.Pipeline[signup] {
+	return pipeline.New[signup]().
+		External(func(ctx context.Context, v *datacop.Validator, s signup) {
+			*ran = append(*ran, "external")
+			v.CheckAsync(func() bool { return s.Email != taken }, "email", "is already registered")
+		}).
+		Shape(func(ctx context.Context, v *datacop.Validator, s signup) {
+			*ran = append(*ran, "shape")
+			v.Field("email", s.Email).Validate(is.Required, "is required")
+			v.Field("password", s.Password).Validate(is.Required, "is required")
+		}).
+		Fields(func(ctx context.Context, v *datacop.Validator, s signup) {
+			*ran = append(*ran, "fields")
+			v.Field("email", s.Email).Validate(is.Email, "is not a valid email address")
+		}, func(ctx context.Context, v *datacop.Validator, s signup) {
+			*ran = append(*ran, "fields")
+			v.Field("password", s.Password).Validate(is.MinLength(8), "is too short")
+		}).
+		CrossField(func(ctx context.Context, v *datacop.Validator, s signup) {
+			*ran = append(*ran, "cross_field")
+			v.Field("confirmation", s.Confirmation).Check(s.Confirmation == s.Password, "does not match")
+		})
+}
+
+func TestPipeline_Run(t *testing.T) {
+	valid := signup{Email: "a@example.com", Password: "correct horse", Confirmation: "correct horse"}
+
+	tests := []struct {
+		name       string
+		input      func(s signup) signup
+		wantRan    []string
+		wantFailed string
+		wantErrors map[string]string
+	}{
+		{
+			name:    "valid",
+			input:   func(s signup) signup { return s },
+			wantRan: []string{"shape", "fields", "fields", "cross_field", "external"},
+		},
+		{
+			name:       "shape fails",
+			input:      func(s signup) signup { s.Email, s.Password = "", ""; return s },
+			wantRan:    []string{"shape"},
+			wantFailed: pipeline.StageShape,
+			wantErrors: map[string]string{"email": "is required", "password": "is required"},
+		},
+		{
+			name:       "every field check runs",
+			input:      func(s signup) signup { s.Email, s.Password = "nope", "short"; return s },
+			wantRan:    []string{"shape", "fields", "fields"},
+			wantFailed: pipeline.StageFields,
+			wantErrors: map[string]string{"email": "is not a valid email address", "password": "is too short"},
+		},
+		{
+			name:       "cross field fails",
+			input:      func(s signup) signup { s.Confirmation = "other"; return s },
+			wantRan:    []string{"shape", "fields", "fields", "cross_field"},
+			wantFailed: pipeline.StageCrossField,
+			wantErrors: map[string]string{"confirmation": "does not match"},
+		},
+		{
+			name:       "async external check",
+			input:      func(s signup) signup { s.Email = "taken@example.com"; return s },
+			wantRan:    []string{"shape", "fields", "fields", "cross_field", "external"},
+			wantFailed: pipeline.StageExternal,
+			wantErrors: map[string]string{"email": "is already registered"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			result := newSignup(&ran, "taken@example.com").Run(context.Background(), tt.input(valid))
+
+			assert.Equal(t, tt.wantRan, ran)
+			assert.Equal(t, tt.wantFailed, result.FailedStage())
+			if tt.wantErrors == nil {
+				assert.NoError(t, result.Err())
+				return
+			}
+			require.Error(t, result.Err())
+			assert.Equal(t, tt.wantErrors, result.Validator.Errors())
+		})
+	}
+}
+
+func TestPipeline_StageResults(t *testing.T) {
+	var ran []string
+	result := newSignup(&ran, "").Run(context.Background(), signup{Email: "nope", Password: "long enough"})
+
+	require.Len(t, result.Stages, 4)
+	names := make([]string, len(result.Stages))
+	for i, s := range result.Stages {
+		names[i] = s.Name
+	}
+	assert.Equal(t, []string{"shape", "fields", "cross_field", "external"}, names)
+
+	assert.True(t, result.Stages[0].Ran)
+	assert.False(t, result.Stages[0].Failed)
+	assert.True(t, result.Stages[1].Ran)
+	assert.True(t, result.Stages[1].Failed)
+	assert.False(t, result.Stages[2].Ran)
+	assert.Zero(t, result.Stages[2].Duration)
+	assert.False(t, result.Stages[3].Ran)
+}
+
+func TestPipeline_EmptyStagesOmitted(t *testing.T) {
+	p := pipeline.New[string]().Fields(func(ctx context.Context, v *datacop.Validator, s string) {
+		v.Field("name", s).Validate(is.Required, "is required")
+	})
+
+	result := p.Run(context.Background(), "")
+	require.Len(t, result.Stages, 1)
+	assert.Equal(t, pipeline.StageFields, result.Stages[0].Name)
+	assert.Equal(t, "is required", result.Validator.ErrorFor("name"))
+}
+
+func TestPipeline_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := pipeline.New[string]().
+		Shape(func(ctx context.Context, v *datacop.Validator, s string) { cancel() }).
+		External(func(ctx context.Context, v *datacop.Validator, s string) {
+			t.Error("external stage ran after the context was canceled")
+		})
+
+	result := p.Run(ctx, "input")
+	assert.True(t, errors.Is(result.Err(), context.Canceled))
+	assert.False(t, result.Stages[1].Ran)
+	assert.Equal(t, "", result.FailedStage())
+}
+
+func TestPipeline_Options(t *testing.T) {
+	p := pipeline.New[string](datacop.WithDefaultMessages(map[string]string{"min_length": "needs {min} or more characters"})).
+		Shape(func(ctx context.Context, v *datacop.Validator, s string) {
+			v.Field("name", s).Validate(is.MinLength(3))
+		})
+
+	assert.Equal(t, "needs 3 or more characters", p.Run(context.Background(), "").Validator.ErrorFor("name"))
+}
+
+func TestStage_SkipIfErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        signup
+		skipIf       []string
+		wantExternal bool
+	}{
+		{"unrelated error", signup{Email: "a@example.com", Password: "short", Confirmation: "short"}, []string{"email"}, true},
+		{"listed field has errors", signup{Email: "nope", Password: "long enough", Confirmation: "long enough"}, []string{"email"}, false},
+		{"one of several fields", signup{Email: "a@example.com", Password: "short", Confirmation: "short"}, []string{"email", "password"}, false},
+		{"no fields skips on any error", signup{Email: "a@example.com", Password: "short", Confirmation: "short"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran []string
+			p := newSignup(&ran, "a@example.com")
+			p.Stage(pipeline.StageExternal).SkipIfErrors(tt.skipIf...)
+
+			result := p.Run(context.Background(), tt.input)
+			assert.Equal(t, tt.wantExternal, result.Stages[3].Ran)
+			assert.False(t, result.Stages[2].Ran, "stages without SkipIfErrors still skip on any error")
+			if tt.wantExternal {
+				assert.True(t, result.Stages[3].Failed)
+				assert.Equal(t, "is already registered", result.Validator.ErrorFor("email"))
+				assert.Equal(t, pipeline.StageFields, result.FailedStage())
+			}
+		})
+	}
+}
+
+func TestStage_SkipIfErrorsGroup(t *testing.T) {
+	lookups := 0
+	p := pipeline.New[map[string]string]().
+		Fields(func(ctx context.Context, v *datacop.Validator, m map[string]string) {
+			v.Group("address").Field("zip", m["zip"]).Validate(is.Required, "is required")
+		}).
+		External(func(ctx context.Context, v *datacop.Validator, m map[string]string) {
+			lookups++
+		})
+	p.Stage(pipeline.StageExternal).SkipIfErrors("address")
+
+	p.Run(context.Background(), map[string]string{})
+	assert.Zero(t, lookups, "errors in the group skip the stage")
+	p.Run(context.Background(), map[string]string{"zip": "12345"})
+	assert.Equal(t, 1, lookups)
+}
+
+func TestPipeline_UnknownStage(t *testing.T) {
+	assert.Panics(t, func() { pipeline.New[string]().Stage("database") })
+}
@@ -0,0 +1,59 @@
+package datacop
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position is the location of a field in the document it was decoded from. Which parts are set
+// depends on the source: JSON documents set the byte offset as well as the line and column, YAML
+// documents the line and column, and multipart forms the name of the part.
+type Position struct {
+	Offset int    `json:"offset,omitempty"` // byte offset from the start of the document
+	Line   int    `json:"line,omitempty"`   // line number, starting at 1
+	Column int    `json:"column,omitempty"` // column number, starting at 1
+	Part   string `json:"part,omitempty"`   // name of a multipart form part
+}
+
+// String describes the position, such as "line 3, column 5" or `part "avatar"`
+func (p Position) String() string {
+	var parts []string
+	if p.Line > 0 {
+		parts = append(parts, fmt.Sprintf("line %d, column %d", p.Line, p.Column))
+	} else if p.Offset > 0 {
+		parts = append(parts, fmt.Sprintf("offset %d", p.Offset))
+	}
+	if p.Part != "" {
+		parts = append(parts, fmt.Sprintf("part %q", p.Part))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SetPosition records where a field appears in the source document. Errors recorded for the field
+// afterwards carry the position in ValidationError.Position, as do errors for fields below it
+// that have no position of their own, such as a missing "address.zip" reported at "address".
+// Decoding helpers such as schema.ValidateJSON and web.FormPositions call it for every field.
+//
+// Example usage:
+// v.SetPosition("spec.replicas", datacop.Position{Line: 14, Column: 3})
+func (v *Validator) SetPosition(field string, pos Position) {
+	if v.positions == nil {
+		v.positions = make(map[string]Position)
+	}
+	v.positions[field] = pos
+}
+
+// PositionOf returns the position recorded for a field or, if it has none, for the closest group
+// containing it
+func (v *Validator) PositionOf(field string) (Position, bool) {
+	for {
+		if pos, ok := v.positions[field]; ok {
+			return pos, true
+		}
+		i := strings.LastIndexAny(field, ".[")
+		if i <= 0 {
+			return Position{}, false
+		}
+		field = field[:i]
+	}
+}
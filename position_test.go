@@ -0,0 +1,88 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestValidator_PositionOf(t *testing.T) {
+	v := datacop.New()
+	v.SetPosition("address", datacop.Position{Line: 2, Column: 1})
+	v.SetPosition("address.zip", datacop.Position{Line: 4, Column: 3})
+	v.SetPosition("items", datacop.Position{Offset: 40, Line: 1, Column: 41})
+
+	tests := []struct {
+		field  string
+		want   datacop.Position
+		wantOK bool
+	}{
+		{"address.zip", datacop.Position{Line: 4, Column: 3}, true},
+		{"address.city", datacop.Position{Line: 2, Column: 1}, true},
+		{"items[2].sku", datacop.Position{Offset: 40, Line: 1, Column: 41}, true},
+		{"name", datacop.Position{}, false},
+		{"", datacop.Position{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, ok := v.PositionOf(tt.field)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  datacop.Position
+		want string
+	}{
+		{"line and column", datacop.Position{Offset: 12, Line: 3, Column: 5}, "line 3, column 5"},
+		{"offset only", datacop.Position{Offset: 12}, "offset 12"},
+		{"part", datacop.Position{Part: "avatar"}, `part "avatar"`},
+		{"zero", datacop.Position{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pos.String())
+		})
+	}
+}
+
+func TestValidator_ErrorPositions(t *testing.T) {
+	v := datacop.New()
+	v.SetPosition("email", datacop.Position{Offset: 10, Line: 2, Column: 3})
+	v.SetPosition("address.zip", datacop.Position{Offset: 50, Line: 5, Column: 5})
+
+	v.Check(false, "email", "is invalid")
+	v.CheckStandalone(false, "form is invalid")
+	v.Group("address").Validate(zipOnly{})
+	v.AddValidationError(datacop.ValidationError{Field: "email", Message: "is taken", Position: &datacop.Position{Part: "email"}})
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 4)
+	assert.Equal(t, &datacop.Position{Offset: 10, Line: 2, Column: 3}, errs[0].Position)
+	assert.Equal(t, &datacop.Position{Part: "email"}, errs[1].Position, "explicit positions are kept")
+	assert.Nil(t, errs[2].Position, "standalone errors have no position")
+	assert.Equal(t, &datacop.Position{Offset: 50, Line: 5, Column: 5}, errs[3].Position)
+
+	data, err := v.MarshalJSONDetailed()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"position":{"offset":10,"line":2,"column":3}`)
+
+	v.Clear()
+	v.Check(false, "email", "is invalid")
+	assert.Nil(t, v.OrderedErrors()[0].Position)
+}
+
+type zipOnly struct{}
+
+func (zipOnly) Validate(v *datacop.Validator) {
+	v.Check(false, "zip", "is required")
+}
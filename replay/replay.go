@@ -0,0 +1,265 @@
+// Package replay records incoming payloads and their validation outcomes, and replays them
+// against a new version of the rules offline, reporting the payloads whose outcome changes. It is
+// the safest way to make validation stricter on live traffic: record for a while, replay the
+// recording against the new rules, and review what they would have rejected.
+//
+// Recordings are JSON lines, one Entry per payload. Sensitive fields are redacted before they
+// are written, along with the messages and params of errors on them, and errors on redacted
+// fields are ignored when replaying, since the rules never see their original values.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/patrickward/datacop"
+)
+
+// Entry is a recorded payload and the errors its validation produced, in canonical form
+type Entry struct {
+	ID       string                    `json:"id,omitempty"`
+	Time     time.Time                 `json:"time"`
+	Payload  map[string]any            `json:"payload"`
+	Redacted []string                  `json:"redacted,omitempty"` // paths whose values were replaced with datacop.RedactedInput
+	Errors   []datacop.ValidationError `json:"errors,omitempty"`
+}
+
+// ValidateFunc validates a payload, recording failures on v
+type ValidateFunc func(v *datacop.Validator, payload map[string]any)
+
+// Recorder writes entries to a recording. It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	redact []string
+}
+
+// NewRecorder creates a recorder that writes entries to w as JSON lines, replacing the values
+// at the redact paths with datacop.RedactedInput. A path is a dotted list of keys, such as
+// "card.number"; a slice along the path is redacted in each of its elements.
+//
+// Example usage:
+//
+//	f, _ := os.OpenFile("signups.jsonl", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+//	rec := replay.NewRecorder(f, "password", "card.number")
+func NewRecorder(w io.Writer, redact ...string) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w), redact: redact}
+}
+
+// Record writes payload, with its sensitive fields redacted, and the errors recorded on v as an
+// entry identified by id, which may be empty. Errors on redacted fields keep only their field and
+// code, since their messages, params, and context may repeat the value. The payload itself is not
+// modified.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	validateSignup(v, payload)
+//	if err := rec.Record(requestID, payload, v); err != nil {
+//		log.Printf("recording payload: %v", err)
+//	}
+func (r *Recorder) Record(id string, payload map[string]any, v *datacop.Validator) error {
+	e := Entry{ID: id, Time: time.Now().UTC(), Payload: payload}
+	for _, path := range r.redact {
+		var found bool
+		e.Payload, found = redactMap(e.Payload, strings.Split(path, "."))
+		if found {
+			e.Redacted = append(e.Redacted, path)
+		}
+	}
+	if v != nil {
+		e.Errors = v.Canonical()
+		for i, err := range e.Errors {
+			if redacted(err.Field, r.redact) {
+				e.Errors[i] = datacop.ValidationError{Field: err.Field, Code: err.Code}
+			}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(e); err != nil {
+		return fmt.Errorf("replay: recording entry: %w", err)
+	}
+	return nil
+}
+
+// redactMap returns a copy of m with the value at path replaced, and whether the path was found.
+// m is returned unchanged if it was not.
+func redactMap(m map[string]any, path []string) (map[string]any, bool) {
+	value, ok := m[path[0]]
+	if !ok {
+		return m, false
+	}
+	if len(path) > 1 {
+		if value, ok = redactValue(value, path[1:]); !ok {
+			return m, false
+		}
+	} else {
+		value = datacop.RedactedInput
+	}
+	c := make(map[string]any, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	c[path[0]] = value
+	return c, true
+}
+
+// redactValue redacts path in a nested map, or in each element of a slice
+func redactValue(value any, path []string) (any, bool) {
+	switch value := value.(type) {
+	case map[string]any:
+		return redactMap(value, path)
+	case []any:
+		var c []any
+		for i, elem := range value {
+			if redacted, ok := redactValue(elem, path); ok {
+				if c == nil {
+					c = append([]any(nil), value...)
+				}
+				c[i] = redacted
+			}
+		}
+		if c == nil {
+			return value, false
+		}
+		return c, true
+	}
+	return value, false
+}
+
+// Change is a recorded entry whose outcome differs under the new rules. Diff.OnlyA lists the
+// errors that no longer occur and Diff.OnlyB those that are new.
+type Change struct {
+	Entry Entry             `json:"entry"`
+	Diff  datacop.ErrorDiff `json:"diff"`
+}
+
+// Rejected reports whether the entry passed validation when recorded but fails under the new
+// rules
+func (c Change) Rejected() bool {
+	return len(c.Entry.Errors) == 0 && len(c.Diff.OnlyB) > 0
+}
+
+// Accepted reports whether the entry failed validation when recorded but passes under the new
+// rules
+func (c Change) Accepted() bool {
+	return len(c.Entry.Errors) > 0 && len(c.Diff.OnlyA) == len(c.Entry.Errors) && len(c.Diff.OnlyB) == 0
+}
+
+// Report lists the outcome changes found by Replay
+type Report struct {
+	Total   int      `json:"total"` // entries replayed
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Rejected returns the changes for entries that passed when recorded and fail now
+func (r *Report) Rejected() []Change {
+	return r.filter(Change.Rejected)
+}
+
+// Accepted returns the changes for entries that failed when recorded and pass now
+func (r *Report) Accepted() []Change {
+	return r.filter(Change.Accepted)
+}
+
+func (r *Report) filter(keep func(Change) bool) []Change {
+	var changes []Change
+	for _, c := range r.Changes {
+		if keep(c) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String summarizes the report, followed by the error differences of each changed entry
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d replayed, %d changed (%d newly rejected, %d newly accepted)",
+		r.Total, len(r.Changes), len(r.Rejected()), len(r.Accepted()))
+	for _, c := range r.Changes {
+		fmt.Fprintf(&sb, "\n\n%s:\n%s", c.label(), c.Diff)
+	}
+	return sb.String()
+}
+
+// label identifies the entry by its ID, or by its recording time if it has none
+func (c Change) label() string {
+	if c.Entry.ID != "" {
+		return c.Entry.ID
+	}
+	return c.Entry.Time.Format(time.RFC3339Nano)
+}
+
+// Replay reads the entries of a recording from rd, validates each payload with fn, and reports
+// the entries whose errors differ from those recorded. Errors are compared as datacop.Diff does;
+// errors on redacted fields, or fields within them, are ignored on both sides.
+//
+// Example usage:
+//
+//	f, _ := os.Open("signups.jsonl")
+//	report, err := replay.Replay(f, validateSignupV2)
+//	if err != nil {
+//		return err
+//	}
+//	for _, c := range report.Rejected() {
+//		fmt.Printf("%s would now be rejected:\n%s\n", c.Entry.ID, c.Diff)
+//	}
+func Replay(rd io.Reader, fn ValidateFunc) (*Report, error) {
+	report := &Report{}
+	dec := json.NewDecoder(rd)
+	for {
+		var e Entry
+		if err := dec.Decode(&e); errors.Is(err, io.EOF) {
+			return report, nil
+		} else if err != nil {
+			return report, fmt.Errorf("replay: reading entry %d: %w", report.Total+1, err)
+		}
+		report.Total++
+
+		before, after := datacop.New(), datacop.New()
+		for _, err := range e.Errors {
+			if !redacted(err.Field, e.Redacted) {
+				before.AddValidationError(err)
+			}
+		}
+		fn(after, e.Payload)
+		after = withoutRedacted(after, e.Redacted)
+
+		if d := datacop.Diff(before, after); !d.Empty() {
+			e.Errors = before.Canonical()
+			report.Changes = append(report.Changes, Change{Entry: e, Diff: d})
+		}
+	}
+}
+
+// withoutRedacted returns v, or a copy of its errors without those on redacted fields
+func withoutRedacted(v *datacop.Validator, paths []string) *datacop.Validator {
+	if len(paths) == 0 {
+		return v
+	}
+	kept := datacop.New()
+	for _, e := range v.Canonical() {
+		if !redacted(e.Field, paths) {
+			kept.AddValidationError(e)
+		}
+	}
+	return kept
+}
+
+// redacted reports whether field is one of the redacted paths, or is nested within one
+func redacted(field string, paths []string) bool {
+	for _, p := range paths {
+		if field == p || strings.HasPrefix(field, p+".") || strings.HasPrefix(field, p+"[") {
+			return true
+		}
+	}
+	return false
+}
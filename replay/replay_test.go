@@ -0,0 +1,149 @@
+package replay_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+	"github.com/patrickward/datacop/replay"
+)
+
+func validateV1(v *datacop.Validator, p map[string]any) {
+	v.Field("email", p["email"]).Validate(is.Required, "is required").Validate(is.Email, "is not valid")
+	v.Field("password", p["password"]).Validate(is.MinLength(8))
+}
+
+func validateV2(v *datacop.Validator, p map[string]any) {
+	v.Field("email", p["email"]).Validate(is.Required, "is required").Validate(is.Email, "is not valid")
+	v.Field("password", p["password"]).Validate(is.MinLength(12))
+	v.Field("name", p["name"]).Validate(is.MinLength(2))
+}
+
+// record validates each payload with fn and returns the recording
+func record(t *testing.T, fn replay.ValidateFunc, payloads ...map[string]any) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf, "password", "card.number")
+	for i, p := range payloads {
+		v := datacop.New()
+		fn(v, p)
+		require.NoError(t, rec.Record(string(rune('a'+i)), p, v))
+	}
+	return &buf
+}
+
+func TestRecorder_Redacts(t *testing.T) {
+	payload := map[string]any{
+		"email":    "someone@",
+		"password": "hunter2",
+		"card":     map[string]any{"number": "4111111111111111", "brand": "visa"},
+	}
+	buf := record(t, validateV1, payload)
+
+	var e replay.Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &e))
+	assert.Equal(t, "a", e.ID)
+	assert.False(t, e.Time.IsZero())
+	assert.Equal(t, map[string]any{
+		"email":    "someone@",
+		"password": datacop.RedactedInput,
+		"card":     map[string]any{"number": datacop.RedactedInput, "brand": "visa"},
+	}, e.Payload)
+	assert.Equal(t, []string{"password", "card.number"}, e.Redacted)
+	require.Len(t, e.Errors, 2)
+	assert.Equal(t, "email", e.Errors[0].Field)
+	assert.Equal(t, "password", e.Errors[1].Field)
+
+	assert.Equal(t, "hunter2", payload["password"], "the payload itself is not modified")
+	assert.Equal(t, "4111111111111111", payload["card"].(map[string]any)["number"])
+}
+
+func TestRecorder_RedactsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf, "password")
+	v := datacop.New()
+	v.AddValidationError(datacop.ValidationError{
+		Field: "password", Code: "min_length", Message: "hunter2 is too short",
+		Params: datacop.Params{"value": "hunter2"}, Context: map[string]any{"input": "hunter2"},
+	})
+	v.AddCodedError("email", "email", "someone@ is not valid")
+	require.NoError(t, rec.Record("", map[string]any{"password": "hunter2", "email": "someone@"}, v))
+
+	assert.NotContains(t, buf.String(), "hunter2")
+	var e replay.Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &e))
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "email", Code: "email", Message: "someone@ is not valid"},
+		{Field: "password", Code: "min_length"},
+	}, e.Errors)
+}
+
+func TestRecorder_RedactsSliceElements(t *testing.T) {
+	var buf bytes.Buffer
+	rec := replay.NewRecorder(&buf, "cards.number", "missing.path")
+	payload := map[string]any{"cards": []any{
+		map[string]any{"number": "4111111111111111"},
+		"not a map",
+	}}
+	require.NoError(t, rec.Record("", payload, nil))
+
+	var e replay.Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &e))
+	assert.Equal(t, []any{map[string]any{"number": datacop.RedactedInput}, "not a map"}, e.Payload["cards"])
+	assert.Equal(t, []string{"cards.number"}, e.Redacted)
+	assert.Empty(t, e.Errors)
+}
+
+func TestReplay(t *testing.T) {
+	buf := record(t, validateV1,
+		map[string]any{"email": "a@example.com", "name": "Al", "password": "correct horse"}, // unchanged
+		map[string]any{"email": "b@example.com", "name": "B", "password": "correct horse"},  // newly rejected
+		map[string]any{"email": "c@", "name": "Cy", "password": "correct horse"},            // still rejected
+		map[string]any{"email": "d@example.com", "name": "Di", "password": "short"},
+	)
+
+	report, err := replay.Replay(buf, validateV2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Total)
+	require.Len(t, report.Changes, 1, "errors on the redacted password are ignored")
+
+	c := report.Changes[0]
+	assert.Equal(t, "b", c.Entry.ID)
+	assert.True(t, c.Rejected())
+	assert.False(t, c.Accepted())
+	assert.Empty(t, c.Diff.OnlyA)
+	require.Len(t, c.Diff.OnlyB, 1)
+	assert.Equal(t, "min_length", c.Diff.OnlyB[0].Code)
+	assert.Len(t, report.Rejected(), 1)
+	assert.Empty(t, report.Accepted())
+
+	assert.Equal(t, "4 replayed, 1 changed (1 newly rejected, 0 newly accepted)\n\n"+
+		"b:\n+ name: must be at least 2 characters (min_length)", report.String())
+}
+
+func TestReplay_Accepted(t *testing.T) {
+	buf := record(t, validateV2, map[string]any{"email": "a@example.com", "name": "A", "password": "battery staple"})
+
+	report, err := replay.Replay(buf, validateV1)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 1)
+	assert.True(t, report.Changes[0].Accepted())
+	assert.Len(t, report.Accepted(), 1)
+	assert.Empty(t, report.Rejected())
+}
+
+func TestReplay_InvalidRecording(t *testing.T) {
+	buf := record(t, validateV1, map[string]any{"email": "a@example.com"})
+	buf.WriteString("{not json\n")
+
+	report, err := replay.Replay(buf, validateV1)
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "replay: reading entry 2: "))
+	assert.Equal(t, 1, report.Total)
+}
@@ -0,0 +1,62 @@
+package datacop
+
+import "fmt"
+
+// Failure describes a failed check, as passed to a Reporter
+type Failure struct {
+	Field   string // the field name, or StandaloneErrorKey for standalone errors
+	Rule    string // the rule name or error code, or "" if the check had neither
+	Kind    string // the Go type of the value, such as "string" or "[]int", "nil", or "" if unknown
+	Message string
+	Context map[string]any // the metadata set with SetContextValue, or nil
+}
+
+// Reporter observes failed checks, for logging or metrics. Reporters are called synchronously
+// as each error is recorded, so they should be fast.
+type Reporter interface {
+	Report(f Failure)
+}
+
+// ReporterFunc adapts a function to the Reporter interface
+type ReporterFunc func(f Failure)
+
+// Report calls fn(f)
+func (fn ReporterFunc) Report(f Failure) {
+	fn(f)
+}
+
+// WithReporter adds a reporter that is called for every error recorded on the validator,
+// whether by a check, a rule, or one of the Add methods. Errors copied by Merge are not
+// reported again.
+//
+// Example usage:
+//
+//	failures := prometheus.NewCounterVec(opts, []string{"field", "rule"})
+//	v := datacop.New(datacop.WithReporter(datacop.ReporterFunc(func(f datacop.Failure) {
+//		failures.WithLabelValues(f.Field, f.Rule).Inc()
+//	})))
+func WithReporter(r Reporter) Option {
+	return func(v *Validator) {
+		v.reporters = append(v.reporters, r)
+	}
+}
+
+// report passes a recorded error to the validator's reporters
+func (v *Validator) report(e ValidationError, kind string) {
+	if len(v.reporters) == 0 {
+		return
+	}
+	e = v.labeled(e)
+	f := Failure{Field: e.Field, Rule: e.Code, Kind: kind, Message: e.Message, Context: e.Context}
+	for _, r := range v.reporters {
+		r.Report(f)
+	}
+}
+
+// kindOf returns the Go type of value for a Failure
+func kindOf(value any) string {
+	if value == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%T", value)
+}
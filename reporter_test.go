@@ -0,0 +1,53 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestWithReporter(t *testing.T) {
+	var failures []datacop.Failure
+	v := datacop.New(datacop.WithReporter(datacop.ReporterFunc(func(f datacop.Failure) {
+		failures = append(failures, f)
+	})))
+
+	v.Field("name", "Al").Validate(is.MinLength(3))
+	v.Field("age", 15).Check(false, "must be an adult")
+	v.Field("tags", []string{"a"}).When(true).Check(false, "too few tags")
+	v.Field("email", nil).Validate(is.Required, "is required")
+	v.CheckCode(false, "plan", "unknown_plan", "is not a plan")
+	v.AddStandaloneError("form has expired")
+	v.Field("ok", "fine").Validate(is.Required, "is required")
+
+	assert.Equal(t, []datacop.Failure{
+		{Field: "name", Rule: "min_length", Kind: "string", Message: "must be at least 3 characters"},
+		{Field: "age", Kind: "int", Message: "must be an adult"},
+		{Field: "tags", Kind: "[]string", Message: "too few tags"},
+		{Field: "email", Rule: "required", Kind: "nil", Message: "is required"},
+		{Field: "plan", Rule: "unknown_plan", Message: "is not a plan"},
+		{Field: datacop.StandaloneErrorKey, Message: "form has expired"},
+	}, failures)
+
+	// Merged errors are not reported again
+	other := datacop.New()
+	other.AddError("zip", "is required")
+	v.Merge(other)
+	assert.Len(t, failures, 6)
+}
+
+func TestWithReporter_Multiple(t *testing.T) {
+	counts := map[string]int{}
+	count := func(name string) datacop.Reporter {
+		return datacop.ReporterFunc(func(datacop.Failure) { counts[name]++ })
+	}
+
+	v := datacop.New(datacop.WithReporter(count("log")), datacop.WithReporter(count("metrics")))
+	v.AddError("name", "is required")
+	v.AddError("email", "is required")
+
+	assert.Equal(t, map[string]int{"log": 2, "metrics": 2}, counts)
+}
@@ -0,0 +1,131 @@
+package datacop
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Result is an immutable snapshot of a validator's errors, taken by Validator.Result. Its params
+// and context are copied deeply, down to the slices and maps they hold, so it can be passed to
+// other goroutines, cached, or serialized while the validator goes on recording errors or is
+// cleared and reused. Values behind pointers are the only memory still shared.
+type Result struct {
+	Passed           bool              `json:"passed"`
+	Errors           []ValidationError `json:"errors,omitempty"`            // field errors, in the order of OrderedErrors
+	StandaloneErrors []string          `json:"standalone_errors,omitempty"` // messages of standalone errors
+	StartedAt        time.Time         `json:"started_at"`                  // when the validator was created or last cleared
+	CompletedAt      time.Time         `json:"completed_at"`                // when the snapshot was taken
+}
+
+// Result returns a snapshot of the errors recorded so far. It is safe to call on a nil validator,
+// which has passed.
+//
+// Example usage:
+//
+//	form.Validate(v)
+//	result := v.Result()
+//	go audit.Record(result) // v may be reused meanwhile
+func (v *Validator) Result() Result {
+	r := Result{Passed: !v.HasErrors(), CompletedAt: time.Now()}
+	if v == nil {
+		return r
+	}
+	r.StartedAt = v.started
+
+	for _, e := range v.OrderedErrors() {
+		if e.Field == StandaloneErrorKey {
+			r.StandaloneErrors = append(r.StandaloneErrors, e.Message)
+			continue
+		}
+		e.Params = cloneMap(e.Params)
+		e.Context = cloneMap(e.Context)
+		if e.Position != nil {
+			pos := *e.Position
+			e.Position = &pos
+		}
+		r.Errors = append(r.Errors, e)
+	}
+	return r
+}
+
+// Duration returns the time between the start of validation and the snapshot
+func (r Result) Duration() time.Duration {
+	return r.CompletedAt.Sub(r.StartedAt)
+}
+
+// HasErrorFor returns true if the snapshot has any errors for a field
+func (r Result) HasErrorFor(field string) bool {
+	for _, e := range r.Errors {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorFor returns the messages recorded for a field joined as by Validator.ErrorFor, or an empty
+// string
+func (r Result) ErrorFor(field string) string {
+	var messages []string
+	for _, e := range r.Errors {
+		if e.Field == field {
+			messages = append(messages, e.Message)
+		}
+	}
+	return strings.Join(messages, ", ")
+}
+
+// cloneMap returns a deep copy of m, as made by deepCopy
+func cloneMap[M ~map[string]any](m M) M {
+	if m == nil {
+		return nil
+	}
+	c := make(M, len(m))
+	for k, value := range m {
+		if value != nil {
+			value = deepCopy(reflect.ValueOf(value)).Interface()
+		}
+		c[k] = value
+	}
+	return c
+}
+
+// deepCopy copies slices, arrays, and maps, and the values they hold, recursively. Other values,
+// including pointers and structs, are copied as they are.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package datacop_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestValidator_Result(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func(v *datacop.Validator)
+		wantPassed     bool
+		wantErrors     []datacop.ValidationError
+		wantStandalone []string
+	}{
+		{
+			name:       "no errors",
+			setup:      func(v *datacop.Validator) {},
+			wantPassed: true,
+		},
+		{
+			name: "field and standalone errors",
+			setup: func(v *datacop.Validator) {
+				v.Field("name", "").Validate(is.Required, "is required")
+				v.CheckStandalone(false, "form is invalid")
+				v.Field("password", "abc").Validate(is.MinLength(8))
+			},
+			wantErrors: []datacop.ValidationError{
+				{Field: "name", Code: "required", Message: "is required"},
+				{Field: "password", Code: "min_length", Message: "must be at least 8 characters", Params: datacop.Params{"min": 8}},
+			},
+			wantStandalone: []string{"form is invalid"},
+		},
+		{
+			name: "only standalone errors",
+			setup: func(v *datacop.Validator) {
+				v.AddStandaloneError("try again later")
+			},
+			wantStandalone: []string{"try again later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			tt.setup(v)
+			result := v.Result()
+
+			assert.Equal(t, tt.wantPassed, result.Passed)
+			assert.Equal(t, tt.wantErrors, result.Errors)
+			assert.Equal(t, tt.wantStandalone, result.StandaloneErrors)
+			assert.False(t, result.StartedAt.IsZero())
+			assert.False(t, result.CompletedAt.Before(result.StartedAt))
+			assert.GreaterOrEqual(t, result.Duration(), time.Duration(0))
+		})
+	}
+}
+
+func TestValidator_Result_IsSnapshot(t *testing.T) {
+	v := datacop.New()
+	v.Field("password", "abc").Validate(is.MinLength(8), "is too short")
+	result := v.Result()
+
+	v.Field("email", "").Validate(is.Required, "is required")
+	v.ValidationErrors()["password"][0].Params["min"] = 99
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, 8, result.Errors[0].Params["min"])
+
+	v.Clear()
+	assert.False(t, result.Passed)
+	assert.True(t, result.HasErrorFor("password"))
+	assert.Equal(t, "is too short", result.ErrorFor("password"))
+	assert.False(t, result.HasErrorFor("email"))
+	assert.Empty(t, result.ErrorFor("email"))
+	assert.True(t, v.Result().Passed)
+}
+
+func TestValidator_Result_CopiesParamsDeeply(t *testing.T) {
+	values := []string{"red", "green"}
+	v := datacop.New()
+	v.SetContextValue("tags", map[string]any{"source": []any{"form"}})
+	oneOf := datacop.Named("one_of", datacop.Params{"values": values}, func(any) bool { return false })
+	v.Field("color", "blue").Validate(oneOf, "is not allowed")
+	result := v.Result()
+
+	values[0] = "pink"
+	v.ValidationErrors()["color"][0].Context["tags"].(map[string]any)["source"].([]any)[0] = "api"
+
+	assert.Equal(t, []string{"red", "green"}, result.Errors[0].Params["values"])
+	assert.Equal(t, map[string]any{"source": []any{"form"}}, result.Errors[0].Context["tags"])
+}
+
+func TestValidator_Result_NilValidator(t *testing.T) {
+	var v *datacop.Validator
+	result := v.Result()
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Errors)
+}
+
+func TestResult_JSON(t *testing.T) {
+	v := datacop.New()
+	v.Field("name", "").Validate(is.Required, "is required")
+	v.CheckStandalone(false, "form is invalid")
+
+	data, err := json.Marshal(v.Result())
+	require.NoError(t, err)
+
+	var decoded datacop.Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.False(t, decoded.Passed)
+	assert.Equal(t, []datacop.ValidationError{{Field: "name", Code: "required", Message: "is required"}}, decoded.Errors)
+	assert.Equal(t, []string{"form is invalid"}, decoded.StandaloneErrors)
+	assert.Contains(t, string(data), `"started_at":`)
+	assert.Contains(t, string(data), `"completed_at":`)
+}
@@ -0,0 +1,36 @@
+package datacop
+
+// ruleMeta is the metadata attached to a validation function by Named, RegisterRule,
+// Annotated, Weighted, and Memoized. It is kept in a table keyed by the function, so rules stay
+// plain functions that can be called directly.
+type ruleMeta struct {
+	rule     ValidationFunc // the function the metadata is attached to
+	check    ValidationFunc // runs the rule; rule calls it
+	annotate func(value any, annotate AnnotateFunc) bool
+	name     string
+	params   Params
+	weighted bool
+	weight   float64
+	memoized bool
+}
+
+// attach returns a new function that runs check and carries the metadata in m
+func (m ruleMeta) attach(check ValidationFunc) ValidationFunc {
+	meta := &m
+	meta.check = check
+	// The function refers to meta, so the table entry lives exactly as long as the function
+	meta.rule = func(value any) bool { return meta.check(value) }
+	register(meta.rule, meta)
+	return meta.rule
+}
+
+// metaOf returns a copy of the metadata attached to fn, or empty metadata if there is none
+func metaOf(fn ValidationFunc) ruleMeta {
+	if fn == nil {
+		return ruleMeta{}
+	}
+	if m := lookup(fn); m != nil {
+		return *m
+	}
+	return ruleMeta{}
+}
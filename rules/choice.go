@@ -0,0 +1,54 @@
+package rules
+
+import (
+	"slices"
+
+	"github.com/patrickward/datacop"
+)
+
+const (
+	// CodeUnknownChoice is recorded when a selection is not one of its options, such as a parent
+	// selection with no entry in the allowed map
+	CodeUnknownChoice = "unknown_choice"
+
+	// CodeInvalidChoice is recorded when a child selection is not allowed for its parent
+	CodeInvalidChoice = "invalid_choice"
+)
+
+// DependentChoice returns a rule for cascading select inputs, such as country → state, where the
+// options for childField depend on the selection in parentField. allowed maps each parent value
+// to its valid child values.
+//
+// Blank selections are ignored so that requiredness can be checked separately. A parent value
+// that is not a key of allowed is reported on parentField; a child value that is not allowed for
+// the selected parent is reported on childField.
+//
+// Example usage:
+//
+//	states := map[string][]string{
+//		"US": {"CA", "NY", "TX"},
+//		"CA": {"BC", "ON", "QC"},
+//	}
+//	rules.Apply(v, values, rules.DependentChoice("country", "state", states))
+func DependentChoice(parentField, childField string, allowed map[string][]string) Rule {
+	return func(v *datacop.Validator, values Values) {
+		parent, ok := values.String(parentField)
+		if ok && parent == "" {
+			return
+		}
+
+		options, known := allowed[parent]
+		if !ok || !known {
+			v.AddCodedError(parentField, CodeUnknownChoice, "is not a valid choice")
+			return
+		}
+
+		child, ok := values.String(childField)
+		if ok && child == "" {
+			return
+		}
+		if !ok || !slices.Contains(options, child) {
+			v.AddCodedError(childField, CodeInvalidChoice, "is not valid for the selected "+parentField)
+		}
+	}
+}
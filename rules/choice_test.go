@@ -0,0 +1,59 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/rules"
+)
+
+func TestDependentChoice(t *testing.T) {
+	states := map[string][]string{
+		"US": {"CA", "NY", "TX"},
+		"CA": {"BC", "ON", "QC"},
+	}
+
+	tests := []struct {
+		name       string
+		values     rules.Values
+		wantErrors map[string]string
+	}{
+		{"valid pair", rules.Values{"country": "US", "state": "NY"}, map[string]string{}},
+		{"child from another parent", rules.Values{"country": "US", "state": "ON"}, map[string]string{
+			"state": "is not valid for the selected country",
+		}},
+		{"unknown parent", rules.Values{"country": "MX", "state": "NY"}, map[string]string{
+			"country": "is not a valid choice",
+		}},
+		{"blank parent", rules.Values{"state": "NY"}, map[string]string{}},
+		{"blank child", rules.Values{"country": "CA", "state": ""}, map[string]string{}},
+		{"non-string child", rules.Values{"country": "CA", "state": 3}, map[string]string{
+			"state": "is not valid for the selected country",
+		}},
+		{"non-string parent", rules.Values{"country": 1, "state": "NY"}, map[string]string{
+			"country": "is not a valid choice",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			rules.Apply(v, tt.values, rules.DependentChoice("country", "state", states))
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestDependentChoice_Codes(t *testing.T) {
+	v := datacop.New()
+	rules.Apply(v, rules.Values{"country": "US", "state": "QC"},
+		rules.DependentChoice("country", "state", map[string][]string{"US": {"NY"}}),
+	)
+
+	errs := v.ValidationErrors()["state"]
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, rules.CodeInvalidChoice, errs[0].Code)
+	}
+}
@@ -0,0 +1,33 @@
+package rules
+
+import (
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// CodeConsentRequired is recorded when a consent field has not been accepted
+const CodeConsentRequired = "consent_required"
+
+// ConsentRequired returns a rule requiring each of fields to be affirmatively accepted, as
+// checked by is.Accepted. Missing or unchecked fields are reported as errors. Accepted fields
+// are recorded as audit annotations carrying the submitted value, so the fact of consent can
+// be stored alongside the record it applies to.
+//
+// Example usage:
+//
+//	rules.Apply(v, values, rules.ConsentRequired("terms", "privacy_policy"))
+//	for _, a := range v.AnnotationsOfKind(datacop.AnnotationAudit) {
+//		audit.Record(userID, a.Field, a.Data["value"])
+//	}
+func ConsentRequired(fields ...string) Rule {
+	return func(v *datacop.Validator, values Values) {
+		for _, field := range fields {
+			value := values[field]
+			if !is.Accepted(value) {
+				v.AddCodedError(field, CodeConsentRequired, "must be accepted")
+				continue
+			}
+			v.Annotate(field, datacop.AnnotationAudit, "consent given", map[string]any{"value": value})
+		}
+	}
+}
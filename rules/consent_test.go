@@ -0,0 +1,28 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/rules"
+)
+
+func TestConsentRequired(t *testing.T) {
+	v := datacop.New()
+	rules.Apply(v, rules.Values{"terms": "on", "marketing": "", "privacy": true},
+		rules.ConsentRequired("terms", "privacy", "marketing", "cookies"),
+	)
+
+	assert.Equal(t, map[string]string{
+		"marketing": "must be accepted",
+		"cookies":   "must be accepted",
+	}, v.Errors())
+	assert.Equal(t, rules.CodeConsentRequired, v.ValidationErrors()["cookies"][0].Code)
+
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "terms", Kind: datacop.AnnotationAudit, Message: "consent given", Data: map[string]any{"value": "on"}},
+		{Field: "privacy", Kind: datacop.AnnotationAudit, Message: "consent given", Data: map[string]any{"value": true}},
+	}, v.AnnotationsOfKind(datacop.AnnotationAudit))
+}
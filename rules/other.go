@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/patrickward/datacop"
+)
+
+// CodeOtherRequired is recorded when the "other" option is selected without describing it
+const CodeOtherRequired = "other_required"
+
+// OneOfOrOther returns a rule for a select input that offers an "other" option backed by a
+// free-text companion field, such as a gender select with a "prefer to self-describe" text box.
+// The selection in field must be one of values or other, and when it is other, otherField must
+// hold non-blank text.
+//
+// A blank selection is ignored so that requiredness can be checked separately. A selection that
+// is not one of the options is reported on field; missing text is reported on otherField, where
+// the user has to fill it in.
+//
+// Example usage:
+//
+//	rules.Apply(v, rules.Values{"gender": gender, "gender_other": genderOther},
+//		rules.OneOfOrOther("gender", "gender_other", []string{"female", "male"}, "other"),
+//	)
+func OneOfOrOther(field, otherField string, values []string, other string) Rule {
+	return func(v *datacop.Validator, vals Values) {
+		choice, ok := vals.String(field)
+		if ok && choice == "" {
+			return
+		}
+		if !ok || (choice != other && !slices.Contains(values, choice)) {
+			v.AddCodedError(field, CodeUnknownChoice, "is not a valid choice")
+			return
+		}
+		if choice != other {
+			return
+		}
+
+		text, ok := vals.String(otherField)
+		if !ok || strings.TrimSpace(text) == "" {
+			v.AddCodedError(otherField, CodeOtherRequired, "is required when "+other+" is selected")
+		}
+	}
+}
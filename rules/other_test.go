@@ -0,0 +1,54 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/rules"
+)
+
+func TestOneOfOrOther(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     rules.Values
+		wantErrors map[string]string
+	}{
+		{"listed option", rules.Values{"gender": "female"}, map[string]string{}},
+		{"other with text", rules.Values{"gender": "other", "gender_other": "non-binary"}, map[string]string{}},
+		{"other without text", rules.Values{"gender": "other"}, map[string]string{
+			"gender_other": "is required when other is selected",
+		}},
+		{"other with blank text", rules.Values{"gender": "other", "gender_other": "  "}, map[string]string{
+			"gender_other": "is required when other is selected",
+		}},
+		{"unknown option", rules.Values{"gender": "unknown", "gender_other": "x"}, map[string]string{
+			"gender": "is not a valid choice",
+		}},
+		{"blank selection", rules.Values{"gender": ""}, map[string]string{}},
+		{"non-string selection", rules.Values{"gender": 1}, map[string]string{
+			"gender": "is not a valid choice",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			rules.Apply(v, tt.values, rules.OneOfOrOther("gender", "gender_other", []string{"female", "male"}, "other"))
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestOneOfOrOther_Codes(t *testing.T) {
+	v := datacop.New()
+	rules.Apply(v, rules.Values{"gender": "other", "gender_other": ""},
+		rules.OneOfOrOther("gender", "gender_other", []string{"female", "male"}, "other"),
+	)
+
+	errs := v.ValidationErrors()["gender_other"]
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, rules.CodeOtherRequired, errs[0].Code)
+	}
+}
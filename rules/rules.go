@@ -0,0 +1,37 @@
+// Package rules provides validation bundles that check relationships between several fields
+// of a submitted form, such as cascading selects, selects with an "other" option, and consent
+// checkboxes.
+//
+// Unlike the single-value functions in the is package, a Rule sees every submitted value and
+// records its own coded errors.
+package rules
+
+import "github.com/patrickward/datacop"
+
+// Values holds submitted values keyed by field name
+type Values map[string]any
+
+// Rule validates one or more fields in values, recording failures on v
+type Rule func(v *datacop.Validator, values Values)
+
+// Apply runs each rule against values
+//
+// Example usage:
+//
+//	rules.Apply(v, rules.Values{"country": country, "state": state},
+//		rules.DependentChoice("country", "state", statesByCountry),
+//	)
+func Apply(v *datacop.Validator, values Values, rs ...Rule) {
+	for _, rule := range rs {
+		rule(v, values)
+	}
+}
+
+// String returns the value of field as a string. ok is false if the value is present but is not a string.
+func (vals Values) String(field string) (s string, ok bool) {
+	if vals[field] == nil {
+		return "", true
+	}
+	s, ok = vals[field].(string)
+	return s, ok
+}
@@ -0,0 +1,66 @@
+//go:build !tinygo
+
+package datacop
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+	"weak"
+)
+
+// ruleTable maps the functions created by Named, Annotated, Weighted, and Memoized to their
+// metadata. Entries are weak, so the table does not keep rules alive, and are removed once the
+// rule is collected. Functions registered with RegisterRule are never collected.
+var ruleTable = struct {
+	sync.RWMutex
+	dynamic map[uintptr]weak.Pointer[ruleMeta]
+	static  map[uintptr]*ruleMeta
+}{
+	dynamic: make(map[uintptr]weak.Pointer[ruleMeta]),
+	static:  make(map[uintptr]*ruleMeta),
+}
+
+// funcKey identifies fn by the address of its closure, which is shared by copies of fn and
+// distinct for each live closure
+func funcKey(fn ValidationFunc) uintptr {
+	return *(*uintptr)(unsafe.Pointer(&fn))
+}
+
+// register attaches m to fn, a closure created by ruleMeta.attach that refers to m
+func register(fn ValidationFunc, m *ruleMeta) {
+	key := funcKey(fn)
+	wp := weak.Make(m)
+
+	ruleTable.Lock()
+	ruleTable.dynamic[key] = wp
+	ruleTable.Unlock()
+
+	runtime.AddCleanup(m, func(key uintptr) {
+		ruleTable.Lock()
+		defer ruleTable.Unlock()
+		// A closure allocated at the same address may have replaced the entry
+		if ruleTable.dynamic[key] == wp {
+			delete(ruleTable.dynamic, key)
+		}
+	}, key)
+}
+
+// registerStatic attaches m to fn, a function declared at package level
+func registerStatic(fn ValidationFunc, m ruleMeta) {
+	m.rule = fn
+	ruleTable.Lock()
+	ruleTable.static[funcKey(fn)] = &m
+	ruleTable.Unlock()
+}
+
+// lookup returns the metadata attached to fn, or nil if there is none
+func lookup(fn ValidationFunc) *ruleMeta {
+	key := funcKey(fn)
+	ruleTable.RLock()
+	defer ruleTable.RUnlock()
+	if m, ok := ruleTable.static[key]; ok {
+		return m
+	}
+	return ruleTable.dynamic[key].Value()
+}
@@ -0,0 +1,43 @@
+//go:build tinygo
+
+package datacop
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// ruleTable maps rules to their metadata. TinyGo has no weak pointers, so entries are kept for
+// the life of the program, along with the rules they describe.
+var ruleTable = struct {
+	sync.RWMutex
+	rules map[[2]uintptr]*ruleMeta
+}{
+	rules: make(map[[2]uintptr]*ruleMeta),
+}
+
+// funcKey identifies fn by its context and function pointers, which TinyGo stores in place of
+// a closure pointer
+func funcKey(fn ValidationFunc) [2]uintptr {
+	return *(*[2]uintptr)(unsafe.Pointer(&fn))
+}
+
+// register attaches m to fn, a closure created by ruleMeta.attach that refers to m
+func register(fn ValidationFunc, m *ruleMeta) {
+	ruleTable.Lock()
+	ruleTable.rules[funcKey(fn)] = m
+	ruleTable.Unlock()
+}
+
+// registerStatic attaches m to fn, a function declared at package level
+func registerStatic(fn ValidationFunc, m ruleMeta) {
+	m.rule = fn
+	register(fn, &m)
+}
+
+// lookup returns the metadata attached to fn, or nil if there is none
+func lookup(fn ValidationFunc) *ruleMeta {
+	ruleTable.RLock()
+	defer ruleTable.RUnlock()
+	return ruleTable.rules[funcKey(fn)]
+}
@@ -0,0 +1,197 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ClientRule describes a field's constraints for client-side validation libraries.
+// Property names follow HTML constraint validation and JSON Schema conventions.
+type ClientRule struct {
+	Field     string            `json:"field"`
+	Type      Type              `json:"type,omitempty"`
+	Required  bool              `json:"required,omitempty"`
+	MinLength *int              `json:"minLength,omitempty"`
+	MaxLength *int              `json:"maxLength,omitempty"`
+	Min       *float64          `json:"min,omitempty"`
+	Max       *float64          `json:"max,omitempty"`
+	Pattern   string            `json:"pattern,omitempty"`
+	Enum      []string          `json:"enum,omitempty"`
+	Format    string            `json:"format,omitempty"`
+	Messages  map[string]string `json:"messages,omitempty"`
+
+	// FormatPattern is the regular expression the server checks Format with, if it has one
+	FormatPattern string `json:"formatPattern,omitempty"`
+}
+
+// ClientRules returns the schema's constraints for client-side validation, in declaration order.
+// Each rule includes the message for every constraint it declares, so the client reports the same
+// messages as the server. A {value} placeholder in a custom message is left for the client to
+// fill, as the script from ClientScript does.
+func (s *Schema) ClientRules() []ClientRule {
+	rules := make([]ClientRule, 0, len(s.fields))
+	for _, f := range s.fields {
+		rule := ClientRule{
+			Field:     f.Name,
+			Type:      f.Type,
+			Required:  f.Required,
+			MinLength: f.MinLength,
+			MaxLength: f.MaxLength,
+			Min:       f.Min,
+			Max:       f.Max,
+			Pattern:   f.Pattern,
+			Enum:      f.Enum,
+			Format:    f.Format,
+			Messages:  make(map[string]string),
+
+			FormatPattern: formatPatterns[f.Format],
+		}
+		for _, code := range f.codes() {
+			rule.Messages[code] = f.message(code, f.params(code))
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ToClientRules encodes the schema's client rules as JSON, for front-end form libraries to consume
+//
+// Example output:
+//
+//	[{"field":"name","type":"string","required":true,"minLength":2,
+//	  "messages":{"required":"is required","min_length":"must be at least 2 characters","type":"must be a string"}}]
+func (s *Schema) ToClientRules() ([]byte, error) {
+	return json.Marshal(s.ClientRules())
+}
+
+// FromClientRules builds a schema from JSON in the format produced by ToClientRules, so a schema
+// exported by an application can be loaded by tools such as the datacop command. Each rule's
+// messages become the field's custom messages.
+//
+// Example usage:
+//
+//	data, _ := os.ReadFile("signup.rules.json")
+//	s, err := schema.FromClientRules(data)
+func FromClientRules(data []byte) (*Schema, error) {
+	var rules []ClientRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("schema: decoding client rules: %w", err)
+	}
+
+	s := New()
+	for _, rule := range rules {
+		if rule.Field == "" {
+			return nil, fmt.Errorf("schema: client rule without a field name")
+		}
+		if _, exists := s.Lookup(rule.Field); exists {
+			return nil, fmt.Errorf("schema: duplicate field %q", rule.Field)
+		}
+		f := &Field{
+			Name:      rule.Field,
+			Type:      rule.Type,
+			Required:  rule.Required,
+			MinLength: rule.MinLength,
+			MaxLength: rule.MaxLength,
+			Min:       rule.Min,
+			Max:       rule.Max,
+			Pattern:   rule.Pattern,
+			Enum:      rule.Enum,
+			Format:    rule.Format,
+			Messages:  rule.Messages,
+		}
+		if err := f.Compile(); err != nil {
+			return nil, fmt.Errorf("schema: field %q: %w", rule.Field, err)
+		}
+		s.fields = append(s.fields, f)
+	}
+	return s, nil
+}
+
+// ClientScript returns a small, dependency-free JavaScript snippet that defines a function named
+// name. The function takes an object of form values and returns an object mapping each invalid
+// field to its error message, mirroring Validate for the schema's rules: types are checked as the
+// server checks them, except that numbers may arrive as strings, and formats with the server's
+// regular expressions. Patterns are evaluated with JavaScript's RegExp, so they should use syntax
+// shared by Go and JavaScript.
+//
+// Example usage:
+//
+//	script, err := s.ClientScript("validateSignup")
+//	// <script>{{ script }}</script>
+//	// const errors = validateSignup(Object.fromEntries(new FormData(form)))
+func (s *Schema) ClientScript(name string) (string, error) {
+	rules, err := s.ToClientRules()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(clientScript, name, rules), nil
+}
+
+// codes returns the codes of the rules declared on the field
+func (f *Field) codes() []string {
+	var codes []string
+	if f.Required {
+		codes = append(codes, CodeRequired)
+	}
+	if f.Type != TypeAny {
+		codes = append(codes, CodeType)
+	}
+	if f.MinLength != nil {
+		codes = append(codes, CodeMinLength)
+	}
+	if f.MaxLength != nil {
+		codes = append(codes, CodeMaxLength)
+	}
+	if f.Min != nil {
+		codes = append(codes, CodeMin)
+	}
+	if f.Max != nil {
+		codes = append(codes, CodeMax)
+	}
+	if f.Pattern != "" {
+		codes = append(codes, CodePattern)
+	}
+	if len(f.Enum) > 0 {
+		codes = append(codes, CodeEnum)
+	}
+	if f.Format != "" {
+		codes = append(codes, CodeFormat)
+	}
+	return codes
+}
+
+// clientScript is the template for ClientScript. Form values arrive as strings, so numeric
+// types are converted before comparison.
+const clientScript = `function %s(values) {
+  const rules = %s;
+  const types = {
+    string: (x) => typeof x === "string",
+    boolean: (x) => typeof x === "boolean",
+    array: Array.isArray,
+    object: (x) => typeof x === "object" && !Array.isArray(x),
+  };
+  const get = (obj, path) => path.split(".").reduce((o, k) => (o == null ? undefined : o[k]), obj);
+  const errors = {};
+  for (const r of rules) {
+    let v = get(values, r.field);
+    const fail = (code) => { errors[r.field] = r.messages[code].split("{value}").join(String(v)); };
+    if (v == null || (typeof v === "string" && v.trim() === "") || (Array.isArray(v) && v.length === 0)) {
+      if (r.required) fail("required");
+      continue;
+    }
+    if (r.type === "integer" || r.type === "number") {
+      const n = Number(v);
+      if (Number.isNaN(n) || (r.type === "integer" && !Number.isInteger(n))) { fail("type"); continue; }
+      v = n;
+    } else if (types[r.type] && !types[r.type](v)) { fail("type"); continue; }
+    const len = typeof v === "string" ? [...v.trim()].length : Array.isArray(v) ? v.length : null;
+    if (len !== null && r.minLength != null && len < r.minLength) { fail("min_length"); continue; }
+    if (len !== null && r.maxLength != null && len > r.maxLength) { fail("max_length"); continue; }
+    if (typeof v === "number" && r.min != null && v < r.min) { fail("min"); continue; }
+    if (typeof v === "number" && r.max != null && v > r.max) { fail("max"); continue; }
+    if (typeof v === "string" && r.pattern && !new RegExp(r.pattern).test(v)) { fail("pattern"); continue; }
+    if (r.enum && !r.enum.includes(v)) { fail("enum"); continue; }
+    if (typeof v === "string" && r.formatPattern && !new RegExp(r.formatPattern).test(v)) { fail("format"); continue; }
+  }
+  return errors;
+}`
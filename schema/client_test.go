@@ -0,0 +1,93 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/is"
+	"github.com/patrickward/datacop/schema"
+)
+
+func TestSchema_ToClientRules(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required().MinLength(2)
+	s.Field("age").Integer().Min(18).Message(schema.CodeMin, "you must be an adult")
+	s.Field("plan").Enum("free", "pro")
+
+	data, err := s.ToClientRules()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[
+		{"field":"name","type":"string","required":true,"minLength":2,
+		 "messages":{"required":"is required","type":"must be a string","min_length":"must be at least 2 characters"}},
+		{"field":"age","type":"integer","min":18,
+		 "messages":{"type":"must be an integer","min":"you must be an adult"}},
+		{"field":"plan","enum":["free","pro"],
+		 "messages":{"enum":"is not an allowed value"}}
+	]`, string(data))
+}
+
+func TestSchema_ClientScript(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required()
+
+	script, err := s.ClientScript("validateSignup")
+	require.NoError(t, err)
+	assert.Contains(t, script, "function validateSignup(values) {")
+	assert.Contains(t, script, `const rules = [{"field":"name","type":"string","required":true`)
+}
+
+func TestSchema_ClientRules_FormatPattern(t *testing.T) {
+	s := schema.New()
+	s.Field("email").String().Format(schema.FormatEmail)
+	s.Field("id").Format(schema.FormatUUID)
+	s.Field("tag").Format("custom")
+
+	rules := s.ClientRules()
+	assert.Equal(t, is.EmailPattern, rules[0].FormatPattern, "the client uses the server's email pattern")
+	assert.Regexp(t, rules[1].FormatPattern, "F47AC10B-58CC-4372-A567-0E02B2C3D479")
+	assert.Empty(t, rules[2].FormatPattern, "unknown formats have no pattern")
+}
+
+func TestFromClientRules(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required().MinLength(2)
+	s.Field("age").Integer().Min(18).Message(schema.CodeMin, "you must be an adult")
+	s.Field("code").Pattern(`^[A-Z]+$`)
+	data, err := s.ToClientRules()
+	require.NoError(t, err)
+
+	loaded, err := schema.FromClientRules(data)
+	require.NoError(t, err)
+	assert.Equal(t, s.ClientRules(), loaded.ClientRules(), "loading round-trips the client rules")
+
+	age, ok := loaded.Lookup("age")
+	require.True(t, ok)
+	code, ok := age.Check(17)
+	assert.False(t, ok)
+	assert.Equal(t, schema.CodeMin, code)
+	assert.Equal(t, "you must be an adult", age.Messages[schema.CodeMin])
+}
+
+func TestFromClientRules_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid json", `{`, "decoding client rules"},
+		{"missing field name", `[{"type":"string"}]`, "without a field name"},
+		{"duplicate field", `[{"field":"a"},{"field":"a"}]`, `duplicate field "a"`},
+		{"invalid pattern", `[{"field":"a","pattern":"["}]`, `field "a"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.FromClientRules([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Change is a difference between two versions of a field's rules. Code is the code of the changed
+// rule, or "" for a removed field or a new optional one. Old and New hold the rule's values, such
+// as a minimum length, and are nil when the rule is not declared in that version.
+type Change struct {
+	Field    string `json:"field"`
+	Code     string `json:"code,omitempty"`
+	Breaking bool   `json:"breaking"` // the new rule rejects values the old one accepted
+	Message  string `json:"message"`
+	Old      any    `json:"old,omitempty"`
+	New      any    `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	if c.Breaking {
+		return "breaking: " + c.Field + ": " + c.Message
+	}
+	return c.Field + ": " + c.Message
+}
+
+// Report lists the changes between two versions of a schema
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the changes that reject values the old schema accepted
+func (r Report) Breaking() []Change {
+	var breaking []Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+// Compatible reports whether every value the old schema accepted is accepted by the new one
+func (r Report) Compatible() bool {
+	return len(r.Breaking()) == 0
+}
+
+func (r Report) String() string {
+	lines := make([]string, len(r.Changes))
+	for i, c := range r.Changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compat compares two versions of a schema and classifies each rule change as compatible, when
+// the new rule loosens the old one, or breaking, when it tightens it: a new required field, a
+// narrower type, a raised minimum or lowered maximum, fewer allowed values, or a new or changed
+// pattern or format. Removed fields and new optional fields are compatible. Patterns and formats
+// are only compared for equality, but when the old field was restricted to allowed values, a new
+// length, pattern, or format rule those values all satisfy is compatible.
+//
+// Changes are listed in the order the new schema declares its fields, followed by removed fields.
+//
+// Example usage:
+//
+//	report := schema.Compat(previous, current)
+//	if !report.Compatible() {
+//		t.Fatalf("breaking rule changes:\n%s", report)
+//	}
+func Compat(old, new *Schema) Report {
+	var report Report
+	for _, nf := range new.fields {
+		of, ok := old.Lookup(nf.Name)
+		if !ok {
+			if nf.Required {
+				report.Changes = append(report.Changes, Change{Field: nf.Name, Code: CodeRequired, Breaking: true, Message: "new required field", New: true})
+			} else {
+				report.Changes = append(report.Changes, Change{Field: nf.Name, Message: "new optional field"})
+			}
+			continue
+		}
+		report.Changes = append(report.Changes, compareFields(of, nf)...)
+	}
+	for _, of := range old.fields {
+		if _, ok := new.Lookup(of.Name); !ok {
+			report.Changes = append(report.Changes, Change{Field: of.Name, Message: "field removed"})
+		}
+	}
+	return report
+}
+
+// compareFields returns the changes between the old and new versions of a field
+func compareFields(of, nf *Field) []Change {
+	var changes []Change
+	add := func(code string, breaking bool, oldValue, newValue any, format string, args ...any) {
+		changes = append(changes, Change{
+			Field:    nf.Name,
+			Code:     code,
+			Breaking: breaking,
+			Message:  fmt.Sprintf(format, args...),
+			Old:      oldValue,
+			New:      newValue,
+		})
+	}
+
+	if of.Required != nf.Required {
+		if nf.Required {
+			add(CodeRequired, true, false, true, "now required")
+		} else {
+			add(CodeRequired, false, true, false, "no longer required")
+		}
+	}
+	if of.Type != nf.Type {
+		loosened := nf.Type == TypeAny || (of.Type == TypeInteger && nf.Type == TypeNumber)
+		add(CodeType, !loosened, of.Type, nf.Type, "type changed from %s to %s", describeType(of.Type), describeType(nf.Type))
+	}
+	if c, ok := compareLength(of, nf, CodeMinLength, "minimum length", of.MinLength, nf.MinLength, func(o, n int) bool { return n > o }); ok {
+		changes = append(changes, c)
+	}
+	if c, ok := compareLength(of, nf, CodeMaxLength, "maximum length", of.MaxLength, nf.MaxLength, func(o, n int) bool { return n < o }); ok {
+		changes = append(changes, c)
+	}
+	if c, ok := compareBound(nf.Name, CodeMin, "minimum", of.Min, nf.Min, func(o, n float64) bool { return n > o }); ok {
+		changes = append(changes, c)
+	}
+	if c, ok := compareBound(nf.Name, CodeMax, "maximum", of.Max, nf.Max, func(o, n float64) bool { return n < o }); ok {
+		changes = append(changes, c)
+	}
+	if of.Pattern != nf.Pattern {
+		breaking := nf.Pattern != "" && !enumPasses(of, nf, CodePattern)
+		switch {
+		case of.Pattern == "":
+			add(CodePattern, breaking, nil, nf.Pattern, "pattern %q added", nf.Pattern)
+		case nf.Pattern == "":
+			add(CodePattern, false, of.Pattern, nil, "pattern %q removed", of.Pattern)
+		default:
+			add(CodePattern, breaking, of.Pattern, nf.Pattern, "pattern changed from %q to %q", of.Pattern, nf.Pattern)
+		}
+	}
+	if !slices.Equal(of.Enum, nf.Enum) {
+		removed, added := difference(of.Enum, nf.Enum), difference(nf.Enum, of.Enum)
+		switch {
+		case len(of.Enum) == 0:
+			add(CodeEnum, true, nil, nf.Enum, "restricted to %s", strings.Join(nf.Enum, ", "))
+		case len(nf.Enum) == 0:
+			add(CodeEnum, false, of.Enum, nil, "no longer restricted to allowed values")
+		case len(removed) > 0 && len(added) > 0:
+			add(CodeEnum, true, of.Enum, nf.Enum, "no longer allows %s; now also allows %s", strings.Join(removed, ", "), strings.Join(added, ", "))
+		case len(removed) > 0:
+			add(CodeEnum, true, of.Enum, nf.Enum, "no longer allows %s", strings.Join(removed, ", "))
+		case len(added) > 0:
+			add(CodeEnum, false, of.Enum, nf.Enum, "now also allows %s", strings.Join(added, ", "))
+		}
+	}
+	if of.Format != nf.Format {
+		breaking := nf.Format != "" && !enumPasses(of, nf, CodeFormat)
+		switch {
+		case of.Format == "":
+			add(CodeFormat, breaking, nil, nf.Format, "format %s added", nf.Format)
+		case nf.Format == "":
+			add(CodeFormat, false, of.Format, nil, "format %s removed", of.Format)
+		default:
+			add(CodeFormat, breaking, of.Format, nf.Format, "format changed from %s to %s", of.Format, nf.Format)
+		}
+	}
+	return changes
+}
+
+// compareLength compares the old and new values of a length limit. tighter reports whether a new
+// limit rejects lengths the old one accepted.
+func compareLength(of, nf *Field, code, name string, o, n *int, tighter func(o, n int) bool) (Change, bool) {
+	c := Change{Field: nf.Name, Code: code}
+	switch {
+	case o == nil && n == nil, o != nil && n != nil && *o == *n:
+		return c, false
+	case o == nil:
+		c.New, c.Breaking = *n, true
+		c.Message = fmt.Sprintf("%s %d added", name, *n)
+	case n == nil:
+		c.Old = *o
+		c.Message = fmt.Sprintf("%s %d removed", name, *o)
+	default:
+		c.Old, c.New, c.Breaking = *o, *n, tighter(*o, *n)
+		c.Message = fmt.Sprintf("%s changed from %d to %d", name, *o, *n)
+	}
+	c.Breaking = c.Breaking && !enumPasses(of, nf, code)
+	return c, true
+}
+
+// compareBound compares the old and new values of a numeric bound. tighter reports whether a
+// new bound rejects values the old one accepted.
+func compareBound(field, code, name string, o, n *float64, tighter func(o, n float64) bool) (Change, bool) {
+	c := Change{Field: field, Code: code}
+	switch {
+	case o == nil && n == nil, o != nil && n != nil && *o == *n:
+		return c, false
+	case o == nil:
+		c.New, c.Breaking = *n, true
+		c.Message = fmt.Sprintf("%s %g added", name, *n)
+	case n == nil:
+		c.Old = *o
+		c.Message = fmt.Sprintf("%s %g removed", name, *o)
+	default:
+		c.Old, c.New, c.Breaking = *o, *n, tighter(*o, *n)
+		c.Message = fmt.Sprintf("%s changed from %g to %g", name, *o, *n)
+	}
+	return c, true
+}
+
+// enumPasses reports whether the old field was restricted to allowed values that all pass the new
+// field's rule with the given code
+func enumPasses(of, nf *Field, code string) bool {
+	if len(of.Enum) == 0 {
+		return false
+	}
+	for _, value := range of.Enum {
+		for _, r := range nf.CheckEach(value) {
+			if r.Code == code && !r.Passed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// difference returns the values not in other
+func difference(values, other []string) []string {
+	var diff []string
+	for _, v := range values {
+		if !slices.Contains(other, v) {
+			diff = append(diff, v)
+		}
+	}
+	return diff
+}
+
+func describeType(t Type) string {
+	if t == TypeAny {
+		return "any"
+	}
+	return string(t)
+}
@@ -0,0 +1,192 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+func TestCompat(t *testing.T) {
+	tests := []struct {
+		name string
+		old  func(s *schema.Schema)
+		new  func(s *schema.Schema)
+		want []schema.Change
+	}{
+		{
+			name: "unchanged",
+			old:  func(s *schema.Schema) { s.Field("name").String().Required().MinLength(2) },
+			new:  func(s *schema.Schema) { s.Field("name").String().Required().MinLength(2) },
+		},
+		{
+			name: "fields added and removed",
+			old: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("legacy_id").String().Required()
+			},
+			new: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("email").String().Required()
+				s.Field("nickname").String()
+			},
+			want: []schema.Change{
+				{Field: "email", Code: schema.CodeRequired, Breaking: true, Message: "new required field", New: true},
+				{Field: "nickname", Message: "new optional field"},
+				{Field: "legacy_id", Message: "field removed"},
+			},
+		},
+		{
+			name: "required",
+			old: func(s *schema.Schema) {
+				s.Field("a").String()
+				s.Field("b").String().Required()
+			},
+			new: func(s *schema.Schema) {
+				s.Field("a").String().Required()
+				s.Field("b").String()
+			},
+			want: []schema.Change{
+				{Field: "a", Code: schema.CodeRequired, Breaking: true, Message: "now required", Old: false, New: true},
+				{Field: "b", Code: schema.CodeRequired, Message: "no longer required", Old: true, New: false},
+			},
+		},
+		{
+			name: "types",
+			old: func(s *schema.Schema) {
+				s.Field("count").Integer()
+				s.Field("ratio").Number()
+				s.Field("id").String()
+				s.Field("data")
+			},
+			new: func(s *schema.Schema) {
+				s.Field("count").Number()
+				s.Field("ratio").Integer()
+				s.Field("id")
+				s.Field("data").Object()
+			},
+			want: []schema.Change{
+				{Field: "count", Code: schema.CodeType, Message: "type changed from integer to number", Old: schema.TypeInteger, New: schema.TypeNumber},
+				{Field: "ratio", Code: schema.CodeType, Breaking: true, Message: "type changed from number to integer", Old: schema.TypeNumber, New: schema.TypeInteger},
+				{Field: "id", Code: schema.CodeType, Message: "type changed from string to any", Old: schema.TypeString, New: schema.TypeAny},
+				{Field: "data", Code: schema.CodeType, Breaking: true, Message: "type changed from any to object", Old: schema.TypeAny, New: schema.TypeObject},
+			},
+		},
+		{
+			name: "bounds",
+			old: func(s *schema.Schema) {
+				s.Field("name").MinLength(2).MaxLength(100)
+				s.Field("bio").MaxLength(500)
+				s.Field("age").Min(18).Max(120)
+				s.Field("score").Min(0)
+			},
+			new: func(s *schema.Schema) {
+				s.Field("name").MinLength(3).MaxLength(200)
+				s.Field("bio").MinLength(10)
+				s.Field("age").Min(16).Max(100)
+				s.Field("score").Max(1)
+			},
+			want: []schema.Change{
+				{Field: "name", Code: schema.CodeMinLength, Breaking: true, Message: "minimum length changed from 2 to 3", Old: 2, New: 3},
+				{Field: "name", Code: schema.CodeMaxLength, Message: "maximum length changed from 100 to 200", Old: 100, New: 200},
+				{Field: "bio", Code: schema.CodeMinLength, Breaking: true, Message: "minimum length 10 added", New: 10},
+				{Field: "bio", Code: schema.CodeMaxLength, Message: "maximum length 500 removed", Old: 500},
+				{Field: "age", Code: schema.CodeMin, Message: "minimum changed from 18 to 16", Old: 18.0, New: 16.0},
+				{Field: "age", Code: schema.CodeMax, Breaking: true, Message: "maximum changed from 120 to 100", Old: 120.0, New: 100.0},
+				{Field: "score", Code: schema.CodeMin, Message: "minimum 0 removed", Old: 0.0},
+				{Field: "score", Code: schema.CodeMax, Breaking: true, Message: "maximum 1 added", New: 1.0},
+			},
+		},
+		{
+			name: "patterns and formats",
+			old: func(s *schema.Schema) {
+				s.Field("sku").Pattern(`^[A-Z]+$`)
+				s.Field("code").Pattern(`^\d+$`)
+				s.Field("email").Format(schema.FormatEmail)
+				s.Field("link")
+			},
+			new: func(s *schema.Schema) {
+				s.Field("sku").Pattern(`^[A-Z]{3}$`)
+				s.Field("code")
+				s.Field("email").Format(schema.FormatURI)
+				s.Field("link").Format(schema.FormatURI)
+			},
+			want: []schema.Change{
+				{Field: "sku", Code: schema.CodePattern, Breaking: true, Message: `pattern changed from "^[A-Z]+$" to "^[A-Z]{3}$"`, Old: `^[A-Z]+$`, New: `^[A-Z]{3}$`},
+				{Field: "code", Code: schema.CodePattern, Message: `pattern "^\\d+$" removed`, Old: `^\d+$`},
+				{Field: "email", Code: schema.CodeFormat, Breaking: true, Message: "format changed from email to uri", Old: schema.FormatEmail, New: schema.FormatURI},
+				{Field: "link", Code: schema.CodeFormat, Breaking: true, Message: "format uri added", New: schema.FormatURI},
+			},
+		},
+		{
+			name: "allowed values",
+			old: func(s *schema.Schema) {
+				s.Field("plan").Enum("free", "pro")
+				s.Field("tier").Enum("a", "b")
+				s.Field("region").Enum("us", "eu")
+				s.Field("color")
+				s.Field("size").Enum("s", "m")
+			},
+			new: func(s *schema.Schema) {
+				s.Field("plan").Enum("free", "pro", "team")
+				s.Field("tier").Enum("a")
+				s.Field("region").Enum("us", "apac")
+				s.Field("color").Enum("red")
+				s.Field("size")
+			},
+			want: []schema.Change{
+				{Field: "plan", Code: schema.CodeEnum, Message: "now also allows team", Old: []string{"free", "pro"}, New: []string{"free", "pro", "team"}},
+				{Field: "tier", Code: schema.CodeEnum, Breaking: true, Message: "no longer allows b", Old: []string{"a", "b"}, New: []string{"a"}},
+				{Field: "region", Code: schema.CodeEnum, Breaking: true, Message: "no longer allows eu; now also allows apac", Old: []string{"us", "eu"}, New: []string{"us", "apac"}},
+				{Field: "color", Code: schema.CodeEnum, Breaking: true, Message: "restricted to red", New: []string{"red"}},
+				{Field: "size", Code: schema.CodeEnum, Message: "no longer restricted to allowed values", Old: []string{"s", "m"}},
+			},
+		},
+		{
+			name: "rules satisfied by old allowed values",
+			old: func(s *schema.Schema) {
+				s.Field("currency").Enum("USD", "EUR")
+				s.Field("unit").Enum("kg", "pound")
+			},
+			new: func(s *schema.Schema) {
+				s.Field("currency").Enum("USD", "EUR").Pattern(`^[A-Z]{3}$`).MinLength(3).MaxLength(3)
+				s.Field("unit").Enum("kg", "pound").MaxLength(3)
+			},
+			want: []schema.Change{
+				{Field: "currency", Code: schema.CodeMinLength, Message: "minimum length 3 added", New: 3},
+				{Field: "currency", Code: schema.CodeMaxLength, Message: "maximum length 3 added", New: 3},
+				{Field: "currency", Code: schema.CodePattern, Message: `pattern "^[A-Z]{3}$" added`, New: `^[A-Z]{3}$`},
+				{Field: "unit", Code: schema.CodeMaxLength, Breaking: true, Message: "maximum length 3 added", New: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, current := schema.New(), schema.New()
+			tt.old(old)
+			tt.new(current)
+
+			report := schema.Compat(old, current)
+			assert.Equal(t, tt.want, report.Changes)
+
+			var breaking []schema.Change
+			for _, c := range tt.want {
+				if c.Breaking {
+					breaking = append(breaking, c)
+				}
+			}
+			assert.Equal(t, breaking, report.Breaking())
+			assert.Equal(t, breaking == nil, report.Compatible())
+		})
+	}
+}
+
+func TestReport_String(t *testing.T) {
+	report := schema.Report{Changes: []schema.Change{
+		{Field: "email", Code: schema.CodeRequired, Breaking: true, Message: "new required field"},
+		{Field: "bio", Code: schema.CodeMaxLength, Message: "maximum length 500 removed"},
+	}}
+	assert.Equal(t, "breaking: email: new required field\nbio: maximum length 500 removed", report.String())
+}
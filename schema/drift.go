@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DriftError is returned by CheckAgainstStruct when a schema and a struct no longer describe the
+// same document
+type DriftError struct {
+	Struct    string   // the struct's type name
+	Unknown   []string // schema fields with no matching struct field
+	Unchecked []string // struct fields the schema does not declare
+}
+
+func (e *DriftError) Error() string {
+	var parts []string
+	if len(e.Unknown) > 0 {
+		parts = append(parts, fmt.Sprintf("fields not in struct: %s", strings.Join(e.Unknown, ", ")))
+	}
+	if len(e.Unchecked) > 0 {
+		parts = append(parts, fmt.Sprintf("struct fields not in schema: %s", strings.Join(e.Unchecked, ", ")))
+	}
+	return fmt.Sprintf("schema: drift from %s: %s", e.Struct, strings.Join(parts, "; "))
+}
+
+// CheckAgainstStruct reports whether s and the struct T describe the same document, so tests catch
+// a DTO that changed without its rules, or rules left behind by a removed field. It returns a
+// *DriftError listing the schema fields with no struct field and the struct fields the schema does
+// not declare, other than those in ignore.
+//
+// Struct fields are named by their json tag, or by their Go name if they have none. Fields tagged
+// "-" and unexported fields are skipped, and embedded structs are flattened, as encoding/json does.
+// Nested structs are matched field by field when the schema declares dotted paths under them, such
+// as "spec.replicas"; otherwise they are matched as a whole.
+//
+// Example usage:
+//
+//	func TestSignupSchema(t *testing.T) {
+//		require.NoError(t, schema.CheckAgainstStruct[SignupRequest](signupSchema, "referrer"))
+//	}
+func CheckAgainstStruct[T any](s *Schema, ignore ...string) error {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("schema: CheckAgainstStruct requires a struct type, got %s", t)
+	}
+
+	declared := make(map[string]bool, len(s.fields))
+	for _, f := range s.fields {
+		declared[f.Name] = true
+	}
+
+	paths := make(map[string]bool)
+	drift := &DriftError{Struct: t.String()}
+	structPaths(t, "", declared, func(path string) {
+		paths[path] = true
+		if !declared[path] && !slices.Contains(ignore, path) {
+			drift.Unchecked = append(drift.Unchecked, path)
+		}
+	})
+	for _, f := range s.fields {
+		if !paths[f.Name] {
+			drift.Unknown = append(drift.Unknown, f.Name)
+		}
+	}
+
+	if len(drift.Unknown) == 0 && len(drift.Unchecked) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// structPaths calls visit with the path of each field of t, descending into nested structs whose
+// fields the schema declares. Such a struct is itself visited only if the schema declares it too,
+// as an object, so it is not reported as unchecked.
+func structPaths(t reflect.Type, prefix string, declared map[string]bool, visit func(path string)) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		name, skip := jsonName(sf)
+		if skip || (!sf.IsExported() && !(sf.Anonymous && ft.Kind() == reflect.Struct)) {
+			continue
+		}
+
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			structPaths(ft, prefix, declared, visit)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		path := prefix + name
+		if ft.Kind() == reflect.Struct && declaresUnder(declared, path) {
+			if declared[path] {
+				visit(path)
+			}
+			structPaths(ft, path+".", declared, visit)
+			continue
+		}
+		visit(path)
+	}
+}
+
+// jsonName returns the name in a field's json tag, and whether encoding/json skips the field
+func jsonName(sf reflect.StructField) (name string, skip bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	return name, false
+}
+
+// declaresUnder reports whether the schema declares any field nested under path
+func declaresUnder(declared map[string]bool, path string) bool {
+	for name := range declared {
+		if strings.HasPrefix(name, path+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Spec struct {
+	Replicas int    `json:"replicas"`
+	Image    string `json:"image"`
+}
+
+type Deployment struct {
+	Audit
+	Name     string            `json:"name"`
+	Spec     *Spec             `json:"spec,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Internal string            `json:"-"`
+	Owner    string
+	secret   string
+}
+
+func TestCheckAgainstStruct(t *testing.T) {
+	tests := []struct {
+		name      string
+		declare   func(s *schema.Schema)
+		ignore    []string
+		unknown   []string
+		unchecked []string
+	}{
+		{
+			name: "in sync",
+			declare: func(s *schema.Schema) {
+				s.Field("name").String().Required()
+				s.Field("created_at").String()
+				s.Field("spec.replicas").Integer().Min(1)
+				s.Field("spec.image").String().Required()
+				s.Field("labels").Object()
+				s.Field("Owner").String()
+			},
+		},
+		{
+			name: "nested struct declared as a whole",
+			declare: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("created_at").String()
+				s.Field("spec").Object().Required()
+				s.Field("labels").Object()
+				s.Field("Owner").String()
+			},
+		},
+		{
+			name: "nested struct declared alongside its fields",
+			declare: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("created_at").String()
+				s.Field("spec").Object().Required()
+				s.Field("spec.replicas").Integer()
+				s.Field("spec.image").String()
+				s.Field("labels").Object()
+				s.Field("Owner").String()
+			},
+		},
+		{
+			name: "drift",
+			declare: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("nickname").String()
+				s.Field("spec.replicas").Integer()
+				s.Field("spec.tag").String()
+				s.Field("secret").String()
+			},
+			unknown:   []string{"nickname", "spec.tag", "secret"},
+			unchecked: []string{"created_at", "spec.image", "labels", "Owner"},
+		},
+		{
+			name: "ignored fields",
+			declare: func(s *schema.Schema) {
+				s.Field("name").String()
+				s.Field("spec").Object()
+			},
+			ignore:    []string{"created_at", "Owner"},
+			unchecked: []string{"labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schema.New()
+			tt.declare(s)
+
+			err := schema.CheckAgainstStruct[Deployment](s, tt.ignore...)
+			if tt.unknown == nil && tt.unchecked == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var drift *schema.DriftError
+			require.True(t, errors.As(err, &drift))
+			assert.Equal(t, "schema_test.Deployment", drift.Struct)
+			assert.Equal(t, tt.unknown, drift.Unknown)
+			assert.Equal(t, tt.unchecked, drift.Unchecked)
+		})
+	}
+}
+
+func TestCheckAgainstStruct_Pointer(t *testing.T) {
+	s := schema.New()
+	s.Field("replicas").Integer()
+	s.Field("image").String()
+
+	assert.NoError(t, schema.CheckAgainstStruct[*Spec](s))
+}
+
+func TestCheckAgainstStruct_NotAStruct(t *testing.T) {
+	err := schema.CheckAgainstStruct[map[string]any](schema.New())
+	assert.EqualError(t, err, "schema: CheckAgainstStruct requires a struct type, got map[string]interface {}")
+}
+
+func TestDriftError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *schema.DriftError
+		want string
+	}{
+		{
+			name: "unknown",
+			err:  &schema.DriftError{Struct: "api.User", Unknown: []string{"nickname"}},
+			want: "schema: drift from api.User: fields not in struct: nickname",
+		},
+		{
+			name: "unchecked",
+			err:  &schema.DriftError{Struct: "api.User", Unchecked: []string{"email", "age"}},
+			want: "schema: drift from api.User: struct fields not in schema: email, age",
+		},
+		{
+			name: "both",
+			err:  &schema.DriftError{Struct: "api.User", Unknown: []string{"nickname"}, Unchecked: []string{"email"}},
+			want: "schema: drift from api.User: fields not in struct: nickname; struct fields not in schema: email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
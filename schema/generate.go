@@ -0,0 +1,394 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// InvalidExample is a payload that violates a single rule of a field
+type InvalidExample struct {
+	Field   string         `json:"field"`
+	Code    string         `json:"code"` // the code of the violated rule, such as CodeMinLength
+	Payload map[string]any `json:"payload"`
+}
+
+// GenerateValid returns an example payload that satisfies every rule of the schema, with a value
+// for each declared field, optional ones included. Values are deterministic: the first allowed
+// enum value, a canonical value for formats, a string matching the pattern, and the number closest
+// to 1 that satisfies the bounds. An error is returned if no value could be found for a field,
+// such as when its pattern and length constraints contradict each other.
+//
+// Example usage:
+//
+//	payload, err := s.GenerateValid()
+//	data, _ := json.MarshalIndent(payload, "", "  ") // an example request for the API docs
+func (s *Schema) GenerateValid() (map[string]any, error) {
+	payload := make(map[string]any)
+	for _, f := range s.fields {
+		value, ok := f.validValue(s.declaresUnder(f.Name))
+		if !ok {
+			return nil, fmt.Errorf("schema: cannot generate a valid value for field %q", f.Name)
+		}
+		setPath(payload, f.Name, value)
+	}
+	return payload, nil
+}
+
+// GenerateInvalid returns example payloads that each violate one rule of one field, and satisfy
+// the rest of the schema, as far as the field's other rules allow. With perRule, it returns a
+// payload for every rule of every field; otherwise only one per field, for the field's first rule.
+// Rules no value can violate on their own, such as a minimum length of one, which an empty value
+// would report as required instead, are left out.
+//
+// Payloads are based on GenerateValid, whose error is returned if it fails.
+//
+// Example usage:
+//
+//	examples, err := s.GenerateInvalid(true)
+//	for _, ex := range examples {
+//		resp := post(t, "/signup", ex.Payload)
+//		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "%s %s", ex.Field, ex.Code)
+//	}
+func (s *Schema) GenerateInvalid(perRule bool) ([]InvalidExample, error) {
+	valid, err := s.GenerateValid()
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []InvalidExample
+	for _, f := range s.fields {
+		for _, code := range f.codes() {
+			value, ok := f.invalidValue(code, valid)
+			if !ok {
+				continue
+			}
+			payload := cloneMap(valid)
+			if value == nil {
+				deletePath(payload, f.Name)
+			} else {
+				setPath(payload, f.Name, value)
+			}
+			examples = append(examples, InvalidExample{Field: f.Name, Code: code, Payload: payload})
+			if !perRule {
+				break
+			}
+		}
+	}
+	return examples, nil
+}
+
+// validValue returns the first candidate value that satisfies the field's rules. Objects whose
+// fields are declared separately start out empty, for those fields to fill in.
+func (f *Field) validValue(hasChildren bool) (any, bool) {
+	var candidates []any
+	switch f.Type {
+	case TypeBoolean:
+		candidates = []any{true}
+	case TypeInteger:
+		candidates = []any{int(f.closestToOne(true))}
+	case TypeNumber:
+		candidates = []any{f.closestToOne(false)}
+	case TypeArray:
+		n := 1
+		if f.MinLength != nil && *f.MinLength > n {
+			n = *f.MinLength
+		}
+		if f.MaxLength != nil && *f.MaxLength < n {
+			n = *f.MaxLength
+		}
+		candidates = []any{items(n)}
+	case TypeObject:
+		if hasChildren {
+			return map[string]any{}, true
+		}
+		candidates = []any{map[string]any{"key": "value"}}
+	default:
+		candidates = f.validStrings()
+	}
+
+	for _, c := range candidates {
+		if _, ok := f.Check(c); ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// validStrings returns candidate strings for the field, most specific first
+func (f *Field) validStrings() []any {
+	var candidates []any
+	for _, value := range f.Enum {
+		candidates = append(candidates, value)
+	}
+	switch f.Format {
+	case FormatEmail:
+		candidates = append(candidates, "user@example.com")
+	case FormatUUID:
+		candidates = append(candidates, "123e4567-e89b-12d3-a456-426614174000")
+	case FormatURI:
+		candidates = append(candidates, "https://example.com")
+	}
+	if f.Pattern != "" {
+		if re, err := syntax.Parse(f.Pattern, syntax.Perl); err == nil {
+			re = re.Simplify()
+			for repeat := range 64 {
+				if str, ok := matching(re, repeat); ok {
+					candidates = append(candidates, str)
+				}
+			}
+		}
+	}
+	return append(candidates, f.fit("example"))
+}
+
+// invalidValue returns a value that fails the rule with the given code before any other rule of
+// the field. A nil value means the field is left out of the payload.
+func (f *Field) invalidValue(code string, valid map[string]any) (any, bool) {
+	var candidates []any
+	switch code {
+	case CodeRequired:
+		return nil, true
+	case CodeType:
+		switch f.Type {
+		case TypeString:
+			candidates = []any{42}
+		case TypeInteger:
+			candidates = []any{1.5, "invalid"}
+		default:
+			candidates = []any{"invalid"}
+		}
+	case CodeMinLength:
+		// Nothing is shorter than a minimum length of zero
+		if *f.MinLength <= 0 {
+			return nil, false
+		}
+		if f.Type == TypeArray {
+			candidates = []any{items(*f.MinLength - 1)}
+		} else {
+			candidates = []any{strings.Repeat("a", *f.MinLength-1)}
+		}
+	case CodeMaxLength:
+		if f.Type == TypeArray {
+			candidates = []any{items(*f.MaxLength + 1)}
+		} else {
+			candidates = []any{strings.Repeat("a", *f.MaxLength+1)}
+		}
+	case CodeMin:
+		candidates = []any{f.number(*f.Min - 1)}
+	case CodeMax:
+		candidates = []any{f.number(*f.Max + 1)}
+	case CodePattern, CodeEnum, CodeFormat:
+		n := 7
+		if str, ok := lookupString(valid, f.Name); ok {
+			n = len([]rune(str))
+		}
+		for _, r := range "!a0A-_" {
+			candidates = append(candidates, strings.Repeat(string(r), n))
+		}
+		candidates = append(candidates, "invalid", "not-an-email", "not a uri")
+	}
+
+	for _, c := range candidates {
+		if failed, ok := f.Check(c); !ok && failed == code {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// closestToOne returns the number closest to 1 within the field's bounds
+func (f *Field) closestToOne(integer bool) float64 {
+	n := 1.0
+	if f.Min != nil && n < *f.Min {
+		n = *f.Min
+		if integer {
+			n = math.Ceil(n)
+		}
+	}
+	if f.Max != nil && n > *f.Max {
+		n = *f.Max
+		if integer {
+			n = math.Floor(n)
+		}
+	}
+	return n
+}
+
+// number returns n as an int for integer fields, rounded away from the field's bounds
+func (f *Field) number(n float64) any {
+	if f.Type != TypeInteger {
+		return n
+	}
+	if f.Min != nil && n < *f.Min {
+		return int(math.Floor(n))
+	}
+	return int(math.Ceil(n))
+}
+
+// fit pads or truncates str to the field's length limits
+func (f *Field) fit(str string) string {
+	if f.MinLength != nil && len(str) < *f.MinLength {
+		str += strings.Repeat("x", *f.MinLength-len(str))
+	}
+	if f.MaxLength != nil && len(str) > *f.MaxLength {
+		str = str[:max(*f.MaxLength, 0)]
+	}
+	return str
+}
+
+// declaresUnder reports whether the schema declares any field nested under name
+func (s *Schema) declaresUnder(name string) bool {
+	for _, f := range s.fields {
+		if strings.HasPrefix(f.Name, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// matching returns a string matched by re, repeating starred expressions repeat times
+func matching(re *syntax.Regexp, repeat int) (string, bool) {
+	var sb strings.Builder
+	if !writeMatch(&sb, re, repeat) {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+func writeMatch(sb *strings.Builder, re *syntax.Regexp, repeat int) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		r, ok := classRune(re.Rune)
+		if !ok {
+			return false
+		}
+		sb.WriteRune(r)
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('a')
+	case syntax.OpCapture:
+		return writeMatch(sb, re.Sub[0], repeat)
+	case syntax.OpStar:
+		return writeRepeat(sb, re.Sub[0], repeat, repeat)
+	case syntax.OpPlus:
+		return writeRepeat(sb, re.Sub[0], max(repeat, 1), repeat)
+	case syntax.OpQuest:
+		return writeRepeat(sb, re.Sub[0], min(repeat, 1), repeat)
+	case syntax.OpRepeat:
+		n := max(repeat, re.Min)
+		if re.Max >= 0 {
+			n = min(n, re.Max)
+		}
+		return writeRepeat(sb, re.Sub[0], n, repeat)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeMatch(sb, sub, repeat) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			var alt strings.Builder
+			if writeMatch(&alt, sub, repeat) {
+				sb.WriteString(alt.String())
+				return true
+			}
+		}
+		return false
+	}
+	// Anchors, word boundaries, and empty matches match without consuming input
+	return true
+}
+
+func writeRepeat(sb *strings.Builder, re *syntax.Regexp, n, repeat int) bool {
+	for range n {
+		if !writeMatch(sb, re, repeat) {
+			return false
+		}
+	}
+	return true
+}
+
+// classRune returns the first printable, non-space rune in a character class, given as pairs of
+// inclusive ranges, falling back to the class's first rune
+func classRune(ranges []rune) (rune, bool) {
+	if len(ranges) == 0 {
+		return 0, false
+	}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r < ranges[i]+128; r++ {
+			if unicode.IsPrint(r) && !unicode.IsSpace(r) {
+				return r, true
+			}
+		}
+	}
+	return ranges[0], true
+}
+
+// items returns a list of n placeholder items
+func items(n int) []any {
+	list := make([]any, max(n, 0))
+	for i := range list {
+		list[i] = fmt.Sprintf("item%d", i+1)
+	}
+	return list
+}
+
+// setPath sets the value at a dotted path, creating nested maps as needed. An object already at
+// the path, filled in by nested fields, is kept.
+func setPath(data map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := data[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			data[key] = next
+		}
+		data = next
+	}
+	last := keys[len(keys)-1]
+	if _, isObject := data[last].(map[string]any); isObject {
+		if _, ok := value.(map[string]any); ok {
+			return
+		}
+	}
+	data[last] = value
+}
+
+// deletePath removes the value at a dotted path
+func deletePath(data map[string]any, path string) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := data[key].(map[string]any)
+		if !ok {
+			return
+		}
+		data = next
+	}
+	delete(data, keys[len(keys)-1])
+}
+
+// lookupString returns the string at a dotted path
+func lookupString(data map[string]any, path string) (string, bool) {
+	value, _ := Lookup(data, path)
+	str, ok := value.(string)
+	return str, ok
+}
+
+// cloneMap copies data and the maps nested in it
+func cloneMap(data map[string]any) map[string]any {
+	clone := maps.Clone(data)
+	for key, value := range clone {
+		if m, ok := value.(map[string]any); ok {
+			clone[key] = cloneMap(m)
+		}
+	}
+	return clone
+}
@@ -0,0 +1,157 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func generateSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("email").String().Required().Format(schema.FormatEmail)
+	s.Field("name").String().Required().MinLength(2).MaxLength(20)
+	s.Field("code").String().Pattern(`^[A-Z]{3}-\d{4}$`)
+	s.Field("age").Integer().Min(18).Max(120)
+	s.Field("score").Number().Max(0.5)
+	s.Field("plan").String().Enum("free", "pro")
+	s.Field("id").String().Format(schema.FormatUUID)
+	s.Field("active").Boolean()
+	s.Field("tags").Array().MinLength(2).MaxLength(3)
+	s.Field("spec").Object().Required()
+	s.Field("spec.replicas").Integer().Min(3)
+	s.Field("meta").Object()
+	return s
+}
+
+func TestSchema_GenerateValid(t *testing.T) {
+	s := generateSchema()
+
+	payload, err := s.GenerateValid()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"email":  "user@example.com",
+		"name":   "example",
+		"code":   "AAA-0000",
+		"age":    18,
+		"score":  0.5,
+		"plan":   "free",
+		"id":     "123e4567-e89b-12d3-a456-426614174000",
+		"active": true,
+		"tags":   []any{"item1", "item2"},
+		"spec":   map[string]any{"replicas": 3},
+		"meta":   map[string]any{"key": "value"},
+	}, payload)
+
+	v := datacop.New()
+	s.Validate(v, payload)
+	assert.False(t, v.HasErrors(), v.Errors())
+}
+
+func TestSchema_GenerateValid_Patterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		declare func(f *schema.FieldBuilder)
+	}{
+		{"alternation", func(f *schema.FieldBuilder) { f.String().Pattern(`^(?:red|green)+$`) }},
+		{"repeat and length", func(f *schema.FieldBuilder) { f.String().Pattern(`^[a-z]+\d*$`).MinLength(5).MaxLength(6) }},
+		{"unanchored", func(f *schema.FieldBuilder) { f.String().Pattern(`\s\w@`) }},
+		{"case insensitive", func(f *schema.FieldBuilder) { f.String().Pattern(`(?i)^sku_[0-9a-f]{6}$`) }},
+		{"short length", func(f *schema.FieldBuilder) { f.String().Required().MaxLength(3) }},
+		{"long length", func(f *schema.FieldBuilder) { f.String().Required().MinLength(12) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schema.New()
+			tt.declare(s.Field("value"))
+
+			payload, err := s.GenerateValid()
+			require.NoError(t, err)
+
+			v := datacop.New()
+			s.Validate(v, payload)
+			assert.False(t, v.HasErrors(), "%v: %v", payload, v.Errors())
+		})
+	}
+}
+
+func TestSchema_GenerateValid_Unsatisfiable(t *testing.T) {
+	s := schema.New()
+	s.Field("code").String().Pattern(`^\d{3}$`).MinLength(5)
+
+	_, err := s.GenerateValid()
+	assert.EqualError(t, err, `schema: cannot generate a valid value for field "code"`)
+}
+
+func TestSchema_GenerateInvalid(t *testing.T) {
+	s := generateSchema()
+
+	examples, err := s.GenerateInvalid(true)
+	require.NoError(t, err)
+
+	var got []string
+	for _, ex := range examples {
+		got = append(got, ex.Field+" "+ex.Code)
+
+		v := datacop.New()
+		s.Validate(v, ex.Payload)
+		failed := v.FailedRules()
+		assert.Equal(t, []string{ex.Code}, failed[ex.Field], "%s %s: %v", ex.Field, ex.Code, ex.Payload)
+	}
+	assert.Equal(t, []string{
+		"email required", "email type", "email format",
+		"name required", "name type", "name min_length", "name max_length",
+		"code type", "code pattern",
+		"age type", "age min", "age max",
+		"score type", "score max",
+		"plan type", "plan enum",
+		"id type", "id format",
+		"active type",
+		"tags type", "tags min_length", "tags max_length",
+		"spec required", "spec type",
+		"spec.replicas type", "spec.replicas min",
+		"meta type",
+	}, got)
+
+	// Payloads are independent copies
+	assert.Equal(t, 3, examples[0].Payload["spec"].(map[string]any)["replicas"])
+}
+
+func TestSchema_GenerateInvalid_ZeroMinLength(t *testing.T) {
+	s := schema.New()
+	s.Field("note").String().MinLength(0)
+	s.Field("tags").Array().MinLength(0)
+
+	examples, err := s.GenerateInvalid(true)
+	require.NoError(t, err)
+
+	var got []string
+	for _, ex := range examples {
+		got = append(got, ex.Field+" "+ex.Code)
+	}
+	assert.Equal(t, []string{"note type", "tags type"}, got)
+}
+
+func TestSchema_GenerateInvalid_FirstRulePerField(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required().MinLength(2)
+	s.Field("age").Integer().Min(18)
+	s.Field("nickname").MinLength(1)
+
+	examples, err := s.GenerateInvalid(false)
+	require.NoError(t, err)
+	require.Len(t, examples, 2)
+
+	assert.Equal(t, "name", examples[0].Field)
+	assert.Equal(t, schema.CodeRequired, examples[0].Code)
+	assert.NotContains(t, examples[0].Payload, "name")
+
+	assert.Equal(t, "age", examples[1].Field)
+	assert.Equal(t, schema.CodeType, examples[1].Code)
+	assert.Equal(t, 1.5, examples[1].Payload["age"])
+}
@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/patrickward/datacop"
+)
+
+// ValidateJSON decodes a JSON document and validates it against the schema, recording failures
+// on v. Numbers decode to json.Number, so large integers keep their precision. The byte offset,
+// line, and column of every object key are set on v with SetPosition, so errors carry them in
+// their Position, and returned for use with Positions.Report. Keys of objects inside arrays are
+// recorded under indexed paths such as "items[0].sku". An error is returned if the document is
+// not valid JSON or its top level is not an object.
+//
+// Example usage:
+//
+//	body, _ := io.ReadAll(r.Body)
+//	v := datacop.New()
+//	if _, err := s.ValidateJSON(v, body); err != nil {
+//		return err
+//	}
+//	web.WriteError(w, r, v.ErrOrNil()) // each error has "position":{"offset":42,"line":3,"column":5}
+func (s *Schema) ValidateJSON(v *datacop.Validator, doc []byte) (Positions, error) {
+	var data map[string]any
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("schema: JSON document must be an object")
+	}
+
+	positions := make(Positions)
+	dec = json.NewDecoder(bytes.NewReader(doc))
+	if err := collectJSONPositions(dec, doc, positions, ""); err != nil {
+		return nil, err
+	}
+
+	positions.set(v)
+	s.Validate(v, data)
+	return positions, nil
+}
+
+// collectJSONPositions reads the next value from dec, recording the position of the keys of
+// objects in it and its nested values
+func collectJSONPositions(dec *json.Decoder, doc []byte, positions Positions, prefix string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			offset := skipJSONSeparators(doc, int(dec.InputOffset()))
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := tok.(string)
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			positions[path] = jsonPosition(doc, offset)
+			if err := collectJSONPositions(dec, doc, positions, path); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := collectJSONPositions(dec, doc, positions, fmt.Sprintf("%s[%d]", prefix, i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+
+	// The closing delimiter
+	if _, err := dec.Token(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// skipJSONSeparators returns the offset of the first byte at or after offset that is not
+// whitespace or a comma, which is where the next key starts
+func skipJSONSeparators(doc []byte, offset int) int {
+	for offset < len(doc) {
+		switch doc[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// jsonPosition returns the position of the byte at offset in doc
+func jsonPosition(doc []byte, offset int) Position {
+	line := 1 + bytes.Count(doc[:offset], []byte("\n"))
+	start := bytes.LastIndexByte(doc[:offset], '\n') + 1
+	return Position{Offset: offset, Line: line, Column: offset - start + 1}
+}
@@ -0,0 +1,108 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func TestSchema_ValidateJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		doc           string
+		wantErrors    map[string]string
+		wantPositions map[string]*datacop.Position
+	}{
+		{
+			name:       "valid document",
+			doc:        `{"name": "web", "spec": {"replicas": 3, "image": "nginx:1.27"}}`,
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "invalid values",
+			doc: `{
+  "name": "web",
+  "spec": {
+    "image": "nginx:1.27",
+    "replicas": 0,
+    "strategy": "blue-green"
+  }
+}`,
+			wantErrors: map[string]string{
+				"spec.replicas": "must be at least 1",
+				"spec.strategy": "is not an allowed value",
+			},
+			wantPositions: map[string]*datacop.Position{
+				"spec.replicas": {Offset: 62, Line: 5, Column: 5},
+				"spec.strategy": {Offset: 81, Line: 6, Column: 5},
+			},
+		},
+		{
+			name:       "missing field is reported at its parent",
+			doc:        `{"name":"web","spec":{"replicas":2}}`,
+			wantErrors: map[string]string{"spec.image": "is required"},
+			wantPositions: map[string]*datacop.Position{
+				"spec.image": {Offset: 14, Line: 1, Column: 15},
+			},
+		},
+		{
+			name:       "missing top-level field has no position",
+			doc:        `{"spec":{"replicas":2,"image":"nginx"}}`,
+			wantErrors: map[string]string{"name": "is required"},
+			wantPositions: map[string]*datacop.Position{
+				"name": nil,
+			},
+		},
+		{
+			name:       "large integers keep their precision",
+			doc:        `{"name":"web","spec":{"replicas":9007199254740993,"image":"nginx"}}`,
+			wantErrors: map[string]string{},
+		},
+	}
+
+	s := deploymentSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			_, err := s.ValidateJSON(v, []byte(tt.doc))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErrors, v.Errors())
+			for _, e := range v.OrderedErrors() {
+				if want, ok := tt.wantPositions[e.Field]; ok {
+					assert.Equal(t, want, e.Position, e.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateJSON_Positions(t *testing.T) {
+	doc := `{"items": [{"sku": "a"}, {"sku": "b", "tags": ["x"]}], "note": null}`
+	positions, err := schema.New().ValidateJSON(datacop.New(), []byte(doc))
+	require.NoError(t, err)
+
+	assert.Equal(t, schema.Positions{
+		"items":         {Offset: 1, Line: 1, Column: 2},
+		"items[0].sku":  {Offset: 12, Line: 1, Column: 13},
+		"items[1].sku":  {Offset: 26, Line: 1, Column: 27},
+		"items[1].tags": {Offset: 38, Line: 1, Column: 39},
+		"note":          {Offset: 55, Line: 1, Column: 56},
+	}, positions)
+}
+
+func TestSchema_ValidateJSON_InvalidDocument(t *testing.T) {
+	s := deploymentSchema()
+
+	_, err := s.ValidateJSON(datacop.New(), []byte(`{"name": `))
+	assert.Error(t, err)
+
+	_, err = s.ValidateJSON(datacop.New(), []byte(`null`))
+	assert.ErrorContains(t, err, "must be an object")
+
+	_, err = s.ValidateJSON(datacop.New(), []byte(`["a list"]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,356 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema draft written by ToJSONSchema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of a JSON Schema object written by ToJSONSchema
+type jsonSchema struct {
+	Schema     string                 `json:"$schema,omitempty"`
+	Type       Type                   `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	MinLength  *int                   `json:"minLength,omitempty"`
+	MaxLength  *int                   `json:"maxLength,omitempty"`
+	MinItems   *int                   `json:"minItems,omitempty"`
+	MaxItems   *int                   `json:"maxItems,omitempty"`
+	Minimum    *float64               `json:"minimum,omitempty"`
+	Maximum    *float64               `json:"maximum,omitempty"`
+	Pattern    string                 `json:"pattern,omitempty"`
+	Enum       []string               `json:"enum,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+}
+
+// ToJSONSchema encodes the schema as a JSON Schema (draft 2020-12) document, so the constraints
+// declared once in Go can be published in an OpenAPI specification or used by other tools
+// instead of being maintained by hand. Dotted field names become nested objects, length limits
+// become minItems and maxItems for arrays and minLength and maxLength otherwise, and required
+// fields are listed in their object's "required" keyword, as are the objects above them.
+//
+// JSON Schema has no notion of a blank value, so unlike Validate, a required string that is
+// present but blank satisfies the exported schema.
+//
+// Example usage:
+//
+//	data, err := s.ToJSONSchema()
+//	// {"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",
+//	//  "properties":{"name":{"type":"string","minLength":2}},"required":["name"]}
+func (s *Schema) ToJSONSchema() ([]byte, error) {
+	root := &jsonSchema{Schema: JSONSchemaDialect, Type: TypeObject}
+	for _, f := range s.fields {
+		parent, name := root, f.Name
+		for {
+			head, rest, nested := strings.Cut(name, ".")
+			if !nested {
+				break
+			}
+			// Validate requires a nested field even when its parent is missing, so its
+			// ancestors are required too
+			if f.Required {
+				parent.require(head)
+			}
+			parent, name = parent.property(head, true), rest
+		}
+
+		node := parent.property(name, false)
+		if f.Type != TypeAny {
+			node.Type = f.Type
+		}
+		node.Format = f.Format
+		node.Minimum, node.Maximum = f.Min, f.Max
+		node.Pattern = f.Pattern
+		node.Enum = f.Enum
+		if f.Type == TypeArray {
+			node.MinItems, node.MaxItems = f.MinLength, f.MaxLength
+		} else {
+			node.MinLength, node.MaxLength = f.MinLength, f.MaxLength
+		}
+		if f.Required {
+			parent.require(name)
+		}
+	}
+	return json.Marshal(root)
+}
+
+// require adds name to the required properties of s, unless it is listed already
+func (s *jsonSchema) require(name string) {
+	if !slices.Contains(s.Required, name) {
+		s.Required = append(s.Required, name)
+	}
+}
+
+// property returns the schema of the named property of s, adding it if needed. Properties added
+// as parents of nested fields are objects.
+func (s *jsonSchema) property(name string, object bool) *jsonSchema {
+	if s.Properties == nil {
+		s.Properties = make(map[string]*jsonSchema)
+	}
+	p, ok := s.Properties[name]
+	if !ok {
+		p = &jsonSchema{}
+		if object {
+			p.Type = TypeObject
+		}
+		s.Properties[name] = p
+	}
+	return p
+}
+
+// unsupportedKeywords are JSON Schema assertions FromJSONSchema cannot enforce. Schemas using them
+// are rejected rather than validated more loosely than their authors intended.
+var unsupportedKeywords = []string{
+	"$ref", "allOf", "anyOf", "oneOf", "not", "if", "const",
+	"exclusiveMinimum", "exclusiveMaximum", "multipleOf", "uniqueItems", "items", "prefixItems",
+}
+
+// FromJSONSchema builds a schema from a JSON Schema document, so forms whose fields are defined
+// at runtime, such as per tenant, can be validated without Go code for each of them. The top level
+// must be an object schema. Nested object properties become dotted fields such as
+// "address.zip"; since flattened fields are checked independently, a nested property can only be
+// required if every object above it is required too, and an error is returned for required
+// properties of optional objects.
+//
+// The supported keywords are type, properties, required, minLength, maxLength, minItems,
+// maxItems, minimum, maximum, pattern, enum (of strings), and format (email, uuid, and uri; other
+// formats are ignored, as they are annotations in JSON Schema). A type may list "null" alongside
+// one other type. Annotations such as title and description are ignored, and an error is
+// returned for assertions that cannot be enforced, such as $ref, oneOf, or exclusiveMinimum.
+//
+// Example usage:
+//
+//	s, err := schema.FromJSONSchema(tenant.FormSchema)
+//	if err != nil {
+//		return err
+//	}
+//	v := datacop.New()
+//	s.Validate(v, submission)
+func FromJSONSchema(data []byte) (*Schema, error) {
+	var root any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("schema: decoding JSON Schema: %w", err)
+	}
+
+	s := New()
+	if err := s.AddJSONSchema("", root, true, JSONSchemaOptions{}); err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	return s, nil
+}
+
+// JSONSchemaOptions configures how AddJSONSchema and Field.ApplyJSONSchema read JSON Schema
+// objects
+type JSONSchemaOptions struct {
+	// Resolve returns the schema object raw stands for, such as by following a local "$ref".
+	// Without it, subschemas must be objects and "$ref" is not supported.
+	Resolve func(raw any) (map[string]any, error)
+
+	// Lenient ignores the keywords and values that cannot be enforced, and the required
+	// properties of optional objects, instead of returning an error. OpenAPI documents use it,
+	// as they routinely contain both.
+	Lenient bool
+}
+
+// resolve returns raw as a schema object, following references with Resolve
+func (o JSONSchemaOptions) resolve(raw any) (map[string]any, error) {
+	if o.Resolve != nil {
+		return o.Resolve(raw)
+	}
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a schema object, got %T", raw)
+	}
+	return obj, nil
+}
+
+// AddJSONSchema declares fields for the JSON Schema object raw at the dotted path name, or for
+// the document itself if name is empty, as FromJSONSchema does. required tells whether the value
+// at name is required by the objects above it. Packages reading schemas from other documents,
+// such as openapi, use it to share FromJSONSchema's rules.
+//
+// Example usage:
+//
+//	body := schema.New()
+//	err := body.AddJSONSchema("", requestSchema, false, schema.JSONSchemaOptions{Lenient: true})
+func (s *Schema) AddJSONSchema(name string, raw any, required bool, opts JSONSchemaOptions) error {
+	where := name
+	if where == "" {
+		where = "top level"
+	}
+
+	obj, err := opts.resolve(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", where, err)
+	}
+	if !opts.Lenient {
+		for _, keyword := range unsupportedKeywords {
+			if _, ok := obj[keyword]; ok {
+				return fmt.Errorf("%s: unsupported keyword %q", where, keyword)
+			}
+		}
+	}
+	typ, err := opts.jsonSchemaType(obj["type"])
+	if err != nil {
+		return fmt.Errorf("%s: %w", where, err)
+	}
+
+	props, hasProps := obj["properties"].(map[string]any)
+	if typ == TypeObject || hasProps {
+		if name != "" {
+			f := s.Field(name).Object()
+			if required {
+				f.Required()
+			}
+		}
+
+		requiredProps := make(map[string]bool)
+		list, _ := obj["required"].([]any)
+		for _, r := range list {
+			if r, ok := r.(string); ok {
+				requiredProps[r] = true
+			}
+		}
+		optional := name != "" && !required
+		if optional && len(requiredProps) > 0 && !opts.Lenient {
+			return fmt.Errorf("%s: required properties of an optional object are not supported", where)
+		}
+
+		for _, prop := range slices.Sorted(maps.Keys(props)) {
+			path := prop
+			if name != "" {
+				path = name + "." + prop
+			}
+			if err := s.AddJSONSchema(path, props[prop], requiredProps[prop] && !optional, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if name == "" {
+		return fmt.Errorf("%s: expected an object schema", where)
+	}
+
+	f := s.Field(name)
+	if required {
+		f.Required()
+	}
+	return f.f.ApplyJSONSchema(obj, opts)
+}
+
+// ApplyJSONSchema copies the type and constraints of a JSON Schema object onto the field, and
+// compiles its pattern. It returns an error for values it cannot enforce, such as a fractional
+// minLength or an enum of numbers, unless opts is lenient.
+//
+// Example usage:
+//
+//	f := &schema.Field{Name: "limit"}
+//	err := f.ApplyJSONSchema(map[string]any{"type": "integer", "minimum": 1}, schema.JSONSchemaOptions{})
+func (f *Field) ApplyJSONSchema(obj map[string]any, opts JSONSchemaOptions) error {
+	typ, err := opts.jsonSchemaType(obj["type"])
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	f.Type = typ
+
+	for _, keyword := range []string{"minLength", "minItems"} {
+		if n, ok, err := opts.jsonSchemaInt(obj, keyword); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		} else if ok {
+			f.MinLength = &n
+		}
+	}
+	for _, keyword := range []string{"maxLength", "maxItems"} {
+		if n, ok, err := opts.jsonSchemaInt(obj, keyword); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		} else if ok {
+			f.MaxLength = &n
+		}
+	}
+	if n, ok := toFloat(obj["minimum"]); ok {
+		f.Min = &n
+	}
+	if n, ok := toFloat(obj["maximum"]); ok {
+		f.Max = &n
+	}
+	if p, ok := obj["pattern"].(string); ok {
+		f.Pattern = p
+	}
+	if err := f.Compile(); err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	if format, ok := obj["format"].(string); ok {
+		switch format {
+		case FormatEmail, FormatUUID, FormatURI:
+			f.Format = format
+		}
+	}
+	if values, ok := obj["enum"].([]any); ok {
+		for _, v := range values {
+			str, ok := v.(string)
+			if !ok {
+				if opts.Lenient {
+					continue
+				}
+				return fmt.Errorf("%s: enum values must be strings, got %v", f.Name, v)
+			}
+			f.Enum = append(f.Enum, str)
+		}
+	}
+	return nil
+}
+
+// jsonSchemaType returns the field type for the value of a "type" keyword. Lenient options treat
+// unsupported types as TypeAny.
+func (o JSONSchemaOptions) jsonSchemaType(raw any) (Type, error) {
+	switch t := raw.(type) {
+	case nil:
+		return TypeAny, nil
+	case string:
+		switch typ := Type(t); typ {
+		case TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeArray, TypeObject:
+			return typ, nil
+		}
+		if o.Lenient {
+			return TypeAny, nil
+		}
+		return "", fmt.Errorf("unsupported type %q", t)
+	case []any:
+		var types []any
+		for _, item := range t {
+			if item != "null" {
+				types = append(types, item)
+			}
+		}
+		if len(types) == 1 {
+			return o.jsonSchemaType(types[0])
+		}
+	}
+	if o.Lenient {
+		return TypeAny, nil
+	}
+	return "", fmt.Errorf("unsupported type %v", raw)
+}
+
+// jsonSchemaInt returns the non-negative integer value of a keyword of obj. Lenient options
+// ignore other values.
+func (o JSONSchemaOptions) jsonSchemaInt(obj map[string]any, keyword string) (int, bool, error) {
+	raw, ok := obj[keyword]
+	if !ok {
+		return 0, false, nil
+	}
+	n, ok := toFloat(raw)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		if o.Lenient {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("%s must be a non-negative integer, got %v", keyword, raw)
+	}
+	return int(n), true, nil
+}
@@ -0,0 +1,251 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func TestSchema_ToJSONSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema func() *schema.Schema
+		want   string
+	}{
+		{
+			name:   "empty schema",
+			schema: schema.New,
+			want:   `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object"}`,
+		},
+		{
+			name: "flat fields",
+			schema: func() *schema.Schema {
+				s := schema.New()
+				s.Field("email").String().Required().Format(schema.FormatEmail)
+				s.Field("name").String().Required().MinLength(2).MaxLength(100)
+				s.Field("age").Integer().Min(18).Max(130)
+				s.Field("plan").String().Enum("free", "pro")
+				s.Field("sku").String().Pattern(`^[A-Z]{3}-\d{4}$`)
+				s.Field("tags").Array().MaxLength(5)
+				s.Field("notes")
+				return s
+			},
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"email": {"type": "string", "format": "email"},
+					"name": {"type": "string", "minLength": 2, "maxLength": 100},
+					"age": {"type": "integer", "minimum": 18, "maximum": 130},
+					"plan": {"type": "string", "enum": ["free", "pro"]},
+					"sku": {"type": "string", "pattern": "^[A-Z]{3}-\\d{4}$"},
+					"tags": {"type": "array", "maxItems": 5},
+					"notes": {}
+				},
+				"required": ["email", "name"]
+			}`,
+		},
+		{
+			name: "nested fields",
+			schema: func() *schema.Schema {
+				s := deploymentSchema()
+				s.Field("metadata.labels.app").String().Required()
+				return s
+			},
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"spec": {
+						"type": "object",
+						"properties": {
+							"replicas": {"type": "integer", "minimum": 1},
+							"image": {"type": "string"},
+							"strategy": {"type": "string", "enum": ["rolling", "recreate"]}
+						},
+						"required": ["replicas", "image"]
+					},
+					"metadata": {
+						"type": "object",
+						"properties": {
+							"labels": {
+								"type": "object",
+								"properties": {"app": {"type": "string"}},
+								"required": ["app"]
+							}
+						},
+						"required": ["labels"]
+					}
+				},
+				"required": ["name", "spec", "metadata"]
+			}`,
+		},
+		{
+			name: "untyped parent declared after its fields stays an object",
+			schema: func() *schema.Schema {
+				s := schema.New()
+				s.Field("address.zip").String()
+				s.Field("address").Required()
+				return s
+			},
+			want: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {"address": {"type": "object", "properties": {"zip": {"type": "string"}}}},
+				"required": ["address"]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.schema().ToJSONSchema()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestFromJSONSchema(t *testing.T) {
+	doc := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Signup",
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"name": {"type": "string", "minLength": 2, "maxLength": 100, "description": "Full name"},
+			"age": {"type": ["integer", "null"], "minimum": 18, "maximum": 130},
+			"plan": {"type": "string", "enum": ["free", "pro"]},
+			"tags": {"type": "array", "minItems": 1, "maxItems": 5},
+			"website": {"type": "string", "format": "uri"},
+			"phone": {"type": "string", "format": "phone", "pattern": "^\\+[0-9]+$"},
+			"address": {
+				"type": "object",
+				"properties": {
+					"zip": {"type": "string"},
+					"city": {"type": "string"}
+				},
+				"required": ["zip"]
+			},
+			"billing": {
+				"properties": {"vat": {"type": "string"}}
+			}
+		},
+		"required": ["email", "name", "address"]
+	}`
+
+	s, err := schema.FromJSONSchema([]byte(doc))
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range s.Fields() {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{
+		"address", "address.city", "address.zip", "age", "billing", "billing.vat",
+		"email", "name", "phone", "plan", "tags", "website",
+	}, names)
+
+	field := func(name string) *schema.Field {
+		f, ok := s.Lookup(name)
+		require.True(t, ok, name)
+		return f
+	}
+	assert.True(t, field("address").Required)
+	assert.True(t, field("address.zip").Required)
+	assert.False(t, field("billing").Required)
+	assert.False(t, field("billing.vat").Required)
+	assert.Equal(t, schema.TypeInteger, field("age").Type)
+	assert.Equal(t, 18.0, *field("age").Min)
+	assert.Equal(t, 2, *field("name").MinLength)
+	assert.Equal(t, 5, *field("tags").MaxLength)
+	assert.Equal(t, []string{"free", "pro"}, field("plan").Enum)
+	assert.Equal(t, schema.FormatEmail, field("email").Format)
+	assert.Empty(t, field("phone").Format, "unknown formats are ignored")
+	assert.Equal(t, `^\+[0-9]+$`, field("phone").Pattern)
+
+	v := datacop.New()
+	s.Validate(v, map[string]any{
+		"email":   "jane@example.com",
+		"name":    "J",
+		"age":     float64(16),
+		"plan":    "enterprise",
+		"address": map[string]any{"city": "Paris"},
+		"phone":   "555",
+	})
+	assert.Equal(t, map[string]string{
+		"name":        "must be at least 2 characters",
+		"age":         "must be at least 18",
+		"plan":        "is not an allowed value",
+		"address.zip": "is required",
+		"phone":       "has an invalid format",
+	}, v.Errors())
+}
+
+func TestFromJSONSchema_RoundTrip(t *testing.T) {
+	original := deploymentSchema()
+	data, err := original.ToJSONSchema()
+	require.NoError(t, err)
+	imported, err := schema.FromJSONSchema(data)
+	require.NoError(t, err)
+
+	docs := []map[string]any{
+		{"name": "web", "spec": map[string]any{"replicas": 3, "image": "nginx"}},
+		{"spec": map[string]any{"replicas": 0, "strategy": "blue-green"}},
+		{},
+	}
+	for _, doc := range docs {
+		want, got := datacop.New(), datacop.New()
+		original.Validate(want, doc)
+		imported.Validate(got, doc)
+		assert.Equal(t, want.Errors(), got.Errors())
+	}
+}
+
+func TestFromJSONSchema_RoundTripRequiredNested(t *testing.T) {
+	original := schema.New()
+	original.Field("address.zip").String().Required()
+	data, err := original.ToJSONSchema()
+	require.NoError(t, err)
+	imported, err := schema.FromJSONSchema(data)
+	require.NoError(t, err)
+
+	for _, doc := range []map[string]any{{}, {"address": map[string]any{}}, {"address": map[string]any{"zip": "12345"}}} {
+		want, got := datacop.New(), datacop.New()
+		original.Validate(want, doc)
+		imported.Validate(got, doc)
+		assert.Equal(t, want.HasErrorFor("address.zip"), got.HasErrorFor("address.zip"), "%v", doc)
+	}
+}
+
+func TestFromJSONSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"invalid JSON", `{"type":`, "decoding JSON Schema"},
+		{"top level not an object schema", `{"type": "string"}`, "top level: expected an object schema"},
+		{"boolean schema", `true`, "expected a schema object"},
+		{"reference", `{"properties": {"a": {"$ref": "#/$defs/a"}}}`, `a: unsupported keyword "$ref"`},
+		{"exclusive bound", `{"properties": {"n": {"type": "number", "exclusiveMinimum": 0}}}`, `n: unsupported keyword "exclusiveMinimum"`},
+		{"unknown type", `{"properties": {"a": {"type": "date"}}}`, `a: unsupported type "date"`},
+		{"several types", `{"properties": {"a": {"type": ["string", "integer"]}}}`, "a: unsupported type"},
+		{"bad pattern", `{"properties": {"a": {"type": "string", "pattern": "("}}}`, "a: error parsing regexp"},
+		{"non-string enum", `{"properties": {"a": {"enum": [1, 2]}}}`, "a: enum values must be strings"},
+		{"required in optional object", `{"properties": {"billing": {"properties": {"vat": {"type": "string"}}, "required": ["vat"]}}}`, "billing: required properties of an optional object are not supported"},
+		{"fractional length", `{"properties": {"a": {"type": "string", "minLength": 1.5}}}`, "a: minLength must be a non-negative integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.FromJSONSchema([]byte(tt.doc))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Package schema describes documents declaratively, as a set of fields and the constraints they
+// must satisfy, and validates decoded data against that description using datacop.
+//
+// Unlike hand-written validation chains, a Schema can be inspected, so the same constraints can
+// be exported for client-side validation, as JSON Schema with ToJSONSchema, and to other tooling.
+// Schemas can also be built at runtime from JSON Schema documents with FromJSONSchema, and
+// checked against the structs they describe with CheckAgainstStruct, so tests catch drift
+// between the rules and the model. GenerateValid and GenerateInvalid produce example payloads
+// that satisfy or violate the rules, for API docs, contract tests, and fuzz corpora, and Compat
+// classifies the changes between two versions of a schema as compatible or breaking.
+//
+// Example usage:
+//
+//	s := schema.New()
+//	s.Field("email").String().Required().Format(schema.FormatEmail)
+//	s.Field("name").String().Required().MinLength(2).MaxLength(100)
+//	s.Field("age").Integer().Min(18)
+//	s.Field("plan").String().Enum("free", "pro")
+//
+//	v := datacop.New()
+//	s.Validate(v, data)
+package schema
+
+import (
+	"regexp"
+
+	"github.com/patrickward/datacop"
+)
+
+// Type is the expected type of a field's value
+type Type string
+
+// Supported field types
+const (
+	TypeAny     Type = ""
+	TypeString  Type = "string"
+	TypeInteger Type = "integer"
+	TypeNumber  Type = "number"
+	TypeBoolean Type = "boolean"
+	TypeArray   Type = "array"
+	TypeObject  Type = "object"
+)
+
+// Supported string formats
+const (
+	FormatEmail = "email"
+	FormatUUID  = "uuid"
+	FormatURI   = "uri"
+)
+
+// Rule codes recorded on validation errors, and used as keys for custom messages
+const (
+	CodeRequired  = "required"
+	CodeType      = "type"
+	CodeMinLength = "min_length"
+	CodeMaxLength = "max_length"
+	CodeMin       = "min"
+	CodeMax       = "max"
+	CodePattern   = "pattern"
+	CodeEnum      = "enum"
+	CodeFormat    = "format"
+)
+
+// Schema is a declarative description of a document's fields
+type Schema struct {
+	fields []*Field
+}
+
+// Field describes a single field of a document and the constraints on its value.
+// Nested fields are addressed with dotted paths, such as "spec.replicas".
+type Field struct {
+	Name      string
+	Type      Type
+	Required  bool
+	MinLength *int
+	MaxLength *int
+	Min       *float64
+	Max       *float64
+	Pattern   string
+	Enum      []string
+	Format    string
+	Messages  map[string]string // custom messages keyed by rule code
+
+	pattern *regexp.Regexp
+}
+
+// New creates an empty schema
+func New() *Schema {
+	return &Schema{}
+}
+
+// Field returns the field with the given name, declaring it if it does not exist yet.
+// Fields are validated in the order they were declared.
+func (s *Schema) Field(name string) *FieldBuilder {
+	for _, f := range s.fields {
+		if f.Name == name {
+			return &FieldBuilder{f: f}
+		}
+	}
+	f := &Field{Name: name}
+	s.fields = append(s.fields, f)
+	return &FieldBuilder{f: f}
+}
+
+// Fields returns the declared fields in declaration order
+func (s *Schema) Fields() []*Field {
+	return s.fields
+}
+
+// Lookup returns the field with the given name
+func (s *Schema) Lookup(name string) (*Field, bool) {
+	for _, f := range s.fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// FieldBuilder declares the constraints of a field with chained calls
+type FieldBuilder struct {
+	f *Field
+}
+
+// String requires the value to be a string
+func (b *FieldBuilder) String() *FieldBuilder { b.f.Type = TypeString; return b }
+
+// Integer requires the value to be a whole number
+func (b *FieldBuilder) Integer() *FieldBuilder { b.f.Type = TypeInteger; return b }
+
+// Number requires the value to be a number
+func (b *FieldBuilder) Number() *FieldBuilder { b.f.Type = TypeNumber; return b }
+
+// Boolean requires the value to be a boolean
+func (b *FieldBuilder) Boolean() *FieldBuilder { b.f.Type = TypeBoolean; return b }
+
+// Array requires the value to be a list
+func (b *FieldBuilder) Array() *FieldBuilder { b.f.Type = TypeArray; return b }
+
+// Object requires the value to be a map
+func (b *FieldBuilder) Object() *FieldBuilder { b.f.Type = TypeObject; return b }
+
+// Required requires the field to be present and non-empty
+func (b *FieldBuilder) Required() *FieldBuilder { b.f.Required = true; return b }
+
+// MinLength sets the minimum number of characters of a string, or items of an array
+func (b *FieldBuilder) MinLength(n int) *FieldBuilder { b.f.MinLength = &n; return b }
+
+// MaxLength sets the maximum number of characters of a string, or items of an array
+func (b *FieldBuilder) MaxLength(n int) *FieldBuilder { b.f.MaxLength = &n; return b }
+
+// Min sets the minimum value of a number
+func (b *FieldBuilder) Min(n float64) *FieldBuilder { b.f.Min = &n; return b }
+
+// Max sets the maximum value of a number
+func (b *FieldBuilder) Max(n float64) *FieldBuilder { b.f.Max = &n; return b }
+
+// Enum restricts a string to the given values
+func (b *FieldBuilder) Enum(values ...string) *FieldBuilder { b.f.Enum = values; return b }
+
+// Format requires a string to be in a known format: FormatEmail, FormatUUID, or FormatURI
+func (b *FieldBuilder) Format(format string) *FieldBuilder { b.f.Format = format; return b }
+
+// Pattern requires a string to match a regular expression. It panics if the pattern does not compile.
+func (b *FieldBuilder) Pattern(pattern string) *FieldBuilder {
+	b.f.Pattern = pattern
+	b.f.pattern = regexp.MustCompile(pattern)
+	return b
+}
+
+// Message overrides the message recorded when the rule with the given code fails. The message may
+// use the placeholders {field}, {value}, and the rule's parameters, such as {min} or {allowed}.
+//
+// Example usage:
+// s.Field("age").Integer().Min(18).Message(schema.CodeMin, "you must be at least {min}, not {value}")
+func (b *FieldBuilder) Message(code, message string) *FieldBuilder {
+	if b.f.Messages == nil {
+		b.f.Messages = make(map[string]string)
+	}
+	b.f.Messages[code] = message
+	return b
+}
+
+// message returns the custom message for code, or the default message, interpolated with params.
+// Custom messages may use the same placeholders as the defaults, such as {min} and {field}.
+func (f *Field) message(code string, params datacop.Params) string {
+	template, ok := f.Messages[code]
+	if !ok {
+		template = f.defaultMessage(code)
+	}
+	return datacop.Interpolate(template, params)
+}
+
+// params returns the values available to the message template of a rule: the field name and
+// the rule's parameters
+func (f *Field) params(code string) datacop.Params {
+	params := datacop.Params{"field": f.Name}
+	switch code {
+	case CodeType:
+		params["type"] = string(f.Type)
+	case CodeMinLength:
+		params["min"] = *f.MinLength
+	case CodeMaxLength:
+		params["max"] = *f.MaxLength
+	case CodeMin:
+		params["min"] = *f.Min
+	case CodeMax:
+		params["max"] = *f.Max
+	case CodePattern:
+		params["pattern"] = f.Pattern
+	case CodeEnum:
+		params["allowed"] = f.Enum
+	case CodeFormat:
+		params["format"] = f.Format
+	}
+	return params
+}
+
+// defaultMessage returns the message template used when a rule has no custom message
+func (f *Field) defaultMessage(code string) string {
+	switch code {
+	case CodeRequired:
+		return "is required"
+	case CodeType:
+		return "must be " + article(string(f.Type))
+	case CodeMinLength:
+		if f.Type == TypeArray {
+			return "must have at least {min} items"
+		}
+		return "must be at least {min} characters"
+	case CodeMaxLength:
+		if f.Type == TypeArray {
+			return "must have at most {max} items"
+		}
+		return "must be at most {max} characters"
+	case CodeMin:
+		return "must be at least {min}"
+	case CodeMax:
+		return "must be at most {max}"
+	case CodePattern:
+		return "has an invalid format"
+	case CodeEnum:
+		return "is not an allowed value"
+	case CodeFormat:
+		return "must be a valid {format}"
+	}
+	return "is invalid"
+}
+
+// article prefixes a type name with "a" or "an"
+func article(word string) string {
+	switch word {
+	case "integer", "array", "object":
+		return "an " + word
+	}
+	return "a " + word
+}
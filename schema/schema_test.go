@@ -0,0 +1,36 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop/schema"
+)
+
+func TestSchema_Field(t *testing.T) {
+	s := schema.New()
+	s.Field("name").String().Required().MinLength(2)
+	s.Field("age").Integer().Min(18)
+	s.Field("name").MaxLength(100)
+
+	fields := s.Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "name", fields[0].Name)
+	assert.Equal(t, "age", fields[1].Name)
+
+	name, ok := s.Lookup("name")
+	require.True(t, ok)
+	assert.Equal(t, schema.TypeString, name.Type)
+	assert.True(t, name.Required)
+	assert.Equal(t, 2, *name.MinLength)
+	assert.Equal(t, 100, *name.MaxLength)
+
+	_, ok = s.Lookup("missing")
+	assert.False(t, ok)
+}
+
+func TestSchema_PatternPanics(t *testing.T) {
+	assert.Panics(t, func() { schema.New().Field("code").Pattern("[") })
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"github.com/BurntSushi/toml"
+
+	"github.com/patrickward/datacop"
+)
+
+// ValidateTOML decodes a TOML document and validates it against the schema, recording failures
+// on v under each field's dotted key path, such as "server.port". Tables decode to maps, integers
+// to int64, and floats to float64. An error is returned if the document is not valid TOML; it is
+// a toml.ParseError carrying the line of the problem.
+//
+// Unlike ValidateYAML, no positions are returned because the TOML decoder does not expose them
+// for individual keys.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	if err := s.ValidateTOML(v, doc); err != nil {
+//		return err
+//	}
+//	for _, e := range v.OrderedErrors() {
+//		fmt.Printf("config.toml: %s %s\n", e.Field, e.Message) // config.toml: server.port must be at most 65535
+//	}
+func (s *Schema) ValidateTOML(v *datacop.Validator, doc []byte) error {
+	data := make(map[string]any)
+	if _, err := toml.Decode(string(doc), &data); err != nil {
+		return err
+	}
+
+	s.Validate(v, data)
+	return nil
+}
@@ -0,0 +1,105 @@
+package schema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func serverSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("title").String().Required()
+	s.Field("server.host").String().Required()
+	s.Field("server.port").Integer().Min(1).Max(65535)
+	s.Field("server.timeout").Number().Min(0.5)
+	s.Field("server.tls").Boolean()
+	s.Field("admins").Array().MinLength(1)
+	return s
+}
+
+func TestSchema_ValidateTOML(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid document",
+			doc: `
+title = "api"
+admins = ["ops@example.com"]
+
+[server]
+host = "0.0.0.0"
+port = 8080
+timeout = 2.5
+tls = true
+`,
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "invalid values",
+			doc: `
+title = "api"
+admins = []
+
+[server]
+host = "0.0.0.0"
+port = 70000
+timeout = 0.1
+tls = "yes"
+`,
+			wantErrors: map[string]string{
+				"server.port":    "must be at most 65535",
+				"server.timeout": "must be at least 0.5",
+				"server.tls":     "must be a boolean",
+			},
+		},
+		{
+			name: "missing keys",
+			doc: `
+[server]
+port = 8080
+`,
+			wantErrors: map[string]string{
+				"title":       "is required",
+				"server.host": "is required",
+			},
+		},
+		{
+			name: "dotted keys",
+			doc: `
+title = "api"
+server.host = "localhost"
+server.port = 0
+`,
+			wantErrors: map[string]string{
+				"server.port": "must be at least 1",
+			},
+		},
+	}
+
+	s := serverSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			require.NoError(t, s.ValidateTOML(v, []byte(tt.doc)))
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestSchema_ValidateTOML_InvalidDocument(t *testing.T) {
+	err := serverSchema().ValidateTOML(datacop.New(), []byte("title = \"api\"\nport = = 1\n"))
+	require.Error(t, err)
+
+	var perr toml.ParseError
+	require.True(t, errors.As(err, &perr))
+	assert.Equal(t, 2, perr.Position.Line)
+}
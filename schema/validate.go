@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"maps"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/internal/numeric"
+	"github.com/patrickward/datacop/is"
+)
+
+var rgxUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// formatPatterns holds regular expressions for the known formats, for clients to check them the
+// way checkFormat does. The URI pattern only checks for a scheme, as url.Parse does.
+var formatPatterns = map[string]string{
+	FormatEmail: is.EmailPattern,
+	FormatUUID:  rgxUUID.String(),
+	FormatURI:   `^[a-zA-Z][a-zA-Z0-9+.-]*:`,
+}
+
+// Validate checks data against the schema, recording failures on v under each field's name
+// with the failing rule's code. Absent, blank, and empty optional fields are not checked further.
+//
+// Example usage:
+//
+//	var data map[string]any
+//	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+//		return err
+//	}
+//	v := datacop.New()
+//	s.Validate(v, data)
+//	return v.ErrOrNil()
+func (s *Schema) Validate(v *datacop.Validator, data map[string]any) {
+	for _, f := range s.fields {
+		value, _ := Lookup(data, f.Name)
+		f.Validate(v, f.Name, value)
+	}
+}
+
+// Validate checks a single value against the field's constraints, recording failures on v
+// under name. It stops at the first failing rule, whose parameters, such as the minimum
+// length, are recorded in the error's Params.
+func (f *Field) Validate(v *datacop.Validator, name string, value any) bool {
+	if code, ok := f.Check(value); !ok {
+		params := f.params(code)
+		delete(params, "field")
+		var ruleParams datacop.Params
+		if len(params) > 0 {
+			ruleParams = maps.Clone(params)
+		}
+		params["field"] = v.Label(name)
+		params["value"] = value
+		v.AddValidationError(datacop.ValidationError{
+			Field:   name,
+			Code:    code,
+			Message: f.message(code, params),
+			Params:  ruleParams,
+		})
+		return false
+	}
+	return true
+}
+
+// Check reports whether value satisfies the field's constraints. If it does not, code
+// identifies the first rule that failed.
+func (f *Field) Check(value any) (code string, ok bool) {
+	for _, r := range f.CheckEach(value) {
+		if !r.Passed && !r.Skipped {
+			return r.Code, false
+		}
+	}
+	return "", true
+}
+
+// RuleResult is the outcome of one of a field's rules
+type RuleResult struct {
+	Code    string // the rule's code, such as CodeMinLength
+	Passed  bool
+	Skipped bool // the rule was not evaluated because the value is absent or has the wrong type
+}
+
+// CheckEach evaluates each rule declared on the field against value, in the order Check applies
+// them, and reports every outcome rather than only the first failure. When the value is absent,
+// only the required rule is evaluated; when it has the wrong type, the rules after the type rule
+// are skipped. Rules that do not apply to the value, such as a pattern on a number, pass.
+//
+// Example usage:
+//
+//	for _, r := range field.CheckEach("ab") {
+//		fmt.Println(r.Code, r.Passed) // required true, type true, min_length false
+//	}
+func (f *Field) CheckEach(value any) []RuleResult {
+	codes := f.codes()
+	results := make([]RuleResult, len(codes))
+	skip := !present(value)
+	for i, code := range codes {
+		results[i].Code = code
+		switch {
+		case code == CodeRequired:
+			results[i].Passed = !skip
+		case skip:
+			results[i].Skipped = true
+		default:
+			results[i].Passed = f.passes(code, value)
+			skip = code == CodeType && !results[i].Passed
+		}
+	}
+	return results
+}
+
+// passes reports whether a present value satisfies the rule with the given code
+func (f *Field) passes(code string, value any) bool {
+	str, isString := value.(string)
+	switch code {
+	case CodeType:
+		return f.checkType(value)
+	case CodeMinLength:
+		n, ok := length(value)
+		return !ok || n >= *f.MinLength
+	case CodeMaxLength:
+		n, ok := length(value)
+		return !ok || n <= *f.MaxLength
+	case CodeMin:
+		n, ok := toFloat(value)
+		return !ok || n >= *f.Min
+	case CodeMax:
+		n, ok := toFloat(value)
+		return !ok || n <= *f.Max
+	case CodePattern:
+		if !isString {
+			return true
+		}
+		re, err := f.compiled()
+		return err == nil && re.MatchString(str)
+	case CodeEnum:
+		return isString && slices.Contains(f.Enum, str)
+	case CodeFormat:
+		return !isString || checkFormat(f.Format, str)
+	}
+	return true
+}
+
+// checkType reports whether value matches the field's type
+func (f *Field) checkType(value any) bool {
+	switch f.Type {
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case TypeNumber:
+		_, ok := toFloat(value)
+		return ok
+	case TypeInteger:
+		n, ok := toFloat(value)
+		return ok && n == math.Trunc(n)
+	case TypeArray:
+		k := reflect.ValueOf(value).Kind()
+		return k == reflect.Slice || k == reflect.Array
+	case TypeObject:
+		return reflect.ValueOf(value).Kind() == reflect.Map
+	}
+	return true
+}
+
+// present reports whether a value counts as provided: not nil, not a blank string, and not an
+// empty list or map. Unlike is.Required, false and zero numbers are present.
+func present(value any) bool {
+	if value == nil {
+		return false
+	}
+	if str, ok := value.(string); ok {
+		return strings.TrimSpace(str) != ""
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	case reflect.Ptr:
+		return !v.IsNil()
+	}
+	return true
+}
+
+// Compile compiles the field's pattern so it is not compiled again on every check. Loaders such
+// as FromJSONSchema call it; call it after setting Pattern directly. It returns an error if the
+// pattern does not compile.
+func (f *Field) Compile() error {
+	f.pattern = nil
+	if f.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(f.Pattern)
+	if err != nil {
+		return err
+	}
+	f.pattern = re
+	return nil
+}
+
+// compiled returns the pattern compiled by Compile, or compiles it for this check only if the
+// field's pattern was set directly, so checks never modify a field shared between goroutines
+func (f *Field) compiled() (*regexp.Regexp, error) {
+	if f.pattern != nil && f.pattern.String() == f.Pattern {
+		return f.pattern, nil
+	}
+	return regexp.Compile(f.Pattern)
+}
+
+// checkFormat reports whether str is in the named format. Unknown formats always pass.
+func checkFormat(format, str string) bool {
+	switch format {
+	case FormatEmail:
+		return is.Email(str)
+	case FormatUUID:
+		return rgxUUID.MatchString(str)
+	case FormatURI:
+		u, err := url.Parse(str)
+		return err == nil && u.Scheme != ""
+	}
+	return true
+}
+
+// Lookup returns the value at a dotted path, such as "spec.replicas", in nested maps
+func Lookup(data map[string]any, path string) (any, bool) {
+	var current any = data
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// length returns the number of characters in a string or items in a list
+func length(value any) (int, bool) {
+	if str, ok := value.(string); ok {
+		return len([]rune(strings.TrimSpace(str))), true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// toFloat converts numeric values to float64. Unlike numeric.Float, it rejects numeric strings,
+// which are not numbers in JSON.
+func toFloat(value any) (float64, bool) {
+	if _, ok := value.(string); ok {
+		return 0, false
+	}
+	return numeric.Float(value)
+}
@@ -0,0 +1,232 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func signupSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("email").String().Required().Format(schema.FormatEmail)
+	s.Field("name").String().Required().MinLength(2).MaxLength(10)
+	s.Field("age").Integer().Min(18).Max(130).Message(schema.CodeMin, "you must be an adult")
+	s.Field("plan").String().Enum("free", "pro")
+	s.Field("code").String().Pattern(`^[A-Z]{3}$`)
+	s.Field("tags").Array().MaxLength(2)
+	s.Field("newsletter").Boolean().Required()
+	s.Field("address.zip").String().Required()
+	return s
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]any
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid document",
+			data: map[string]any{
+				"email":      "jane@example.com",
+				"name":       "Jane",
+				"age":        float64(30),
+				"plan":       "pro",
+				"code":       "ABC",
+				"tags":       []any{"a"},
+				"newsletter": false,
+				"address":    map[string]any{"zip": "12345"},
+			},
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "missing required fields",
+			data: map[string]any{"name": "  ", "address": map[string]any{}},
+			wantErrors: map[string]string{
+				"email":       "is required",
+				"name":        "is required",
+				"newsletter":  "is required",
+				"address.zip": "is required",
+			},
+		},
+		{
+			name: "rule failures",
+			data: map[string]any{
+				"email":      "not-an-email",
+				"name":       "J",
+				"age":        int64(12),
+				"plan":       "enterprise",
+				"code":       "abc",
+				"tags":       []any{"a", "b", "c"},
+				"newsletter": "yes",
+				"address":    map[string]any{"zip": 12345},
+			},
+			wantErrors: map[string]string{
+				"email":       "must be a valid email",
+				"name":        "must be at least 2 characters",
+				"age":         "you must be an adult",
+				"plan":        "is not an allowed value",
+				"code":        "has an invalid format",
+				"tags":        "must have at most 2 items",
+				"newsletter":  "must be a boolean",
+				"address.zip": "must be a string",
+			},
+		},
+		{
+			name: "integer type and max",
+			data: map[string]any{
+				"email":      "jane@example.com",
+				"name":       "Jane Doe The Third",
+				"age":        json.Number("30.5"),
+				"newsletter": true,
+				"address":    map[string]any{"zip": "12345"},
+			},
+			wantErrors: map[string]string{
+				"name": "must be at most 10 characters",
+				"age":  "must be an integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			signupSchema().Validate(v, tt.data)
+			assert.Equal(t, tt.wantErrors, v.Errors())
+		})
+	}
+}
+
+func TestSchema_ValidateCodes(t *testing.T) {
+	v := datacop.New()
+	signupSchema().Validate(v, map[string]any{"email": "x", "age": 200})
+
+	codes := map[string]string{}
+	for _, err := range v.OrderedErrors() {
+		codes[err.Field] = err.Code
+	}
+	assert.Equal(t, schema.CodeFormat, codes["email"])
+	assert.Equal(t, schema.CodeRequired, codes["name"])
+	assert.Equal(t, schema.CodeMax, codes["age"])
+}
+
+func TestSchema_Validate_MessagePlaceholders(t *testing.T) {
+	s := schema.New()
+	s.Field("age").Integer().Min(18).Message(schema.CodeMin, "{field} must be at least {min}, got {value}")
+	s.Field("plan").String().Enum("free", "pro").Message(schema.CodeEnum, "choose one of {allowed}")
+	s.Field("name").String().MinLength(2)
+
+	v := datacop.New()
+	s.Validate(v, map[string]any{"age": 16, "plan": "gold", "name": "J"})
+
+	assert.Equal(t, map[string]string{
+		"age":  "age must be at least 18, got 16",
+		"plan": "choose one of free, pro",
+		"name": "must be at least 2 characters",
+	}, v.Errors())
+
+	rules := s.ClientRules()
+	assert.Equal(t, "age must be at least 18, got {value}", rules[0].Messages[schema.CodeMin])
+}
+
+func TestSchema_Validate_Params(t *testing.T) {
+	v := datacop.New()
+	signupSchema().Validate(v, map[string]any{"name": "J", "age": 200, "plan": "gold", "newsletter": true})
+
+	params := map[string]datacop.Params{}
+	for _, err := range v.OrderedErrors() {
+		params[err.Field] = err.Params
+	}
+	assert.Equal(t, datacop.Params{"min": 2}, params["name"])
+	assert.Equal(t, datacop.Params{"max": 130.0}, params["age"])
+	assert.Equal(t, datacop.Params{"allowed": []string{"free", "pro"}}, params["plan"])
+	assert.Nil(t, params["email"])
+}
+
+func TestField_Check(t *testing.T) {
+	f := &schema.Field{Name: "id", Type: schema.TypeString, Format: schema.FormatUUID}
+
+	code, ok := f.Check("8c2f5b8e-7d3a-4b2e-9f1a-0c6d5e4b3a21")
+	assert.True(t, ok)
+	assert.Empty(t, code)
+
+	code, ok = f.Check("not-a-uuid")
+	assert.False(t, ok)
+	assert.Equal(t, schema.CodeFormat, code)
+
+	uri := &schema.Field{Name: "site", Format: schema.FormatURI}
+	_, ok = uri.Check("https://example.com")
+	assert.True(t, ok)
+	_, ok = uri.Check("example.com")
+	assert.False(t, ok)
+
+	bad := &schema.Field{Name: "code", Pattern: "["}
+	code, ok = bad.Check("x")
+	assert.False(t, ok)
+	assert.Equal(t, schema.CodePattern, code)
+}
+
+func TestField_CheckEach(t *testing.T) {
+	minLength := 3
+	f := &schema.Field{Name: "name", Type: schema.TypeString, Required: true, MinLength: &minLength, Pattern: "^[a-z]+$"}
+
+	tests := []struct {
+		name  string
+		value any
+		want  []schema.RuleResult
+	}{
+		{"valid", "alice", []schema.RuleResult{
+			{Code: schema.CodeRequired, Passed: true},
+			{Code: schema.CodeType, Passed: true},
+			{Code: schema.CodeMinLength, Passed: true},
+			{Code: schema.CodePattern, Passed: true},
+		}},
+		{"several failures", "AL", []schema.RuleResult{
+			{Code: schema.CodeRequired, Passed: true},
+			{Code: schema.CodeType, Passed: true},
+			{Code: schema.CodeMinLength},
+			{Code: schema.CodePattern},
+		}},
+		{"absent", "", []schema.RuleResult{
+			{Code: schema.CodeRequired},
+			{Code: schema.CodeType, Skipped: true},
+			{Code: schema.CodeMinLength, Skipped: true},
+			{Code: schema.CodePattern, Skipped: true},
+		}},
+		{"wrong type", 42, []schema.RuleResult{
+			{Code: schema.CodeRequired, Passed: true},
+			{Code: schema.CodeType},
+			{Code: schema.CodeMinLength, Skipped: true},
+			{Code: schema.CodePattern, Skipped: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, f.CheckEach(tt.value))
+		})
+	}
+
+	minAge := 18.0
+	optional := &schema.Field{Name: "age", Min: &minAge}
+	assert.Equal(t, []schema.RuleResult{{Code: schema.CodeMin, Skipped: true}}, optional.CheckEach(nil))
+	assert.Empty(t, (&schema.Field{Name: "any"}).CheckEach("x"))
+}
+
+func TestLookup(t *testing.T) {
+	data := map[string]any{"spec": map[string]any{"replicas": 3}, "name": "web"}
+
+	v, ok := schema.Lookup(data, "spec.replicas")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	_, ok = schema.Lookup(data, "spec.image")
+	assert.False(t, ok)
+
+	_, ok = schema.Lookup(data, "name.first")
+	assert.False(t, ok)
+}
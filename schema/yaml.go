@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/patrickward/datacop"
+)
+
+// Position is a location in a source document. Lines and columns start at 1.
+type Position = datacop.Position
+
+// Positions maps dotted field paths to their position in a source document
+type Positions map[string]Position
+
+// ValidateYAML decodes a YAML document and validates it against the schema, recording failures
+// on v. The line and column of every mapping key are set on v with SetPosition, so errors carry
+// them in their Position, and returned so that failures can be reported against the source with
+// Positions.Report. An error is returned if the document is not valid YAML or its top level is
+// not a mapping.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	positions, err := s.ValidateYAML(v, doc)
+//	if err != nil {
+//		return err
+//	}
+//	for _, line := range positions.Report(v, "config.yaml") {
+//		fmt.Println(line) // config.yaml:14:3 spec.replicas must be at least 1
+//	}
+func (s *Schema) ValidateYAML(v *datacop.Validator, doc []byte) (Positions, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any)
+	positions := make(Positions)
+	if len(root.Content) > 0 {
+		node := root.Content[0]
+		if node.Kind != yaml.MappingNode {
+			return nil, errors.New("schema: YAML document must be a mapping")
+		}
+		if err := node.Decode(&data); err != nil {
+			return nil, err
+		}
+		collectPositions(positions, "", node)
+	}
+
+	positions.set(v)
+	s.Validate(v, data)
+	return positions, nil
+}
+
+// collectPositions records the position of the keys of a mapping node and its nested mappings
+func collectPositions(positions Positions, prefix string, node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+		positions[path] = Position{Line: key.Line, Column: key.Column}
+		if value.Kind == yaml.MappingNode {
+			collectPositions(positions, path, value)
+		}
+	}
+}
+
+// set records the positions on v
+func (p Positions) set(v *datacop.Validator) {
+	for field, pos := range p {
+		v.SetPosition(field, pos)
+	}
+}
+
+// Position returns the position of a field. Fields missing from the document are reported at
+// the position of their closest enclosing key, if any.
+func (p Positions) Position(field string) (Position, bool) {
+	for {
+		if pos, ok := p[field]; ok {
+			return pos, true
+		}
+		i := strings.LastIndexByte(field, '.')
+		if i < 0 {
+			return Position{}, false
+		}
+		field = field[:i]
+	}
+}
+
+// Report formats the errors recorded on v as "file:line:column field message" lines, in the
+// order the fields failed validation. Errors without a position are reported as "file: field
+// message", and standalone errors as "file: message".
+func (p Positions) Report(v *datacop.Validator, filename string) []string {
+	var lines []string
+	for _, e := range v.OrderedErrors() {
+		if e.Field == datacop.StandaloneErrorKey {
+			lines = append(lines, fmt.Sprintf("%s: %s", filename, e.Message))
+			continue
+		}
+		if pos, ok := p.Position(e.Field); ok {
+			lines = append(lines, fmt.Sprintf("%s:%d:%d %s %s", filename, pos.Line, pos.Column, e.Field, e.Message))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s %s", filename, e.Field, e.Message))
+	}
+	return lines
+}
@@ -0,0 +1,124 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/schema"
+)
+
+func deploymentSchema() *schema.Schema {
+	s := schema.New()
+	s.Field("name").String().Required()
+	s.Field("spec").Object().Required()
+	s.Field("spec.replicas").Integer().Required().Min(1)
+	s.Field("spec.image").String().Required()
+	s.Field("spec.strategy").String().Enum("rolling", "recreate")
+	return s
+}
+
+func TestSchema_ValidateYAML(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		wantErrors map[string]string
+		wantReport []string
+	}{
+		{
+			name: "valid document",
+			doc: `name: web
+spec:
+  replicas: 3
+  image: nginx:1.27
+`,
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "invalid values",
+			doc: `name: web
+spec:
+  image: nginx:1.27
+  replicas: 0
+  strategy: blue-green
+`,
+			wantErrors: map[string]string{
+				"spec.replicas": "must be at least 1",
+				"spec.strategy": "is not an allowed value",
+			},
+			wantReport: []string{
+				"config.yaml:4:3 spec.replicas must be at least 1",
+				"config.yaml:5:3 spec.strategy is not an allowed value",
+			},
+		},
+		{
+			name: "missing fields",
+			doc: `spec:
+  replicas: 2
+`,
+			wantErrors: map[string]string{
+				"name":       "is required",
+				"spec.image": "is required",
+			},
+			wantReport: []string{
+				"config.yaml: name is required",
+				"config.yaml:1:1 spec.image is required",
+			},
+		},
+		{
+			name:       "empty document",
+			doc:        "",
+			wantErrors: map[string]string{"name": "is required", "spec": "is required", "spec.replicas": "is required", "spec.image": "is required"},
+			wantReport: []string{
+				"config.yaml: name is required",
+				"config.yaml: spec is required",
+				"config.yaml: spec.replicas is required",
+				"config.yaml: spec.image is required",
+			},
+		},
+	}
+
+	s := deploymentSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			positions, err := s.ValidateYAML(v, []byte(tt.doc))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErrors, v.Errors())
+			assert.Equal(t, tt.wantReport, positions.Report(v, "config.yaml"))
+		})
+	}
+}
+
+func TestSchema_ValidateYAML_ErrorPositions(t *testing.T) {
+	doc := `name: web
+spec:
+  image: nginx:1.27
+  replicas: 0
+`
+	v := datacop.New()
+	_, err := deploymentSchema().ValidateYAML(v, []byte(doc))
+	require.NoError(t, err)
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, &datacop.Position{Line: 4, Column: 3}, errs[0].Position)
+}
+
+func TestSchema_ValidateYAML_InvalidDocument(t *testing.T) {
+	s := deploymentSchema()
+
+	_, err := s.ValidateYAML(datacop.New(), []byte("name: [unclosed"))
+	assert.Error(t, err)
+
+	_, err = s.ValidateYAML(datacop.New(), []byte("- just\n- a list\n"))
+	assert.ErrorContains(t, err, "must be a mapping")
+}
+
+func TestPositions_Report_Standalone(t *testing.T) {
+	v := datacop.New()
+	v.AddStandaloneError("document is empty")
+	assert.Equal(t, []string{"config.yaml: document is empty"}, schema.Positions{}.Report(v, "config.yaml"))
+}
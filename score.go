@@ -0,0 +1,95 @@
+package datacop
+
+import "slices"
+
+// scoreTally holds the weight of the weighted rules that ran and of those that passed
+type scoreTally struct {
+	passed float64
+	total  float64
+}
+
+func (t *scoreTally) add(weight float64, passed bool) {
+	t.total += weight
+	if passed {
+		t.passed += weight
+	}
+}
+
+func (t *scoreTally) merge(other scoreTally) {
+	t.passed += other.passed
+	t.total += other.total
+}
+
+// Weighted attaches a weight to a validation function for scoring. When the function runs in
+// Validate or RuleSet.Apply, its weight counts toward the validator's Score, and in scoring mode
+// a failure of a rule lighter than the error weight is recorded as a suggestion rather than an
+// error; see WithScoring. Rules without a weight are not scored.
+//
+// Example usage:
+//
+//	v.Field("email", p.Email).Validate(datacop.Weighted(10, is.Required), "email is required")
+//	v.Field("bio", p.Bio).Validate(datacop.Weighted(2, is.Required), "add a bio so others can find you")
+func Weighted(weight float64, fn ValidationFunc) ValidationFunc {
+	m := metaOf(fn)
+	m.weighted, m.weight = true, weight
+	return m.attach(fn)
+}
+
+// WithScoring makes the validator record a failing weighted rule whose weight is below
+// errorWeight as an annotation of kind AnnotationSuggestion instead of an error, so optional
+// quality checks, such as a missing profile photo, lower the Score without failing validation.
+// The annotation's data holds the rule's code, params, and weight. Heavier weighted rules and
+// rules without a weight fail as usual.
+//
+// Example usage:
+// v := datacop.New(datacop.WithScoring(5))
+func WithScoring(errorWeight float64) Option {
+	return func(v *Validator) {
+		v.scoring = true
+		v.errorWeight = errorWeight
+	}
+}
+
+// suggest records the failure of a light weighted rule as a suggestion
+func (v *Validator) suggest(e ValidationError, weight float64) {
+	data := map[string]any{"weight": weight}
+	if e.Code != "" {
+		data["code"] = e.Code
+	}
+	if e.Params != nil {
+		data["params"] = e.Params
+	}
+	v.Annotate(e.Field, AnnotationSuggestion, v.labeled(e).Message, data)
+}
+
+// Score returns the weight of the weighted rules that passed as a fraction of the weight of all
+// weighted rules that ran, from 0 to 1, such as 0.78 for a profile that is 78% complete. It
+// returns 1 if no weighted rules ran. Rules nested in groups count toward their parent's score.
+//
+// Example usage:
+// fmt.Printf("profile %.0f%% complete\n", v.Score()*100)
+func (v *Validator) Score() float64 {
+	if v.score.total == 0 {
+		return 1
+	}
+	return v.score.passed / v.score.total
+}
+
+// Suggestions returns the suggestions recorded in scoring mode, heaviest first, so the changes
+// that would raise the score the most come first. Suggestions of equal weight keep the order
+// they were recorded in.
+func (v *Validator) Suggestions() []Annotation {
+	suggestions := v.AnnotationsOfKind(AnnotationSuggestion)
+	slices.SortStableFunc(suggestions, func(a, b Annotation) int {
+		wa, _ := a.Data["weight"].(float64)
+		wb, _ := b.Data["weight"].(float64)
+		switch {
+		case wa > wb:
+			return -1
+		case wa < wb:
+			return 1
+		}
+		return 0
+	})
+	return suggestions
+}
@@ -0,0 +1,127 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+type profile struct {
+	Email string
+	Photo string
+	Bio   string
+}
+
+func (p profile) Validate(v *datacop.Validator) {
+	v.Field("email", p.Email).Validate(datacop.Weighted(10, is.Required), "email is required")
+	v.Field("photo", p.Photo).Validate(datacop.Weighted(3, is.Required), "add a profile photo")
+	v.Field("bio", p.Bio).Validate(datacop.Weighted(2, is.MinLength(20)), "write a longer bio")
+}
+
+func TestValidator_Score(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []datacop.Option
+		profile         profile
+		wantScore       float64
+		wantErrors      map[string]string
+		wantSuggestions []string
+	}{
+		{
+			name:      "complete profile",
+			opts:      []datacop.Option{datacop.WithScoring(5)},
+			profile:   profile{Email: "a@example.com", Photo: "me.png", Bio: "writes Go and climbs rocks"},
+			wantScore: 1,
+		},
+		{
+			name:            "light rules fail as suggestions",
+			opts:            []datacop.Option{datacop.WithScoring(5)},
+			profile:         profile{Email: "a@example.com"},
+			wantScore:       10.0 / 15,
+			wantSuggestions: []string{"photo", "bio"},
+		},
+		{
+			name:            "heavy rules fail as errors",
+			opts:            []datacop.Option{datacop.WithScoring(5)},
+			profile:         profile{Photo: "me.png"},
+			wantScore:       3.0 / 15,
+			wantErrors:      map[string]string{"email": "email is required"},
+			wantSuggestions: []string{"bio"},
+		},
+		{
+			name:       "without scoring mode every rule fails as an error",
+			profile:    profile{Email: "a@example.com"},
+			wantScore:  10.0 / 15,
+			wantErrors: map[string]string{"photo": "add a profile photo", "bio": "write a longer bio"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New(tt.opts...)
+			tt.profile.Validate(v)
+
+			assert.InDelta(t, tt.wantScore, v.Score(), 1e-9)
+			if tt.wantErrors == nil {
+				assert.False(t, v.HasErrors())
+			} else {
+				assert.Equal(t, tt.wantErrors, v.Errors())
+			}
+			var fields []string
+			for _, s := range v.Suggestions() {
+				fields = append(fields, s.Field)
+			}
+			assert.Equal(t, tt.wantSuggestions, fields)
+		})
+	}
+}
+
+func TestValidator_Suggestions(t *testing.T) {
+	v := datacop.New(datacop.WithScoring(5))
+	v.Field("bio", "").Validate(datacop.Weighted(1, is.MinLength(20)), "write at least {min} characters")
+	v.Field("photo", "").Validate(datacop.Weighted(3, is.Required), "add a profile photo")
+
+	assert.Equal(t, []datacop.Annotation{
+		{Field: "photo", Kind: datacop.AnnotationSuggestion, Message: "add a profile photo", Data: map[string]any{"code": "required", "weight": 3.0}},
+		{Field: "bio", Kind: datacop.AnnotationSuggestion, Message: "write at least 20 characters", Data: map[string]any{
+			"code": "min_length", "params": datacop.Params{"min": 20}, "weight": 1.0,
+		}},
+	}, v.Suggestions())
+}
+
+func TestValidator_ScoreAcrossValidators(t *testing.T) {
+	v := datacop.New(datacop.WithScoring(5))
+	assert.Equal(t, 1.0, v.Score(), "no weighted rules ran")
+
+	// Unweighted rules are not scored
+	v.Field("name", "").Validate(is.Required, "is required")
+	assert.Equal(t, 1.0, v.Score())
+
+	// Nested objects count toward the parent's score and keep scoring mode
+	v.Group("profile").Validate(profile{Email: "a@example.com"})
+	assert.InDelta(t, 10.0/15, v.Score(), 1e-9)
+	assert.Equal(t, map[string]string{"name": "is required"}, v.Errors())
+	assert.Len(t, v.Suggestions(), 2)
+	assert.Equal(t, "profile.photo", v.Suggestions()[0].Field)
+
+	other := datacop.New()
+	other.Field("terms", "").Validate(datacop.Weighted(5, is.Required), "must be accepted")
+	v.Merge(other)
+	assert.InDelta(t, 10.0/20, v.Score(), 1e-9)
+
+	v.Clear()
+	assert.Equal(t, 1.0, v.Score())
+	assert.Empty(t, v.Suggestions())
+}
+
+func TestWeighted(t *testing.T) {
+	rule := datacop.Weighted(2, is.MinLength(3))
+
+	assert.True(t, rule("abc"))
+	assert.False(t, rule("ab"))
+	assert.Equal(t, "min_length", rule.Name())
+	assert.Equal(t, datacop.Params{"min": 3}, rule.Params())
+}
@@ -0,0 +1,80 @@
+package datacop
+
+// ErrorSink stores validation errors outside the validator, such as in a file, an object store,
+// or a database table, for validations that record too many errors to hold in memory
+type ErrorSink interface {
+	Write(e ValidationError) error
+}
+
+// ErrorSinkFunc adapts a function to the ErrorSink interface
+type ErrorSinkFunc func(e ValidationError) error
+
+// Write calls fn(e)
+func (fn ErrorSinkFunc) Write(e ValidationError) error {
+	return fn(e)
+}
+
+// WithErrorSink writes every error recorded on the validator to sink and keeps only the first
+// sample errors in memory, so methods such as Errors and OrderedErrors return a sample while
+// HasErrors and ErrorCount account for every error. A negative sample keeps every error.
+//
+// Writing stops at the first error the sink returns, which SinkErr reports; the validator keeps
+// counting errors. Reporters are still called for every error.
+//
+// Example usage:
+//
+//	enc := json.NewEncoder(f)
+//	v := datacop.New(datacop.WithErrorSink(datacop.ErrorSinkFunc(func(e datacop.ValidationError) error {
+//		return enc.Encode(e)
+//	}), 100))
+func WithErrorSink(sink ErrorSink, sample int) Option {
+	return func(v *Validator) {
+		v.sink, v.sample = sink, sample
+	}
+}
+
+// store keeps e in memory, or, with an error sink, writes it to the sink and keeps it if the
+// sample is not full
+func (v *Validator) store(e ValidationError) {
+	if v.sink != nil {
+		if v.sinkErr == nil {
+			v.sinkErr = v.sink.Write(v.labeled(e))
+		}
+		if v.sample >= 0 && v.held >= v.sample {
+			v.dropped++
+			return
+		}
+		v.held++
+	}
+
+	if v.errors == nil {
+		v.errors = make(map[string][]ValidationError)
+	}
+	if len(v.errors[e.Field]) == 0 {
+		v.order = append(v.order, e.Field)
+	}
+	v.errors[e.Field] = append(v.errors[e.Field], e)
+}
+
+// ErrorCount returns the number of errors recorded, including errors written to an error sink
+// and not kept in memory. It is safe to call on a nil validator.
+func (v *Validator) ErrorCount() int {
+	if v == nil {
+		return 0
+	}
+	v.Wait()
+	n := v.dropped
+	for _, errs := range v.errors {
+		n += len(errs)
+	}
+	return n
+}
+
+// SinkErr returns the first error returned by the error sink, or nil. It is safe to call on a
+// nil validator.
+func (v *Validator) SinkErr() error {
+	if v == nil {
+		return nil
+	}
+	return v.sinkErr
+}
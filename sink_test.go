@@ -0,0 +1,88 @@
+package datacop_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+)
+
+// collect returns a sink that appends the errors written to it to errs
+func collect(errs *[]datacop.ValidationError) datacop.ErrorSink {
+	return datacop.ErrorSinkFunc(func(e datacop.ValidationError) error {
+		*errs = append(*errs, e)
+		return nil
+	})
+}
+
+func TestWithErrorSink(t *testing.T) {
+	tests := []struct {
+		name        string
+		sample      int
+		wantInMem   int
+		wantMessage string
+	}{
+		{"sample", 2, 2, "a: [is invalid] | b: [is invalid] | and 2 more"},
+		{"counts only", 0, 0, "and 4 more"},
+		{"keep everything", -1, 4, "a: [is invalid] | b: [is invalid] | c: [is invalid] | d: [is invalid]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written []datacop.ValidationError
+			v := datacop.New(datacop.WithErrorSink(collect(&written), tt.sample))
+			for _, field := range []string{"a", "b", "c", "d"} {
+				v.Check(false, field, "is invalid")
+			}
+
+			assert.Len(t, written, 4)
+			assert.Len(t, v.OrderedErrors(), tt.wantInMem)
+			assert.Equal(t, 4, v.ErrorCount())
+			assert.True(t, v.HasErrors())
+			assert.Equal(t, tt.wantMessage, v.Error())
+
+			v.Clear()
+			assert.False(t, v.HasErrors())
+			assert.Equal(t, 0, v.ErrorCount())
+		})
+	}
+}
+
+func TestWithErrorSink_WriteError(t *testing.T) {
+	failure := errors.New("bucket unavailable")
+	writes := 0
+	v := datacop.New(datacop.WithErrorSink(datacop.ErrorSinkFunc(func(datacop.ValidationError) error {
+		writes++
+		return failure
+	}), 0))
+
+	v.Check(false, "a", "is invalid")
+	v.Check(false, "b", "is invalid")
+
+	assert.Equal(t, 1, writes, "writing stops at the first error")
+	assert.ErrorIs(t, v.SinkErr(), failure)
+	assert.Equal(t, 2, v.ErrorCount())
+}
+
+func TestWithErrorSink_NestedAndMerged(t *testing.T) {
+	var written []datacop.ValidationError
+	v := datacop.New(datacop.WithErrorSink(collect(&written), 1))
+
+	v.Group("shipping").Validate(address{Street: "1 Main St"})
+	other := datacop.New()
+	other.AddError("terms", "must be accepted")
+	v.Merge(other)
+
+	require.Len(t, written, 2)
+	assert.Equal(t, "shipping.zip", written[0].Field)
+	assert.Equal(t, "terms", written[1].Field)
+	assert.Equal(t, map[string]string{"shipping.zip": "must be at least 5 characters"}, v.Errors())
+	assert.Equal(t, 2, v.ErrorCount())
+
+	var nilValidator *datacop.Validator
+	assert.Equal(t, 0, nilValidator.ErrorCount())
+	assert.NoError(t, nilValidator.SinkErr())
+}
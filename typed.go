@@ -0,0 +1,72 @@
+package datacop
+
+// TypedField is a validation chain for a field whose value has the static type T. Its rules take
+// a T, so a rule written for the wrong type is a compile error rather than a rule that silently
+// fails at runtime with a confusing message.
+type TypedField[T any] struct {
+	f     *FieldValidation
+	value T
+}
+
+// FieldOf starts a typed validation chain for the given field
+//
+// Example usage:
+//
+//	datacop.FieldOf(v, "age", age).
+//		Validate(func(n int) bool { return n >= 18 }, "must be at least 18").
+//		Rule(is.Max(130))
+func FieldOf[T any](v *Validator, name string, value T) *TypedField[T] {
+	return &TypedField[T]{f: v.Field(name, value), value: value}
+}
+
+// Check performs a validation in the chain, as FieldValidation.Check does
+func (t *TypedField[T]) Check(valid bool, message string) *TypedField[T] {
+	t.f.Check(valid, message)
+	return t
+}
+
+// Validate runs fn against the field's current value and adds an error with message if it fails
+//
+// Example usage:
+// datacop.FieldOf(v, "tags", tags).Validate(func(tags []string) bool { return len(tags) <= 5 }, "has too many tags")
+func (t *TypedField[T]) Validate(fn func(T) bool, message ...string) *TypedField[T] {
+	t.f.Validate(func(any) bool { return fn(t.value) }, message...)
+	return t
+}
+
+// Rule runs an untyped validation function, such as one from the is package, against the field's
+// current value. Named rules keep their code, params, and default message.
+//
+// Example usage:
+// datacop.FieldOf(v, "name", name).Rule(is.MinLength(3)) // "must be at least 3 characters"
+func (t *TypedField[T]) Rule(fn ValidationFunc, message ...string) *TypedField[T] {
+	t.f.Validate(fn, message...)
+	return t
+}
+
+// Transform normalizes the field's value before it is validated, applying each function in
+// order. Later rules and Value see the transformed value.
+//
+// Example usage:
+// email := datacop.FieldOf(v, "email", raw).Transform(strings.TrimSpace, strings.ToLower).Rule(is.Email).Value()
+func (t *TypedField[T]) Transform(fns ...func(T) T) *TypedField[T] {
+	for _, fn := range fns {
+		t.value = fn(t.value)
+	}
+	t.f.value = t.value
+	return t
+}
+
+// Value returns the field's current value, after any transforms
+func (t *TypedField[T]) Value() T {
+	return t.value
+}
+
+// Untyped returns the underlying chain, for conditional validation with When and the other
+// methods of FieldValidation. It sees the transformed value.
+//
+// Example usage:
+// datacop.FieldOf(v, "vat_id", vatID).Untyped().When(country == "DE").Validate(is.Required, "is required")
+func (t *TypedField[T]) Untyped() *FieldValidation {
+	return t.f
+}
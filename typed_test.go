@@ -0,0 +1,68 @@
+package datacop_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestFieldOf_Validate(t *testing.T) {
+	adult := func(n int) bool { return n >= 18 }
+
+	tests := []struct {
+		name  string
+		age   int
+		want  string
+		valid bool
+	}{
+		{"passes", 30, "", true},
+		{"fails", 12, "must be at least 18", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			datacop.FieldOf(v, "age", tt.age).Validate(adult, "must be at least 18")
+			assert.Equal(t, tt.valid, !v.HasErrors())
+			assert.Equal(t, tt.want, v.ErrorFor("age"))
+		})
+	}
+}
+
+func TestFieldOf_Chain(t *testing.T) {
+	v := datacop.New()
+	calls := 0
+	name := datacop.FieldOf(v, "name", "  Al ").
+		Transform(strings.TrimSpace).
+		Validate(func(s string) bool { calls++; return s != "" }, "is required").
+		Rule(is.MinLength(3)).
+		Check(false, "is taken").
+		Value()
+
+	assert.Equal(t, "Al", name)
+	assert.Equal(t, 1, calls, "typed rules are not called by rule probes")
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "name", Code: "min_length", Message: "must be at least 3 characters", Params: datacop.Params{"min": 3}},
+		{Field: "name", Message: "is taken"},
+	}, v.OrderedErrors())
+}
+
+func TestFieldOf_NilInterface(t *testing.T) {
+	v := datacop.New()
+	var err error
+	datacop.FieldOf(v, "err", err).Validate(func(err error) bool { return err == nil }, "must be nil")
+	assert.False(t, v.HasErrors())
+}
+
+func TestFieldOf_Untyped(t *testing.T) {
+	v := datacop.New()
+	datacop.FieldOf(v, "vat_id", " ").
+		Transform(strings.TrimSpace).
+		Untyped().
+		When(true).Validate(is.Required, "is required")
+	assert.Equal(t, "is required", v.ErrorFor("vat_id"))
+}
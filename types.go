@@ -2,3 +2,169 @@ package datacop
 
 // ValidationFunc is a non-generic function type for validation
 type ValidationFunc func(value any) bool
+
+// Validatable is implemented by types that validate themselves, recording failures on v
+type Validatable interface {
+	Validate(v *Validator)
+}
+
+// NamedRule is a ValidationFunc created with Named, such as those returned by the constructors
+// of the is package. It is the same type as ValidationFunc, so it can be called and passed
+// wherever one is expected, and its Name and Params methods describe the rule.
+//
+// Example usage:
+// rule := is.MinLength(8)
+// rule.Name()   // returns "min_length"
+// rule.Params() // returns map[min:8]
+// rule("short") // returns false
+type NamedRule = ValidationFunc
+
+// Named attaches a rule name and parameters to a validation function. When the function fails
+// in Validate or RuleSet.Apply, the name is recorded as the error's code and the parameters as
+// its params, and both are available to message templates. The metadata attached to fn with
+// Annotated and Weighted is kept.
+//
+// Example usage:
+//
+//	func MultipleOf(n int) datacop.NamedRule {
+//		return datacop.Named("multiple_of", datacop.Params{"n": n}, func(value any) bool {
+//			v, ok := value.(int)
+//			return ok && v%n == 0
+//		})
+//	}
+func Named(name string, params Params, fn ValidationFunc) NamedRule {
+	m := metaOf(fn)
+	m.name, m.params = name, params
+	return m.attach(fn)
+}
+
+// Named attaches a rule name and parameters to fn, as the Named function does. It reads well
+// after Annotated.
+//
+// Example usage:
+//
+//	datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+//		...
+//	}).Named("spell_check", nil)
+func (fn ValidationFunc) Named(name string, params Params) NamedRule {
+	return Named(name, params, fn)
+}
+
+// RegisterRule attaches a rule name and parameters to a function declared at package level,
+// such as is.Required, so the function itself carries them wherever it is passed. The function
+// must depend on nothing but its value, since its results are memoized like those of rules
+// returned by Memoized. Use Named for other functions, such as closures, which would otherwise
+// keep the metadata alive forever.
+//
+// Example usage:
+//
+//	func Even(value any) bool {
+//		n, ok := value.(int)
+//		return ok && n%2 == 0
+//	}
+//
+//	func init() {
+//		datacop.RegisterRule("even", nil, Even)
+//	}
+func RegisterRule(name string, params Params, fn ValidationFunc) {
+	m := metaOf(fn)
+	m.name, m.params, m.memoized = name, params, true
+	m.check = fn
+	registerStatic(fn, m)
+}
+
+// RuleInfo returns the name and parameters attached to fn with Named or RegisterRule. It
+// reports false for functions without them.
+func RuleInfo(fn ValidationFunc) (name string, params Params, ok bool) {
+	m := metaOf(fn)
+	return m.name, m.params, m.name != ""
+}
+
+// Name returns the rule name attached to fn with Named, or "" for functions without one
+func (fn ValidationFunc) Name() string {
+	return metaOf(fn).name
+}
+
+// Params returns the parameters attached to fn with Named, or nil for functions without them
+func (fn ValidationFunc) Params() Params {
+	return metaOf(fn).params
+}
+
+// AnnotateFunc records an annotation for the field being validated
+type AnnotateFunc func(kind, message string, data map[string]any)
+
+// Annotated creates a validation function that can record annotations about the value, such as
+// the categories a moderation service flagged it for. When it runs in Validate or RuleSet.Apply,
+// the annotations are recorded for the field being validated, whether or not it passes. Called
+// directly, its annotations are discarded.
+//
+// Example usage:
+//
+//	longText := datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+//		s, ok := value.(string)
+//		if ok && len(s) > 1000 {
+//			annotate(datacop.AnnotationWarning, "long text may be truncated", nil)
+//		}
+//		return ok
+//	})
+func Annotated(fn func(value any, annotate AnnotateFunc) bool) ValidationFunc {
+	m := ruleMeta{annotate: fn}
+	return m.attach(func(value any) bool {
+		return fn(value, func(string, string, map[string]any) {})
+	})
+}
+
+// Intercept wraps a validation function so around runs each call to it, such as to time,
+// log, or delay the calls to an external service. around must call call to run fn, and returns
+// the result of the check. The metadata attached to fn with Named, Annotated, and Weighted is
+// kept.
+//
+// Example usage:
+//
+//	timed := datacop.Intercept(is.PassesModeration(ctx, moderator), func(call func() bool) bool {
+//		start := time.Now()
+//		defer func() { metrics.ModerationLatency(time.Since(start)) }()
+//		return call()
+//	})
+func Intercept(fn ValidationFunc, around func(call func() bool) bool) ValidationFunc {
+	m := metaOf(fn)
+	m.memoized = false
+	if annotate := m.annotate; annotate != nil {
+		m.annotate = func(value any, a AnnotateFunc) bool {
+			return around(func() bool { return annotate(value, a) })
+		}
+	}
+	return m.attach(func(value any) bool {
+		return around(func() bool { return fn(value) })
+	})
+}
+
+// Rule pairs a validation function with the message recorded when it fails. An empty message
+// uses the rule's default message; see Validator.SetDefaultMessage.
+type Rule struct {
+	Func    ValidationFunc
+	Message string
+}
+
+// RuleSet is an ordered list of rules applied to a single value
+type RuleSet []Rule
+
+// Apply runs every rule in the set against value and records failures under field, with the
+// code and params of rules created with Named. It returns true if all rules pass.
+//
+// Example usage:
+//
+//	rules := datacop.RuleSet{
+//		{Func: is.Required, Message: "name is required"},
+//		{Func: is.MaxLength(255), Message: "name is too long"},
+//	}
+//	rules.Apply(v, "name", name)
+func (rs RuleSet) Apply(v *Validator, field string, value any) bool {
+	valid := true
+	for _, r := range rs {
+		if !v.checkFunc(r.Func, field, r.Message, value) {
+			valid = false
+		}
+	}
+	return valid
+}
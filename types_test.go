@@ -0,0 +1,68 @@
+package datacop_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+)
+
+func TestIntercept(t *testing.T) {
+	calls := 0
+	rule := datacop.Annotated(func(value any, annotate datacop.AnnotateFunc) bool {
+		annotate(datacop.AnnotationAudit, "checked", nil)
+		s, ok := value.(string)
+		return ok && len(s) <= 5
+	}).Named("short", datacop.Params{"max": 5})
+	counted := datacop.Intercept(datacop.Weighted(2, rule), func(call func() bool) bool {
+		calls++
+		return call()
+	})
+
+	assert.Equal(t, "short", counted.Name())
+	assert.Equal(t, datacop.Params{"max": 5}, counted.Params())
+	assert.Zero(t, calls, "reading metadata does not run around")
+
+	v := datacop.New(datacop.WithScoring(1))
+	v.Field("code", "toolong").Validate(counted)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "short", v.OrderedErrors()[0].Code)
+	assert.Len(t, v.AnnotationsOfKind(datacop.AnnotationAudit), 1)
+	assert.Zero(t, v.Score())
+
+	assert.True(t, counted("ok"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIntercept_OverridesResult(t *testing.T) {
+	failing := datacop.Intercept(datacop.Named("anything", nil, func(any) bool { return true }), func(call func() bool) bool {
+		return false
+	})
+
+	v := datacop.New()
+	v.Field("name", "x").Validate(failing, "service unavailable")
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "name", Code: "anything", Message: "service unavailable"},
+	}, v.OrderedErrors())
+}
+
+func TestNamed(t *testing.T) {
+	min3 := datacop.Named("min", datacop.Params{"min": 3}, func(value any) bool { return value.(int) >= 3 })
+	min5 := datacop.Named("min", datacop.Params{"min": 5}, func(value any) bool { return value.(int) >= 5 })
+
+	assert.True(t, min3(4), "named rules can be called directly")
+	assert.False(t, min5(4))
+	assert.Equal(t, datacop.Params{"min": 3}, min3.Params())
+	assert.Equal(t, datacop.Params{"min": 5}, min5.Params(), "each rule keeps its own metadata")
+
+	plain := datacop.ValidationFunc(func(any) bool { return true })
+	name, params, ok := datacop.RuleInfo(plain)
+	assert.Equal(t, "", name)
+	assert.Nil(t, params)
+	assert.False(t, ok)
+
+	renamed := datacop.Named("at_least_three", nil, min3)
+	assert.Equal(t, "at_least_three", renamed.Name())
+	assert.Equal(t, "min", min3.Name(), "naming a copy leaves the original unchanged")
+}
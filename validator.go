@@ -2,29 +2,114 @@ package datacop
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // StandaloneErrorKey is the key used for standalone errors, i.e. global errors
 const StandaloneErrorKey = "__standalone__"
 
+// NoErrorsMessage is returned by Error when a validator has no errors
+const NoErrorsMessage = "no validation errors"
+
+// ErrValidation is the sentinel error that every *Validator unwraps to, allowing callers to
+// detect validation failures with errors.Is without depending on the concrete type.
+var ErrValidation = errors.New("validation failed")
+
+// DefaultErrorCode is the code reported for errors that were recorded without one
+const DefaultErrorCode = "invalid"
+
+// CodeConflict is the code of errors caused by a conflict with the current state of a resource,
+// such as a reused idempotency key or an email address that is already registered. HTTP
+// handlers report them as 409 Conflict rather than as invalid input.
+const CodeConflict = "conflict"
+
 type ValidationError struct {
 	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
+	Params  Params `json:"params,omitempty"` // parameters of the failed rule, such as {"min": 8}
+
+	// Position is where the field appears in the source document, if known; see SetPosition
+	Position *Position `json:"position,omitempty"`
+
+	// Context holds the metadata set with SetContextValue when the error was recorded
+	Context map[string]any `json:"context,omitempty"`
 }
 
 type Validator struct {
 	errors map[string][]ValidationError
+	order  []string // field names in the order they first received an error
+
+	annotations []Annotation
+
+	stable   bool              // render the canonical form in MarshalJSON
+	messages map[string]string // default message templates keyed by rule name
+	labels   map[string]string // human-friendly field names keyed by field
+
+	reporters []Reporter
+	pending   []*asyncCheck // checks started by CheckAsync and not yet collected by Wait
+
+	memo map[memoKey]bool // results of rules by value; nil unless WithIntraRequestMemo is used
+
+	explain   bool // record a trace of evaluations; see WithExplain
+	trace     []ExplainStep
+	sensitive map[string]bool // fields whose values are redacted from the trace
+
+	prefix string // path of the group a nested object is validated in; see Group.Validate
+
+	started time.Time // when the validator was created or last cleared; see Result
+
+	positions map[string]Position // source positions keyed by field; see SetPosition
+	context   map[string]any      // metadata attached to new errors; see SetContextValue
+
+	scoring     bool    // record light weighted rules as suggestions; see WithScoring
+	errorWeight float64 // the weight from which failing rules are errors in scoring mode
+	score       scoreTally
+
+	sink    ErrorSink // receives every error; see WithErrorSink
+	sample  int       // the number of errors kept in memory with a sink, or -1 for all
+	held    int       // errors kept in memory with a sink
+	dropped int       // errors written to the sink but not kept in memory
+	sinkErr error     // the first error returned by the sink
 }
 
-// New creates a new validator instance
+// New creates a new validator instance, configured with the given options
 //
 // Example usage:
-func New() *Validator {
-	return &Validator{
-		errors: make(map[string][]ValidationError),
+// v := datacop.New()
+// v := datacop.New(datacop.WithStableOutput())
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		errors:  make(map[string][]ValidationError),
+		started: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(v)
 	}
+	return v
+}
+
+// child returns a validator for an object nested under prefix, such as "shipping.", with v's
+// configuration and no errors. Its errors, annotations, trace, score, and pending checks are
+// merged back by the caller; errors reach v's error sink when v stores them, so the child has
+// no sink of its own.
+func (v *Validator) child(prefix string) *Validator {
+	c := *v
+	c.errors = make(map[string][]ValidationError)
+	c.order = nil
+	c.annotations = nil
+	c.reporters = nil
+	c.pending = nil
+	c.trace = nil
+	c.prefix = v.prefix + prefix
+	c.score = scoreTally{}
+	c.sink = nil
+	c.held, c.dropped, c.sinkErr = 0, 0, nil
+	return &c
 }
 
 // CheckStandalone performs a standalone validation and adds an error if it fails
@@ -45,30 +130,69 @@ func (v *Validator) Check(valid bool, field, message string) bool {
 	return true
 }
 
-// Error implements the error interface
+// CheckCode performs a field validation and adds an error with the given code if it fails
+func (v *Validator) CheckCode(valid bool, field, code, message string) bool {
+	if !valid {
+		v.AddCodedError(field, code, message)
+		return false
+	}
+	return true
+}
+
+// Error implements the error interface. Standalone errors are listed first, followed by
+// field errors in the order each field first failed validation. A validator without errors,
+// including a nil validator, returns NoErrorsMessage.
 func (v *Validator) Error() string {
+	if !v.HasErrors() {
+		return NoErrorsMessage
+	}
+
 	parts := make([]string, 0, len(v.errors))
 
 	if standalone := v.StandaloneErrors(); len(standalone) > 0 {
 		parts = append(parts, fmt.Sprintf("global: [%s]", strings.Join(standalone, ", ")))
 	}
 
-	for field, errs := range v.errors {
+	for _, field := range v.order {
 		if field == StandaloneErrorKey {
 			continue
 		}
-		if len(errs) > 0 {
+		if errs := v.errors[field]; len(errs) > 0 {
 			messages := make([]string, len(errs))
 			for i, err := range errs {
-				messages[i] = err.Message
+				messages[i] = v.labeled(err).Message
 			}
 			parts = append(parts, fmt.Sprintf("%s: [%s]", field, strings.Join(messages, ", ")))
 		}
 	}
 
+	if v.dropped > 0 {
+		parts = append(parts, fmt.Sprintf("and %d more", v.dropped))
+	}
+
 	return strings.Join(parts, " | ")
 }
 
+// Unwrap returns ErrValidation so that errors.Is(err, ErrValidation) reports true for validators
+func (v *Validator) Unwrap() error {
+	return ErrValidation
+}
+
+// AsValidator finds the first *Validator in err's chain
+//
+// Example usage:
+//
+//	if v, ok := datacop.AsValidator(err); ok {
+//		return v.Errors()
+//	}
+func AsValidator(err error) (*Validator, bool) {
+	var v *Validator
+	if errors.As(err, &v) {
+		return v, true
+	}
+	return nil, false
+}
+
 // AddStandaloneError adds a standalone error
 func (v *Validator) AddStandaloneError(message string) {
 	v.AddError(StandaloneErrorKey, message)
@@ -76,15 +200,98 @@ func (v *Validator) AddStandaloneError(message string) {
 
 // AddError adds an error for a specific field
 func (v *Validator) AddError(field, message string) {
-	// Ensure the current validator is initialized
-	if v.errors == nil {
-		v.errors = make(map[string][]ValidationError)
+	v.AddCodedError(field, "", message)
+}
+
+// AddCodedError adds an error with a machine-readable code, such as "required" or "too_long",
+// for a specific field
+func (v *Validator) AddCodedError(field, code, message string) {
+	v.AddValidationError(ValidationError{Field: field, Code: code, Message: message})
+}
+
+// AddValidationError adds a fully specified error, such as one carrying rule params, under
+// e.Field. A {field} placeholder in the message is replaced with the field's label when the
+// message is read; see SetLabel.
+func (v *Validator) AddValidationError(e ValidationError) {
+	v.addError(e, "")
+}
+
+// addError records e and reports it to the validator's reporters. kind is the type of the
+// validated value, or "" if it is not known.
+func (v *Validator) addError(e ValidationError, kind string) {
+	if e.Context == nil {
+		e.Context = v.context
+	}
+	if e.Position == nil && e.Field != StandaloneErrorKey {
+		if pos, ok := v.PositionOf(v.prefix + e.Field); ok {
+			e.Position = &pos
+		}
 	}
 
-	v.errors[field] = append(v.errors[field], ValidationError{
-		Field:   field,
-		Message: message,
-	})
+	v.store(e)
+	v.report(e, kind)
+}
+
+// checkFunc runs fn against value and, if it fails, records an error for field. Rules created
+// with Named contribute their name as the code and their params, which are also used to
+// interpolate message. An empty message is replaced with the rule's default message. Rules
+// created with Annotated record their annotations for field.
+func (v *Validator) checkFunc(fn ValidationFunc, field, message string, value any) bool {
+	info := lookup(fn)
+	if info == nil {
+		info = &ruleMeta{}
+	}
+
+	var valid, memoized bool
+	key, memoize := v.memoKeyFor(info, value)
+	if memoize {
+		valid, memoized = v.memo[key]
+	}
+
+	switch {
+	case memoized:
+	case info.annotate != nil:
+		valid = info.annotate(value, func(kind, message string, data map[string]any) {
+			v.Annotate(field, kind, message, data)
+		})
+	default:
+		valid = fn(value)
+		if memoize {
+			v.memo[key] = valid
+		}
+	}
+	v.explainStep(ExplainStep{
+		Kind: StepRule, Field: field, Rule: info.name, Params: info.params, Passed: valid, Memoized: memoized,
+	}, value)
+	if info.weighted {
+		v.score.add(info.weight, valid)
+	}
+	if valid {
+		return true
+	}
+
+	e := ValidationError{Field: field, Message: message}
+	if e.Message == "" {
+		e.Message = v.defaultMessage(info.name)
+	}
+	if info.name != "" {
+		e.Code, e.Params = info.name, info.params
+		e.Message = Interpolate(e.Message, info.params)
+	}
+	if v.scoring && info.weighted && info.weight < v.errorWeight {
+		v.suggest(e, info.weight)
+		return false
+	}
+	v.addError(e, kindOf(value))
+	return false
+}
+
+// firstMessage returns the optional message argument of Validate, or "" if it was omitted
+func firstMessage(message []string) string {
+	if len(message) == 0 {
+		return ""
+	}
+	return message[0]
 }
 
 // HasStandaloneErrors returns true if there are any standalone errors
@@ -92,31 +299,66 @@ func (v *Validator) HasStandaloneErrors() bool {
 	return v.HasErrorFor(StandaloneErrorKey)
 }
 
-// HasErrors returns true if there are any validation errors
+// HasErrors returns true if there are any validation errors, including errors written to an
+// error sink and not kept in memory. It is safe to call on a nil validator.
 func (v *Validator) HasErrors() bool {
-	return len(v.errors) > 0
+	if v == nil {
+		return false
+	}
+	v.Wait()
+	return len(v.errors) > 0 || v.dropped > 0
+}
+
+// ErrOrNil returns the validator as an error if it has errors, and nil otherwise.
+// Return it instead of the validator itself: a nil *Validator stored in an error
+// interface is not a nil error.
+//
+// Example usage:
+//
+//	func (f Form) Validate() error {
+//		v := datacop.New()
+//		v.Check(is.Required(f.Name), "name", "name is required")
+//		return v.ErrOrNil()
+//	}
+func (v *Validator) ErrOrNil() error {
+	if !v.HasErrors() {
+		return nil
+	}
+	return v
 }
 
 // ErrorFor returns the string error message for a field
 func (v *Validator) ErrorFor(field string) string {
+	v.Wait()
 	if errs, exists := v.errors[field]; exists && len(errs) > 0 {
 		messages := make([]string, len(errs))
 		for i, err := range errs {
-			messages[i] = err.Message
+			messages[i] = v.labeled(err).Message
 		}
 		return strings.Join(messages, ", ")
 	}
 	return ""
 }
 
+// FirstErrorFor returns the first error message recorded for a field, or an empty string
+func (v *Validator) FirstErrorFor(field string) string {
+	v.Wait()
+	if errs := v.errors[field]; len(errs) > 0 {
+		return v.labeled(errs[0]).Message
+	}
+	return ""
+}
+
 // HasErrorFor returns true if the field has any errors for a field
 func (v *Validator) HasErrorFor(field string) bool {
+	v.Wait()
 	errs, exists := v.errors[field]
 	return exists && len(errs) > 0
 }
 
 // StandaloneErrors returns all standalone error messages
 func (v *Validator) StandaloneErrors() []string {
+	v.Wait()
 	if errs, exists := v.errors[StandaloneErrorKey]; exists {
 		messages := make([]string, len(errs))
 		for i, err := range errs {
@@ -127,8 +369,10 @@ func (v *Validator) StandaloneErrors() []string {
 	return nil
 }
 
-// Errors returns a map of field names and their string error messages
+// Errors returns a map of field names and their string error messages.
+// Use OrderedErrors when iteration order matters.
 func (v *Validator) Errors() map[string]string {
+	v.Wait()
 	fields := make(map[string]string)
 	for field, errs := range v.errors {
 		if len(errs) > 0 {
@@ -138,30 +382,140 @@ func (v *Validator) Errors() map[string]string {
 	return fields
 }
 
+// ErrorsFor returns the errors of the fields in a group, keyed by their full field names. A field
+// is in the group if its name is prefix or a path below it, such as "address.zip" or
+// "items[2].qty" for the prefixes "address" and "items".
+//
+// Example usage:
+// v.ErrorsFor("address") // returns map[address.street:street is required address.zip:zip is required]
+func (v *Validator) ErrorsFor(prefix string) map[string]string {
+	v.Wait()
+	fields := make(map[string]string)
+	for field, errs := range v.errors {
+		if len(errs) > 0 && inGroup(field, prefix) {
+			fields[field] = v.ErrorFor(field)
+		}
+	}
+	return fields
+}
+
+// HasErrorsInGroup returns true if any field in a group has errors; see ErrorsFor
+func (v *Validator) HasErrorsInGroup(prefix string) bool {
+	v.Wait()
+	for field, errs := range v.errors {
+		if len(errs) > 0 && inGroup(field, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// inGroup reports whether field is prefix or a path below it
+func inGroup(field, prefix string) bool {
+	if !strings.HasPrefix(field, prefix) {
+		return false
+	}
+	rest := field[len(prefix):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
+// AllErrors returns a map of field names to each of their error messages, in the order
+// they were recorded. Unlike Errors, the messages are not joined into a single string.
+func (v *Validator) AllErrors() map[string][]string {
+	v.Wait()
+	fields := make(map[string][]string, len(v.errors))
+	for field, errs := range v.errors {
+		if len(errs) == 0 {
+			continue
+		}
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = v.labeled(err).Message
+		}
+		fields[field] = messages
+	}
+	return fields
+}
+
+// OrderedErrors returns all validation errors as a slice, grouped by field in the order each
+// field first failed validation. Unlike the map-based accessors, the result is deterministic.
+func (v *Validator) OrderedErrors() []ValidationError {
+	errs := v.UnlabeledErrors()
+	for i, e := range errs {
+		errs[i] = v.labeled(e)
+	}
+	return errs
+}
+
+// FailedRules returns the names of the named rules that failed for each field, in the order they
+// failed, for analytics on which rules fail most or for mapping rule names to client-side
+// messages. Errors without a code, such as those recorded by Check, are not included.
+//
+// Example usage:
+// v.FailedRules() // returns map[password:[min_length strong_password]]
+func (v *Validator) FailedRules() map[string][]string {
+	v.Wait()
+	rules := make(map[string][]string)
+	for _, field := range v.order {
+		for _, e := range v.errors[field] {
+			if e.Code != "" {
+				rules[field] = append(rules[field], e.Code)
+			}
+		}
+	}
+	return rules
+}
+
 // ValidationErrors returns all validation errors as a map of field names to their errors
 func (v *Validator) ValidationErrors() map[string][]ValidationError {
-	return v.errors
+	v.Wait()
+	fields := make(map[string][]ValidationError, len(v.errors))
+	for field, errs := range v.errors {
+		labeled := make([]ValidationError, len(errs))
+		for i, e := range errs {
+			labeled[i] = v.labeled(e)
+		}
+		fields[field] = labeled
+	}
+	return fields
 }
 
-// Merge combines another validator's errors into this one. The other validator is not modified.
+// Merge combines another validator's errors, annotations, and score into this one. The other
+// validator is not modified. With an error sink, the merged errors are written to it; errors
+// the other validator did not keep in memory are only counted. Messages of fields labeled in the
+// other validator keep its labels.
 func (v *Validator) Merge(other *Validator) {
-	// Ensure the current validator is initialized
-	if v.errors == nil {
-		v.errors = make(map[string][]ValidationError)
-	}
-
-	for field, errs := range other.errors {
-		v.errors[field] = append(v.errors[field], errs...)
+	for _, field := range other.order {
+		for _, e := range other.errors[field] {
+			if _, ok := other.labels[other.prefix+field]; ok {
+				e = other.labeled(e)
+			}
+			v.store(e)
+		}
 	}
+	v.dropped += other.dropped
+	v.annotations = append(v.annotations, other.annotations...)
+	v.score.merge(other.score)
 }
 
-// MarshalJSON implements json.Marshaler for the Validator type
+// MarshalJSON implements json.Marshaler for the Validator type. With WithStableOutput, each
+// field's messages are taken from the canonical form rather than in the order they were recorded.
 func (v *Validator) MarshalJSON() ([]byte, error) {
+	v.Wait()
 	fields := make(map[string]string)
 
-	for field, errs := range v.errors {
-		if len(errs) > 0 {
-			fields[field] = v.ErrorFor(field)
+	if v.stable {
+		for _, e := range v.Canonical() {
+			if fields[e.Field] != "" {
+				fields[e.Field] += ", "
+			}
+			fields[e.Field] += e.Message
+		}
+	} else {
+		for field, errs := range v.errors {
+			if len(errs) > 0 {
+				fields[field] = v.ErrorFor(field)
+			}
 		}
 	}
 
@@ -172,9 +526,17 @@ func (v *Validator) MarshalJSON() ([]byte, error) {
 	})
 }
 
-// Clear removes all errors from the validator instance
+// Clear removes all errors, annotations, explain steps, and positions from the validator instance
+// and restarts the time reported as Result.StartedAt
 func (v *Validator) Clear() {
 	v.errors = make(map[string][]ValidationError)
+	v.order = nil
+	v.annotations = nil
+	v.trace = nil
+	v.started = time.Now()
+	v.positions = nil
+	v.score = scoreTally{}
+	v.held, v.dropped, v.sinkErr = 0, 0, nil
 }
 
 // FieldValidation enables chain validation for a specific field
@@ -214,10 +576,54 @@ func (v *Validator) Field(name string, value any) *FieldValidation {
 //	Check(Required(username), "username is required").
 //	Check(MinLength(3)(username), "username must be at least 3 characters")
 func (f *FieldValidation) Check(valid bool, message string) *FieldValidation {
-	f.v.Check(valid, f.field, message)
+	f.v.explainStep(ExplainStep{Kind: StepCheck, Field: f.field, Passed: valid}, f.value)
+	if !valid {
+		f.v.addError(ValidationError{Field: f.field, Message: message}, kindOf(f.value))
+	}
+	return f
+}
+
+// Transform normalizes the field's value before it is validated, applying each function in
+// order. Transforms apply to string values only; other values are left unchanged. Later calls to
+// Validate and Value see the transformed value.
+//
+// Example usage:
+//
+//	email := v.Field("email", raw).
+//		Transform(strings.TrimSpace, strings.ToLower).
+//		Validate(is.Required, "email is required").
+//		Validate(is.Email, "email is invalid").
+//		Value().(string)
+func (f *FieldValidation) Transform(fns ...func(string) string) *FieldValidation {
+	str, ok := f.value.(string)
+	if !ok {
+		return f
+	}
+	for _, fn := range fns {
+		str = fn(str)
+	}
+	f.value = str
 	return f
 }
 
+// Validate runs a validation function against the field's current value and adds an error with
+// message if it fails. For rules that carry params, such as is.MinLength, the message may use
+// them as placeholders. The message may be omitted to use the rule's default message; see
+// SetDefaultMessage.
+//
+// Example usage:
+// v.Field("age", age).Validate(is.Min(18), "must be at least {min}")
+// v.Field("name", name).Validate(is.MinLength(3)) // "must be at least 3 characters"
+func (f *FieldValidation) Validate(fn ValidationFunc, message ...string) *FieldValidation {
+	f.v.checkFunc(fn, f.field, firstMessage(message), f.value)
+	return f
+}
+
+// Value returns the field's current value, after any transforms
+func (f *FieldValidation) Value() any {
+	return f.value
+}
+
 // Group represents a group of related validations
 type Group struct {
 	name string
@@ -227,6 +633,7 @@ type Group struct {
 // When represents a conditional validation
 type When struct {
 	condition bool
+	pending   []func(value any) bool // lazy conditions not yet evaluated
 	field     string
 	value     any
 	v         *Validator
@@ -237,32 +644,194 @@ func (v *Validator) Group(name string) *Group {
 	return &Group{name: name, v: v}
 }
 
+// GroupFunc runs fn with a validation group, keeping the validations of a nested object together
+//
+// Example usage:
+//
+//	v.GroupFunc("address", func(g *datacop.Group) {
+//		g.Field("street", street).Validate(is.Required, "is required")
+//		g.Field("zip", zip).Validate(is.MinLength(5), "is too short")
+//	})
+func (v *Validator) GroupFunc(name string, fn func(g *Group)) {
+	fn(v.Group(name))
+}
+
 // Field starts a validation chain for the given field in the group
 func (g *Group) Field(name string, value any) *FieldValidation {
-	fullName := g.name + "." + name
-	return g.v.Field(fullName, value)
+	return g.v.Field(g.Path(name), value)
+}
+
+// Path returns the full name of a field in the group, such as "user.address.zip"
+func (g *Group) Path(name string) string {
+	return g.name + "." + name
+}
+
+// Group starts a group nested in g
+//
+// Example usage:
+// v.Group("user").Group("address").Field("zip", zip) // validates "user.address.zip"
+func (g *Group) Group(name string) *Group {
+	return &Group{name: g.Path(name), v: g.v}
+}
+
+// Index starts a group for the element at index i of a slice held by g
+//
+// Example usage:
+//
+//	items := v.Group("items")
+//	for i, item := range order.Items {
+//		items.Index(i).Field("qty", item.Qty).Validate(is.Min(1), "must be at least {min}") // "items[2].qty"
+//	}
+func (g *Group) Index(i int) *Group {
+	return &Group{name: g.name + "[" + strconv.Itoa(i) + "]", v: g.v}
+}
+
+// Errors returns the errors of the fields in the group; see Validator.ErrorsFor
+func (g *Group) Errors() map[string]string {
+	return g.v.ErrorsFor(g.name)
+}
+
+// HasErrors returns true if any field in the group has errors
+func (g *Group) HasErrors() bool {
+	return g.v.HasErrorsInGroup(g.name)
+}
+
+// GroupFunc runs fn with a group nested in g
+func (g *Group) GroupFunc(name string, fn func(g *Group)) {
+	fn(g.Group(name))
 }
 
 // When starts a conditional validation
 func (f *FieldValidation) When(condition bool) *When {
+	f.v.explainStep(ExplainStep{Kind: StepCondition, Field: f.field, Passed: condition}, f.value)
+	w := f.unconditional()
+	w.condition = condition
+	return w
+}
+
+// unconditional returns a conditional validation without conditions yet
+func (f *FieldValidation) unconditional() *When {
 	return &When{
-		condition: condition,
+		condition: true,
 		field:     f.field,
 		value:     f.value,
 		v:         f.v,
 	}
 }
 
+// Unless starts a conditional validation that runs when condition is false, the inverse of When
+//
+// Example usage:
+// v.Field("phone", phone).Unless(hasEmail).Validate(is.Required, "is required without an email")
+func (f *FieldValidation) Unless(condition bool) *When {
+	return f.When(!condition)
+}
+
+// WhenFunc starts a conditional validation whose condition is evaluated lazily, when the first
+// check that depends on it runs, and at most once. Use it for conditions that are expensive or
+// that would make the chain hard to read if computed up front.
+//
+// Example usage:
+// v.Field("vat_id", vatID).WhenFunc(order.ShipsAbroad).Validate(is.Required, "is required for exports")
+func (f *FieldValidation) WhenFunc(condition func() bool) *When {
+	return f.unconditional().WhenFunc(condition)
+}
+
+// WhenValue starts a conditional validation whose condition depends on the field's value. Like
+// WhenFunc, the condition is evaluated lazily and at most once.
+//
+// Example usage:
+//
+//	v.Field("website", website).
+//		WhenValue(func(value any) bool { return value != "" }).
+//		Validate(is.StartsWith("https://"), "must be an https URL")
+func (f *FieldValidation) WhenValue(condition func(value any) bool) *When {
+	return f.unconditional().WhenValue(condition)
+}
+
+// holds reports whether every condition of the chain holds, evaluating pending lazy conditions
+// in order until one fails
+func (w *When) holds() bool {
+	for w.condition && len(w.pending) > 0 {
+		fn := w.pending[0]
+		w.pending = w.pending[1:]
+		w.condition = fn(w.value)
+		w.v.explainStep(ExplainStep{Kind: StepCondition, Field: w.field, Passed: w.condition}, w.value)
+	}
+	if !w.condition {
+		w.pending = nil
+	}
+	return w.condition
+}
+
 // Check performs a validation in the chain
 func (w *When) Check(valid bool, message string) *When {
-	if w.condition {
-		w.v.Check(valid, w.field, message)
+	if valid {
+		// A passing check does not need the condition, so lazy conditions are left unevaluated
+		w.v.explainStep(ExplainStep{Kind: StepCheck, Field: w.field, Passed: true}, w.value)
+		return w
+	}
+	holds := w.holds()
+	w.v.explainStep(ExplainStep{Kind: StepCheck, Field: w.field, Skipped: !holds}, w.value)
+	if holds {
+		w.v.addError(ValidationError{Field: w.field, Message: message}, kindOf(w.value))
+	}
+	return w
+}
+
+// Validate runs a validation function against the field's value if the condition holds. As with
+// FieldValidation.Validate, the message may be omitted.
+func (w *When) Validate(fn ValidationFunc, message ...string) *When {
+	if w.holds() {
+		w.v.checkFunc(fn, w.field, firstMessage(message), w.value)
+	} else if w.v.explain {
+		name, params, _ := RuleInfo(fn)
+		w.v.explainStep(ExplainStep{
+			Kind: StepRule, Field: w.field, Rule: name, Params: params, Skipped: true,
+		}, w.value)
 	}
 	return w
 }
 
 // When performs a validation in the chain
 func (w *When) When(condition bool) *When {
+	w.v.explainStep(ExplainStep{Kind: StepCondition, Field: w.field, Passed: condition}, w.value)
 	w.condition = w.condition && condition
 	return w
 }
+
+// Unless adds the inverse of condition to the chain, combined with the earlier conditions with
+// AND logic
+func (w *When) Unless(condition bool) *When {
+	return w.When(!condition)
+}
+
+// Otherwise starts a branch of checks that run when the conditions of w do not all hold, so one
+// chain can express if/else validation. Lazy conditions of w are evaluated only when a check in
+// the branch needs them.
+//
+// Example usage:
+//
+//	v.Field("tax_id", taxID).
+//		When(country == "US").
+//		Validate(is.Match(`^\d{2}-\d{7}$`), "must be an EIN").
+//		Otherwise().
+//		Validate(is.MinLength(5), "is too short")
+func (w *When) Otherwise() *When {
+	return w.v.Field(w.field, w.value).WhenFunc(func() bool { return !w.holds() })
+}
+
+// WhenFunc adds a lazily evaluated condition to the chain, combined with the earlier conditions
+// with AND logic. It is not evaluated if an earlier condition fails.
+func (w *When) WhenFunc(condition func() bool) *When {
+	return w.WhenValue(func(any) bool { return condition() })
+}
+
+// WhenValue adds a lazily evaluated condition on the field's value to the chain, combined with
+// the earlier conditions with AND logic
+func (w *When) WhenValue(condition func(value any) bool) *When {
+	if w.condition {
+		w.pending = append(w.pending, condition)
+	}
+	return w
+}
@@ -2,6 +2,9 @@ package datacop_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -120,7 +123,7 @@ func TestValidator_Clear(t *testing.T) {
 	// Clear errors
 	v.Clear()
 	assert.False(t, v.HasErrors())
-	assert.Empty(t, v.Error())
+	assert.Equal(t, datacop.NoErrorsMessage, v.Error())
 }
 
 func TestValidator_JSONMarshaling(t *testing.T) {
@@ -299,6 +302,206 @@ func TestFieldValidation(t *testing.T) {
 	}
 }
 
+func TestFieldValidation_Transform(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		transforms []func(string) string
+		wantValue  any
+		wantError  string
+	}{
+		{
+			name:       "normalized valid value",
+			value:      "  Jane@Example.COM ",
+			transforms: []func(string) string{strings.TrimSpace, strings.ToLower},
+			wantValue:  "jane@example.com",
+		},
+		{
+			name:       "validation sees transformed value",
+			value:      "   ",
+			transforms: []func(string) string{strings.TrimSpace},
+			wantValue:  "",
+			wantError:  "email is required, email is invalid",
+		},
+		{
+			name:      "no transforms",
+			value:     "jane@example.com",
+			wantValue: "jane@example.com",
+		},
+		{
+			name:       "non-string values are unchanged",
+			value:      42,
+			transforms: []func(string) string{strings.ToUpper},
+			wantValue:  42,
+			wantError:  "email is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			f := v.Field("email", tt.value).
+				Transform(tt.transforms...).
+				Validate(is.Required, "email is required").
+				Validate(is.Email, "email is invalid")
+
+			assert.Equal(t, tt.wantValue, f.Value())
+			assert.Equal(t, tt.wantError, v.ErrorFor("email"))
+		})
+	}
+}
+
+func TestWhenValidation_Validate(t *testing.T) {
+	v := datacop.New()
+	v.Field("phone", "").When(false).Validate(is.Required, "phone is required")
+	assert.False(t, v.HasErrors())
+
+	v.Field("phone", "").When(true).Validate(is.Required, "phone is required")
+	assert.Equal(t, "phone is required", v.ErrorFor("phone"))
+}
+
+func TestWhenValidation_Lazy(t *testing.T) {
+	calls := 0
+	expensive := func(result bool) func() bool {
+		return func() bool {
+			calls++
+			return result
+		}
+	}
+
+	tests := []struct {
+		name      string
+		chain     func(f *datacop.FieldValidation) *datacop.When
+		wantError bool
+		wantCalls int
+	}{
+		{
+			name:      "condition holds",
+			chain:     func(f *datacop.FieldValidation) *datacop.When { return f.WhenFunc(expensive(true)) },
+			wantError: true,
+			wantCalls: 1,
+		},
+		{
+			name:      "condition fails",
+			chain:     func(f *datacop.FieldValidation) *datacop.When { return f.WhenFunc(expensive(false)) },
+			wantCalls: 1,
+		},
+		{
+			name: "not evaluated after a false condition",
+			chain: func(f *datacop.FieldValidation) *datacop.When {
+				return f.When(false).WhenFunc(expensive(true))
+			},
+		},
+		{
+			name: "short-circuits in order",
+			chain: func(f *datacop.FieldValidation) *datacop.When {
+				return f.WhenFunc(expensive(false)).WhenFunc(expensive(true))
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "combined with When",
+			chain: func(f *datacop.FieldValidation) *datacop.When {
+				return f.WhenFunc(expensive(true)).When(false)
+			},
+		},
+		{
+			name: "depends on the value",
+			chain: func(f *datacop.FieldValidation) *datacop.When {
+				return f.WhenValue(func(value any) bool { return value == "abc" })
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			v := datacop.New()
+			w := tt.chain(v.Field("code", "abc"))
+			assert.Zero(t, calls, "conditions are not evaluated until a check runs")
+
+			w.Validate(is.MinLength(5), "is too short").Check(false, "is wrong")
+			assert.Equal(t, tt.wantError, v.HasErrorFor("code"))
+			if tt.wantError {
+				assert.Equal(t, []string{"is too short", "is wrong"}, v.AllErrors()["code"])
+			}
+			assert.Equal(t, tt.wantCalls, calls, "conditions are evaluated at most once")
+		})
+	}
+}
+
+func TestWhenValidation_LazyCheckPasses(t *testing.T) {
+	called := false
+	v := datacop.New()
+	v.Field("name", "x").WhenFunc(func() bool { called = true; return true }).Check(true, "unused")
+	assert.False(t, called, "a passing Check does not need the condition")
+}
+
+func TestWhenValidation_UnlessOtherwise(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     func(f *datacop.FieldValidation)
+		wantError []string
+	}{
+		{
+			name:      "unless false",
+			chain:     func(f *datacop.FieldValidation) { f.Unless(false).Check(false, "unless") },
+			wantError: []string{"unless"},
+		},
+		{
+			name:  "unless true",
+			chain: func(f *datacop.FieldValidation) { f.Unless(true).Check(false, "unless") },
+		},
+		{
+			name:      "chained unless",
+			chain:     func(f *datacop.FieldValidation) { f.When(true).Unless(false).Check(false, "both") },
+			wantError: []string{"both"},
+		},
+		{
+			name: "when branch",
+			chain: func(f *datacop.FieldValidation) {
+				f.When(true).Check(false, "when").Otherwise().Check(false, "otherwise")
+			},
+			wantError: []string{"when"},
+		},
+		{
+			name: "otherwise branch",
+			chain: func(f *datacop.FieldValidation) {
+				f.When(false).Check(false, "when").Otherwise().Check(false, "otherwise")
+			},
+			wantError: []string{"otherwise"},
+		},
+		{
+			name: "otherwise of combined conditions",
+			chain: func(f *datacop.FieldValidation) {
+				f.When(true).When(false).Check(false, "when").Otherwise().Validate(is.MinLength(5), "too short")
+			},
+			wantError: []string{"too short"},
+		},
+		{
+			name: "otherwise of lazy condition",
+			chain: func(f *datacop.FieldValidation) {
+				f.WhenValue(func(value any) bool { return value == "abc" }).Otherwise().Check(false, "otherwise")
+			},
+		},
+		{
+			name: "condition within otherwise",
+			chain: func(f *datacop.FieldValidation) {
+				f.When(false).Otherwise().When(false).Check(false, "nested")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := datacop.New()
+			tt.chain(v.Field("code", "abc"))
+			assert.Equal(t, tt.wantError, v.AllErrors()["code"])
+		})
+	}
+}
+
 func TestGroupValidation(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -353,6 +556,64 @@ func TestGroupValidation(t *testing.T) {
 	}
 }
 
+func TestGroup_Nesting(t *testing.T) {
+	v := datacop.New()
+
+	v.Group("user").Group("address").Field("zip", "").Check(false, "is required")
+
+	items := v.Group("items")
+	items.Index(2).Field("qty", 0).Validate(is.Min(1), "must be at least {min}")
+	items.Index(0).Group("options").Index(1).Field("name", "").Check(false, "is required")
+
+	v.GroupFunc("billing", func(g *datacop.Group) {
+		g.Field("name", "").Check(false, "is required")
+		g.GroupFunc("card", func(g *datacop.Group) {
+			g.Field("number", "").Check(false, "is required")
+		})
+	})
+
+	assert.Equal(t, map[string]string{
+		"user.address.zip":         "is required",
+		"items[2].qty":             "must be at least 1",
+		"items[0].options[1].name": "is required",
+		"billing.name":             "is required",
+		"billing.card.number":      "is required",
+	}, v.Errors())
+
+	assert.Equal(t, "user.address.zip", v.Group("user").Group("address").Path("zip"))
+	assert.Equal(t, "items[3].qty", v.Group("items").Index(3).Path("qty"))
+}
+
+func TestValidator_ErrorsFor(t *testing.T) {
+	v := datacop.New()
+	v.AddError("address.street", "street is required")
+	v.AddError("address.zip", "zip is required")
+	v.AddError("address", "address is incomplete")
+	v.AddError("addresses", "too many addresses")
+	v.AddError("items[2].qty", "must be at least 1")
+	v.AddError("name", "name is required")
+	v.AddStandaloneError("form has expired")
+
+	assert.Equal(t, map[string]string{
+		"address":        "address is incomplete",
+		"address.street": "street is required",
+		"address.zip":    "zip is required",
+	}, v.ErrorsFor("address"))
+	assert.Equal(t, map[string]string{"items[2].qty": "must be at least 1"}, v.ErrorsFor("items"))
+	assert.Equal(t, map[string]string{"items[2].qty": "must be at least 1"}, v.ErrorsFor("items[2]"))
+	assert.Empty(t, v.ErrorsFor("billing"))
+
+	assert.True(t, v.HasErrorsInGroup("address"))
+	assert.True(t, v.HasErrorsInGroup("items"))
+	assert.False(t, v.HasErrorsInGroup("items[1]"))
+	assert.False(t, v.HasErrorsInGroup("addr"))
+	assert.False(t, v.HasErrorsInGroup("billing"))
+
+	assert.True(t, v.Group("address").HasErrors())
+	assert.Len(t, v.Group("address").Errors(), 3)
+	assert.False(t, v.Group("billing").HasErrors())
+}
+
 func TestWhenValidation(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -448,3 +709,214 @@ func TestWhenValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleSet_Apply(t *testing.T) {
+	rules := datacop.RuleSet{
+		{Func: is.Required, Message: "name is required"},
+		{Func: is.MinLength(3), Message: "name is too short"},
+	}
+
+	v := datacop.New()
+	assert.True(t, rules.Apply(v, "name", "alice"))
+	assert.False(t, v.HasErrors())
+
+	assert.False(t, rules.Apply(v, "name", ""))
+	assert.Equal(t, "name is required, name is too short", v.ErrorFor("name"))
+}
+
+func TestNamed_RuleInfo(t *testing.T) {
+	fn := datacop.Named("multiple_of", datacop.Params{"n": 3}, func(value any) bool {
+		v, ok := value.(int)
+		return ok && v%3 == 0
+	})
+
+	assert.True(t, fn(9))
+	assert.False(t, fn(10))
+
+	name, params, ok := datacop.RuleInfo(fn)
+	assert.True(t, ok)
+	assert.Equal(t, "multiple_of", name)
+	assert.Equal(t, datacop.Params{"n": 3}, params)
+
+	name, _, ok = datacop.RuleInfo(is.Required)
+	assert.True(t, ok)
+	assert.Equal(t, "required", name)
+
+	// Plain functions are never called to read metadata, so one that panics is safe
+	_, _, ok = datacop.RuleInfo(datacop.ValidationFunc(func(value any) bool { return value.(string) != "" }))
+	assert.False(t, ok)
+}
+
+func TestValidator_PlainFunctions(t *testing.T) {
+	calls := 0
+	counted := datacop.ValidationFunc(func(value any) bool {
+		calls++
+		return false
+	})
+	v := datacop.New()
+	v.Field("name", "").Validate(counted, "is invalid")
+	assert.Equal(t, 1, calls)
+
+	// A plain function calling named rules does not take on their code or params
+	combined := datacop.ValidationFunc(func(value any) bool {
+		return is.MinLength(3)(value) && is.EmailWith(is.EmailOptions{})(value)
+	})
+	v.Field("email", "alice").Validate(combined, "is not a valid email")
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 2)
+	assert.Empty(t, errs[1].Code)
+	assert.Nil(t, errs[1].Params)
+}
+
+func TestValidator_RuleParams(t *testing.T) {
+	v := datacop.New()
+	v.Field("password", "abc").Validate(is.MinLength(8), "{field} must be at least {min} characters")
+	v.Field("plan", "gold").Validate(is.In("free", "pro"), "must be one of {allowed}")
+	v.Field("name", "").Validate(is.Required, "is required")
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 3)
+
+	assert.Equal(t, "min_length", errs[0].Code)
+	assert.Equal(t, datacop.Params{"min": 8}, errs[0].Params)
+	assert.Equal(t, "password must be at least 8 characters", errs[0].Message)
+
+	assert.Equal(t, "in", errs[1].Code)
+	assert.Equal(t, datacop.Params{"allowed": []string{"free", "pro"}}, errs[1].Params)
+	assert.Equal(t, "must be one of free, pro", errs[1].Message)
+
+	assert.Equal(t, "required", errs[2].Code)
+	assert.Nil(t, errs[2].Params)
+
+	detailed := v.DetailedErrors()
+	require.Len(t, detailed, 3)
+	assert.Equal(t, datacop.Params{"min": 8}, detailed[0].Params)
+
+	data, err := v.MarshalJSONDetailed()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"params":{"min":8}`)
+}
+
+func TestRuleSet_Apply_Params(t *testing.T) {
+	rules := datacop.RuleSet{
+		{Func: is.Required, Message: "name is required"},
+		{Func: is.MaxLength(3), Message: "name must be at most {max} characters"},
+	}
+
+	v := datacop.New()
+	assert.False(t, rules.Apply(v, "name", "alice"))
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "max_length", errs[0].Code)
+	assert.Equal(t, datacop.Params{"max": 3}, errs[0].Params)
+	assert.Equal(t, "name must be at most 3 characters", errs[0].Message)
+}
+
+func TestValidator_DeterministicOrder(t *testing.T) {
+	v := datacop.New()
+	v.Check(false, "zeta", "zeta error")
+	v.Check(false, "alpha", "alpha error")
+	v.CheckStandalone(false, "global error")
+	v.Check(false, "zeta", "another zeta error")
+
+	for range 10 {
+		assert.Equal(t, "global: [global error] | zeta: [zeta error, another zeta error] | alpha: [alpha error]", v.Error())
+	}
+
+	assert.Equal(t, []datacop.ValidationError{
+		{Field: "zeta", Message: "zeta error"},
+		{Field: "zeta", Message: "another zeta error"},
+		{Field: "alpha", Message: "alpha error"},
+		{Field: datacop.StandaloneErrorKey, Message: "global error"},
+	}, v.OrderedErrors())
+
+	other := datacop.New()
+	other.Check(false, "beta", "beta error")
+	other.Check(false, "alpha", "second alpha error")
+	v.Merge(other)
+	assert.Equal(t, "global: [global error] | zeta: [zeta error, another zeta error] | alpha: [alpha error, second alpha error] | beta: [beta error]", v.Error())
+
+	v.Clear()
+	assert.Empty(t, v.OrderedErrors())
+}
+
+func TestValidator_ErrorsIsAndAs(t *testing.T) {
+	v := datacop.New()
+	v.Check(false, "email", "invalid email")
+
+	wrapped := fmt.Errorf("creating user: %w", v)
+	assert.ErrorIs(t, wrapped, datacop.ErrValidation)
+
+	found, ok := datacop.AsValidator(wrapped)
+	require.True(t, ok)
+	assert.Same(t, v, found)
+	assert.Equal(t, "invalid email", found.ErrorFor("email"))
+
+	other := errors.New("connection refused")
+	assert.NotErrorIs(t, other, datacop.ErrValidation)
+	found, ok = datacop.AsValidator(other)
+	assert.False(t, ok)
+	assert.Nil(t, found)
+}
+
+func TestValidator_FirstErrorForAndAllErrors(t *testing.T) {
+	v := datacop.New()
+	v.Check(false, "name", "must be Last, First")
+	v.Check(false, "name", "too long")
+	v.CheckStandalone(false, "global")
+
+	assert.Equal(t, "must be Last, First", v.FirstErrorFor("name"))
+	assert.Empty(t, v.FirstErrorFor("missing"))
+
+	assert.Equal(t, map[string][]string{
+		"name":                     {"must be Last, First", "too long"},
+		datacop.StandaloneErrorKey: {"global"},
+	}, v.AllErrors())
+
+	v.Clear()
+	assert.Empty(t, v.AllErrors())
+}
+
+func TestValidator_ErrOrNil(t *testing.T) {
+	v := datacop.New()
+	assert.NoError(t, v.ErrOrNil())
+	assert.Equal(t, datacop.NoErrorsMessage, v.Error())
+
+	v.Check(false, "name", "name is required")
+	err := v.ErrOrNil()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, datacop.ErrValidation)
+	assert.Equal(t, "name: [name is required]", err.Error())
+
+	var nilValidator *datacop.Validator
+	assert.NoError(t, nilValidator.ErrOrNil())
+	assert.False(t, nilValidator.HasErrors())
+	assert.Equal(t, datacop.NoErrorsMessage, nilValidator.Error())
+}
+
+func TestValidator_FailedRules(t *testing.T) {
+	v := datacop.New()
+	v.Field("password", "abc").
+		Validate(is.MinLength(8)).
+		Validate(is.StrongPassword(is.DefaultPasswordPolicy))
+	v.Field("name", "").Validate(is.Required, "is required")
+	v.Field("email", "jane").Check(false, "is invalid").Validate(is.EndsWith("@example.com"))
+
+	assert.Equal(t, map[string][]string{
+		"password": {"min_length", "strong_password"},
+		"name":     {"required"},
+		"email":    {"ends_with"},
+	}, v.FailedRules())
+	assert.Empty(t, datacop.New().FailedRules())
+}
+
+func TestNamedRule_NameAndParams(t *testing.T) {
+	named := datacop.Named("multiple_of", datacop.Params{"n": 3}, func(value any) bool { return true })
+	assert.Equal(t, "multiple_of", named.Name())
+	assert.Equal(t, datacop.Params{"n": 3}, named.Params())
+
+	plain := datacop.Weighted(1, datacop.ValidationFunc(func(value any) bool { return true }))
+	assert.Empty(t, plain.Name())
+	assert.Nil(t, plain.Params())
+}
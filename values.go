@@ -0,0 +1,129 @@
+package datacop
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/patrickward/datacop/internal/numeric"
+)
+
+// Value returns the value of a field chain as a T, after any transforms. It returns the zero
+// value and false if the field has errors or its value is not a T, so a validated chain can
+// double as a binding step.
+//
+// Example usage:
+//
+//	f := v.Field("email", input).Transform(strings.TrimSpace).Validate(is.Email, "is not valid")
+//	email, ok := datacop.Value[string](f)
+func Value[T any](f *FieldValidation) (T, bool) {
+	var zero T
+	if f.Failed() {
+		return zero, false
+	}
+	value, ok := f.value.(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+// Failed reports whether the field has any errors
+func (f *FieldValidation) Failed() bool {
+	return f.v.HasErrorFor(f.field)
+}
+
+// String returns the field's value as a string, or "" if the field has errors or its value is
+// not a string
+func (f *FieldValidation) String() string {
+	s, _ := Value[string](f)
+	return s
+}
+
+// Int returns the field's value as an int, or 0 if the field has errors or its value cannot be
+// converted. Integers of any size that fit in an int are converted, as are whole floats and
+// strings holding an integer.
+//
+// Example usage:
+// v.Field("age", r.FormValue("age")).Validate(is.IntString, "must be a number").Int() // returns 42 for "42"
+func (f *FieldValidation) Int() int {
+	if f.Failed() {
+		return 0
+	}
+	n, _ := toInt(f.value)
+	return n
+}
+
+// Float returns the field's value as a float64, or 0 if the field has errors or its value cannot
+// be converted. Numbers of any type and strings holding a number are converted.
+func (f *FieldValidation) Float() float64 {
+	if f.Failed() {
+		return 0
+	}
+	n, _ := numeric.Float(f.value)
+	return n
+}
+
+// Bool returns the field's value as a bool, or false if the field has errors or its value cannot
+// be converted. Strings accepted by strconv.ParseBool, such as "true" and "0", are converted.
+func (f *FieldValidation) Bool() bool {
+	if f.Failed() {
+		return false
+	}
+	switch v := f.value.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(strings.TrimSpace(v))
+		return b
+	}
+	return false
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		if v < math.MinInt || v > math.MaxInt {
+			return 0, false
+		}
+		return int(v), true
+	case uint:
+		if v > math.MaxInt {
+			return 0, false
+		}
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		if uint64(v) > math.MaxInt {
+			return 0, false
+		}
+		return int(v), true
+	case uint64:
+		if v > math.MaxInt {
+			return 0, false
+		}
+		return int(v), true
+	case float32:
+		return toInt(float64(v))
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt || v >= math.MaxInt {
+			return 0, false
+		}
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,79 @@
+package datacop_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+func TestValue(t *testing.T) {
+	v := datacop.New()
+
+	email, ok := datacop.Value[string](v.Field("email", " ada@example.com ").
+		Transform(strings.TrimSpace).
+		Validate(is.Email, "is not valid"))
+	assert.True(t, ok)
+	assert.Equal(t, "ada@example.com", email)
+
+	// Wrong type
+	n, ok := datacop.Value[int](v.Field("age", "42"))
+	assert.False(t, ok)
+	assert.Zero(t, n)
+
+	// Failed validation
+	name, ok := datacop.Value[string](v.Field("name", "").Validate(is.Required, "is required"))
+	assert.False(t, ok)
+	assert.Empty(t, name)
+}
+
+func TestFieldValidation_Getters(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		str   string
+		int   int
+		float float64
+		bool  bool
+	}{
+		{"string", "hello", "hello", 0, 0, false},
+		{"integer string", " 42 ", " 42 ", 42, 42, false},
+		{"float string", "1.5", "1.5", 0, 1.5, false},
+		{"bool string", "true", "true", 0, 0, true},
+		{"int", 7, "", 7, 7, false},
+		{"int64", int64(-3), "", -3, -3, false},
+		{"uint8", uint8(200), "", 200, 200, false},
+		{"whole float", 12.0, "", 12, 12, false},
+		{"fractional float", 12.5, "", 0, 12.5, false},
+		{"bool", true, "", 0, 0, true},
+		{"nil", nil, "", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := datacop.New().Field("field", tt.value)
+			assert.Equal(t, tt.str, f.String())
+			assert.Equal(t, tt.int, f.Int())
+			assert.Equal(t, tt.float, f.Float())
+			assert.Equal(t, tt.bool, f.Bool())
+		})
+	}
+}
+
+func TestFieldValidation_GettersAfterFailure(t *testing.T) {
+	v := datacop.New()
+
+	f := v.Field("age", "42").Validate(is.IntString, "must be a number")
+	assert.False(t, f.Failed())
+	assert.Equal(t, 42, f.Int())
+
+	f = v.Field("age", "42").Validate(is.MinNumeric(50), "must be at least {min}")
+	assert.True(t, f.Failed())
+	assert.Zero(t, f.Int())
+	assert.Zero(t, f.Float())
+	assert.Empty(t, f.String())
+	assert.False(t, f.Bool())
+}
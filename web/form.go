@@ -0,0 +1,31 @@
+package web
+
+import (
+	"mime/multipart"
+
+	"github.com/patrickward/datacop"
+)
+
+// FormPositions records the part name of every value and file of a parsed multipart form on v
+// with SetPosition, so errors for form fields carry the part they were submitted in. Call it
+// after r.ParseMultipartForm and before validating.
+//
+// Example usage:
+//
+//	if err := r.ParseMultipartForm(32 << 20); err != nil {
+//		return err
+//	}
+//	v := datacop.New()
+//	web.FormPositions(v, r.MultipartForm)
+//	v.Field("avatar", r.MultipartForm.File["avatar"]).Validate(is.Required, "is required")
+func FormPositions(v *datacop.Validator, form *multipart.Form) {
+	if form == nil {
+		return
+	}
+	for name := range form.Value {
+		v.SetPosition(name, datacop.Position{Part: name})
+	}
+	for name := range form.File {
+		v.SetPosition(name, datacop.Position{Part: name})
+	}
+}
@@ -0,0 +1,48 @@
+package web_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/web"
+)
+
+func TestFormPositions(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("name", ""))
+	fw, err := mw.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("not an image"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest("POST", "/profile", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	require.NoError(t, r.ParseMultipartForm(1<<20))
+
+	v := datacop.New()
+	web.FormPositions(v, r.MultipartForm)
+	v.Check(false, "name", "is required")
+	v.Check(false, "avatar", "must be an image")
+	v.Check(false, "bio", "is required")
+
+	errs := v.OrderedErrors()
+	require.Len(t, errs, 3)
+	assert.Equal(t, &datacop.Position{Part: "name"}, errs[0].Position)
+	assert.Equal(t, &datacop.Position{Part: "avatar"}, errs[1].Position)
+	assert.Nil(t, errs[2].Position)
+}
+
+func TestFormPositions_NilForm(t *testing.T) {
+	v := datacop.New()
+	web.FormPositions(v, nil)
+	v.Check(false, "name", "is required")
+	assert.Nil(t, v.OrderedErrors()[0].Position)
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/is"
+)
+
+// IdempotencyHeader is the request header that carries an idempotency key
+const IdempotencyHeader = "Idempotency-Key"
+
+// KeyStore records the idempotency keys that have been used
+type KeyStore interface {
+	// Claim marks key as used for ttl and reports whether it was unused. Claims must be atomic:
+	// of two concurrent claims of the same key, only one may succeed.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release frees a claimed key so that it can be used again
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyKey returns middleware that requires each request to carry a UUID in its
+// Idempotency-Key header that has not been used within ttl. The key is claimed in store before
+// the next handler runs, so a retried request is rejected even if the first attempt is still in
+// progress. If the handler fails with a 5xx status or panics, the key is released so that the
+// request can be retried.
+//
+// Keys are scoped by the request method, the route (the ServeMux pattern, or the URL path when
+// there is none) and the caller returned by caller, so the same key can be used on different
+// routes and by different callers. A nil caller identifies callers by their remote IP address.
+//
+// Missing or malformed keys are reported with WriteError as 422 Unprocessable Entity, and
+// reused keys as 409 Conflict with the code datacop.CodeConflict. Errors from the store are
+// reported as 500 Internal Server Error.
+//
+// Example usage:
+//
+//	keys := web.NewMemoryKeyStore()
+//	mux.Handle("POST /payments", web.IdempotencyKey(keys, 24*time.Hour, accountID)(createPayment))
+func IdempotencyKey(store KeyStore, ttl time.Duration, caller func(*http.Request) string) func(http.Handler) http.Handler {
+	if caller == nil {
+		caller = remoteHost
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get(IdempotencyHeader))
+
+			v := datacop.New()
+			if v.CheckCode(key != "", IdempotencyHeader, "required", "is required") {
+				v.CheckCode(is.UUID(key), IdempotencyHeader, "format", "must be a UUID")
+			}
+			if WriteError(w, r, v.ErrOrNil()) {
+				return
+			}
+
+			route := r.Pattern
+			if route == "" {
+				route = r.URL.Path
+			}
+			scoped := strings.Join([]string{r.Method, route, caller(r), strings.ToLower(key)}, "\x00")
+
+			claimed, err := store.Claim(r.Context(), scoped, ttl)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if !claimed {
+				v.AddCodedError(IdempotencyHeader, datacop.CodeConflict, "has already been used")
+				WriteError(w, r, v)
+				return
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				if p := recover(); p != nil {
+					_ = store.Release(context.WithoutCancel(r.Context()), scoped)
+					panic(p)
+				}
+				if rec.status >= http.StatusInternalServerError {
+					_ = store.Release(context.WithoutCancel(r.Context()), scoped)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// remoteHost returns the IP address of the client that sent r
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// statusRecorder records the status code written through a ResponseWriter
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	wrote  bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wrote {
+		w.status, w.wrote = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// MemoryKeyStore is a KeyStore that keeps keys in memory. It suits tests and applications that
+// run a single instance; use a shared store, such as a database table, otherwise.
+type MemoryKeyStore struct {
+	// Now returns the current time used to expire keys; nil uses time.Now
+	Now func() time.Time
+
+	mu     sync.Mutex
+	keys   map[string]time.Time // expiry by key
+	claims int                  // claims since expired keys were last removed
+}
+
+// sweepInterval is the number of claims between removals of expired keys
+const sweepInterval = 1024
+
+// NewMemoryKeyStore creates an empty in-memory key store
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{keys: make(map[string]time.Time)}
+}
+
+// Claim marks key as used for ttl and reports whether it was unused or had expired
+func (s *MemoryKeyStore) Claim(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	t := now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil {
+		s.keys = make(map[string]time.Time)
+	}
+	if s.claims++; s.claims >= sweepInterval {
+		s.claims = 0
+		for k, expiry := range s.keys {
+			if !t.Before(expiry) {
+				delete(s.keys, k)
+			}
+		}
+	}
+
+	if expiry, ok := s.keys[key]; ok && t.Before(expiry) {
+		return false, nil
+	}
+	s.keys[key] = t.Add(ttl)
+	return true, nil
+}
+
+// Release frees key so that it can be claimed again
+func (s *MemoryKeyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
@@ -0,0 +1,156 @@
+package web_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/web"
+)
+
+type failingStore struct{}
+
+func (failingStore) Claim(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func (failingStore) Release(context.Context, string) error {
+	return errors.New("store unavailable")
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	calls := 0
+	handler := web.IdempotencyKey(web.NewMemoryKeyStore(), time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	send := func(key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/payments", nil)
+		if key != "" {
+			r.Header.Set(web.IdempotencyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec
+	}
+
+	key := "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	assert.Equal(t, http.StatusCreated, send(key).Code)
+
+	rec := send(key)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problem datacop.ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, []datacop.DetailedError{
+		{Field: web.IdempotencyHeader, Code: datacop.CodeConflict, Message: "has already been used"},
+	}, problem.Errors)
+
+	// Keys are compared case-insensitively
+	assert.Equal(t, http.StatusConflict, send("F47AC10B-58CC-4372-A567-0E02B2C3D479").Code)
+
+	rec = send("")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"required"`)
+
+	rec = send("not-a-uuid")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"format"`)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyKey_Release(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	handler := web.IdempotencyKey(web.NewMemoryKeyStore(), time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Panic") != "" {
+			panic("boom")
+		}
+		w.WriteHeader(status)
+	}))
+
+	send := func(panics bool) int {
+		r := httptest.NewRequest("POST", "/payments", nil)
+		r.Header.Set(web.IdempotencyHeader, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		if panics {
+			r.Header.Set("X-Panic", "1")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	assert.PanicsWithValue(t, "boom", func() { send(true) })
+	assert.Equal(t, http.StatusServiceUnavailable, send(false), "a panic releases the key")
+
+	status = http.StatusCreated
+	assert.Equal(t, http.StatusCreated, send(false), "a 5xx response releases the key")
+	assert.Equal(t, http.StatusConflict, send(false))
+}
+
+func TestIdempotencyKey_Scope(t *testing.T) {
+	caller := func(r *http.Request) string { return r.Header.Get("X-Account") }
+	handler := web.IdempotencyKey(web.NewMemoryKeyStore(), time.Hour, caller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	send := func(method, path, account string) int {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set(web.IdempotencyHeader, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		r.Header.Set("X-Account", account)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusCreated, send("POST", "/payments", "a"))
+	assert.Equal(t, http.StatusCreated, send("PUT", "/payments", "a"))
+	assert.Equal(t, http.StatusCreated, send("POST", "/refunds", "a"))
+	assert.Equal(t, http.StatusCreated, send("POST", "/payments", "b"))
+	assert.Equal(t, http.StatusConflict, send("POST", "/payments", "a"))
+}
+
+func TestIdempotencyKey_StoreError(t *testing.T) {
+	handler := web.IdempotencyKey(failingStore{}, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not run")
+	}))
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set(web.IdempotencyHeader, "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestMemoryKeyStore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := web.NewMemoryKeyStore()
+	store.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	claimed, err := store.Claim(ctx, "a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, _ = store.Claim(ctx, "a", time.Minute)
+	assert.False(t, claimed)
+
+	claimed, _ = store.Claim(ctx, "b", time.Minute)
+	assert.True(t, claimed)
+
+	now = now.Add(time.Minute)
+	claimed, _ = store.Claim(ctx, "a", time.Minute)
+	assert.True(t, claimed, "expired keys can be claimed again")
+
+	require.NoError(t, store.Release(ctx, "b"))
+	claimed, _ = store.Claim(ctx, "b", time.Minute)
+	assert.True(t, claimed, "released keys can be claimed again")
+}
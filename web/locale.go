@@ -0,0 +1,81 @@
+package web
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AcceptedLocales returns the language tags of the request's Accept-Language header, ordered
+// from most to least preferred by quality value. Tags with a quality of zero, the "*" wildcard,
+// and malformed entries are left out.
+//
+// Example usage:
+// // Accept-Language: fr-CA, fr;q=0.9, en;q=0.5
+// web.AcceptedLocales(r) // returns ["fr-CA", "fr", "en"]
+func AcceptedLocales(r *http.Request) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, header := range r.Header.Values("Accept-Language") {
+		for _, part := range strings.Split(header, ",") {
+			tag, params, _ := strings.Cut(part, ";")
+			tag = strings.TrimSpace(tag)
+			if tag == "" || tag == "*" || !validTag(tag) {
+				continue
+			}
+
+			q := 1.0
+			if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil || parsed < 0 || parsed > 1 {
+					continue
+				}
+				q = parsed
+			}
+			if q == 0 {
+				continue
+			}
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	locales := make([]string, len(tags))
+	for i, t := range tags {
+		locales[i] = t.tag
+	}
+	return locales
+}
+
+// LocaleFromRequest returns the caller's most preferred language tag from the Accept-Language
+// header, or an empty string if the header names none
+func LocaleFromRequest(r *http.Request) string {
+	if locales := AcceptedLocales(r); len(locales) > 0 {
+		return locales[0]
+	}
+	return ""
+}
+
+// validTag reports whether tag looks like a BCP 47 language tag: alphanumeric subtags of one to
+// eight characters separated by hyphens
+func validTag(tag string) bool {
+	for _, sub := range strings.Split(tag, "-") {
+		if len(sub) == 0 || len(sub) > 8 {
+			return false
+		}
+		for _, c := range sub {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
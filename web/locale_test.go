@@ -0,0 +1,46 @@
+package web_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/patrickward/datacop/web"
+)
+
+func TestAcceptedLocales(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{"single tag", "fr", []string{"fr"}},
+		{"ordered by quality", "en;q=0.5, fr-CA, fr;q=0.9", []string{"fr-CA", "fr", "en"}},
+		{"equal quality keeps header order", "de, nl", []string{"de", "nl"}},
+		{"whitespace around parameters", "fr ; q = 0.7 , en", []string{"en", "fr"}},
+		{"zero quality is excluded", "fr, en;q=0", []string{"fr"}},
+		{"wildcard is excluded", "*, es;q=0.8", []string{"es"}},
+		{"invalid quality is excluded", "fr;q=abc, en;q=2, de", []string{"de"}},
+		{"malformed tag is excluded", "fr_FR!, en", []string{"en"}},
+		{"empty header", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			assert.Equal(t, tt.want, web.AcceptedLocales(r))
+		})
+	}
+}
+
+func TestLocaleFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	assert.Equal(t, "", web.LocaleFromRequest(r))
+
+	r.Header.Set("Accept-Language", "en-GB;q=0.8, pt-BR")
+	assert.Equal(t, "pt-BR", web.LocaleFromRequest(r))
+}
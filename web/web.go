@@ -0,0 +1,127 @@
+// Package web provides HTTP helpers for datacop: locale negotiation and rendering validation
+// errors as RFC 7807 problem details in the caller's language.
+//
+// Install Middleware with a message catalog and render errors with WriteError or WriteProblem;
+// messages are translated into the negotiated locale without any handler code.
+//
+// Example usage:
+//
+//	catalog, _ := i18n.LoadCatalog(locales, "locales/*")
+//	mux.Handle("/signup", web.Middleware(catalog)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//		if err := validateSignup(r); web.WriteError(w, r, err) {
+//			return
+//		}
+//		...
+//	})))
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/i18n"
+)
+
+type contextKey int
+
+const (
+	localeKey contextKey = iota
+	catalogKey
+)
+
+// Middleware negotiates the locale of each request from its Accept-Language header and stores
+// it in the request context along with the catalog. The negotiated locale is the most preferred
+// accepted locale that the catalog has messages for, directly or through its base language, or
+// the catalog's default locale otherwise.
+func Middleware(catalog *i18n.Catalog) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), catalogKey, catalog)
+			ctx = WithLocale(ctx, negotiate(catalog, AcceptedLocales(r)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// negotiate returns the first accepted locale that the catalog supports
+func negotiate(catalog *i18n.Catalog, accepted []string) string {
+	for _, locale := range accepted {
+		chain := catalog.Fallbacks(locale)
+		// The last entry is the default locale, which is only chosen if nothing else matches
+		for _, l := range chain[:len(chain)-1] {
+			if catalog.Has(l) {
+				return locale
+			}
+		}
+	}
+	return catalog.Default
+}
+
+// WithLocale returns a copy of ctx carrying locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale returns the locale stored in ctx by Middleware or WithLocale, or an empty string
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey).(string)
+	return locale
+}
+
+// Localize translates the errors of v into the locale of the request, using the catalog
+// installed by Middleware. It returns v unchanged if the request has no catalog.
+func Localize(r *http.Request, v *datacop.Validator) *datacop.Validator {
+	catalog, ok := r.Context().Value(catalogKey).(*i18n.Catalog)
+	if !ok {
+		return v
+	}
+	return catalog.Localize(v, Locale(r.Context()))
+}
+
+// WriteProblem writes the errors of v as RFC 7807 problem details with the given status,
+// translated into the locale of the request
+func WriteProblem(w http.ResponseWriter, r *http.Request, v *datacop.Validator, status int) {
+	if locale := Locale(r.Context()); locale != "" {
+		w.Header().Set("Content-Language", locale)
+	}
+	w.Header().Set("Content-Type", datacop.ProblemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Localize(r, v).ToProblemDetails(status))
+}
+
+// WriteError writes a problem response if err is a validation error with errors, and reports
+// whether it did. The status is chosen by SuggestStatus. Other errors, including nil, are left to
+// the caller.
+//
+// Example usage:
+//
+//	v := datacop.New()
+//	// ... checks ...
+//	if web.WriteError(w, r, v.ErrOrNil()) {
+//		return
+//	}
+func WriteError(w http.ResponseWriter, r *http.Request, err error) bool {
+	v, ok := datacop.AsValidator(err)
+	if !ok || !v.HasErrors() {
+		return false
+	}
+	WriteProblem(w, r, v, SuggestStatus(v))
+	return true
+}
+
+// SuggestStatus returns the HTTP status that best describes the errors of v: 409 Conflict if any
+// error has the code datacop.CodeConflict, 422 Unprocessable Entity for other errors, and 200 OK
+// if there are none.
+func SuggestStatus(v *datacop.Validator) int {
+	if !v.HasErrors() {
+		return http.StatusOK
+	}
+	for _, e := range v.OrderedErrors() {
+		if e.Code == datacop.CodeConflict {
+			return http.StatusConflict
+		}
+	}
+	return http.StatusUnprocessableEntity
+}
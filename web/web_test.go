@@ -0,0 +1,119 @@
+package web_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/patrickward/datacop"
+	"github.com/patrickward/datacop/i18n"
+	"github.com/patrickward/datacop/web"
+)
+
+func testCatalog() *i18n.Catalog {
+	catalog := i18n.NewCatalog()
+	catalog.Add("en", map[string]string{"required": "is required"})
+	catalog.Add("fr", map[string]string{"required": "est obligatoire"})
+	catalog.Add("fr-CA", map[string]string{"email.required": "entrez votre courriel"})
+	return catalog
+}
+
+func TestMiddleware_NegotiatesLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"supported locale", "fr", "fr"},
+		{"regional locale with messages", "fr-CA", "fr-CA"},
+		{"regional locale falls back to language", "fr-BE", "fr-BE"},
+		{"first supported locale wins", "de, fr;q=0.8, en;q=0.5", "fr"},
+		{"unsupported locale uses default", "de", "en"},
+		{"no header uses default", "", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := web.Middleware(testCatalog())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = web.Locale(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	handler := web.Middleware(testCatalog())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := datacop.New()
+		v.AddCodedError("name", "required", "is required")
+		v.AddCodedError("email", "required", "is required")
+		if web.WriteError(w, r, v.ErrOrNil()) {
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Accept-Language", "fr-CA")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, datacop.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "fr-CA", rec.Header().Get("Content-Language"))
+
+	var problem datacop.ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, []datacop.DetailedError{
+		{Field: "name", Code: "required", Message: "est obligatoire"},
+		{Field: "email", Code: "required", Message: "entrez votre courriel"},
+	}, problem.Errors)
+}
+
+func TestWriteError_NotValidation(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	assert.False(t, web.WriteError(rec, r, nil))
+	assert.False(t, web.WriteError(rec, r, errors.New("database unavailable")))
+	assert.False(t, web.WriteError(rec, r, datacop.New()))
+	assert.Equal(t, 0, rec.Body.Len())
+}
+
+func TestSuggestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusOK, web.SuggestStatus(datacop.New()))
+
+	v := datacop.New()
+	v.AddCodedError("name", "required", "is required")
+	assert.Equal(t, http.StatusUnprocessableEntity, web.SuggestStatus(v))
+
+	v.AddCodedError("email", datacop.CodeConflict, "is already registered")
+	assert.Equal(t, http.StatusConflict, web.SuggestStatus(v))
+}
+
+func TestWriteProblem_WithoutMiddleware(t *testing.T) {
+	v := datacop.New()
+	v.AddCodedError("name", "required", "is required")
+
+	rec := httptest.NewRecorder()
+	web.WriteProblem(rec, httptest.NewRequest("GET", "/", nil), v, http.StatusBadRequest)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Language"))
+
+	var problem datacop.ProblemDetails
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, "is required", problem.Errors[0].Message)
+}